@@ -27,9 +27,8 @@ func parseWithUpdates(basePath string) {
 func parseBaseOnly(basePath string) {
 	parser := s57.NewParser()
 
-	opts := s57.ParseOptions{
-		ApplyUpdates: false,
-	}
+	opts := s57.DefaultParseOptions()
+	opts.ApplyUpdates = false
 
 	chart, err := parser.ParseWithOptions("GB5X01SW.000", opts)
 	if err != nil {
@@ -8,10 +8,8 @@ import (
 )
 
 type ChartInfo struct {
-	Path     string
-	Name     string
-	Bounds   s57.Bounds
-	Features int
+	Path    string
+	Summary s57.ChartSummary
 }
 
 func buildCatalog(chartPaths []string) ([]ChartInfo, error) {
@@ -25,13 +23,7 @@ func buildCatalog(chartPaths []string) ([]ChartInfo, error) {
 			continue
 		}
 
-		info := ChartInfo{
-			Path:     path,
-			Name:     chart.DatasetName(),
-			Bounds:   chart.Bounds(),
-			Features: chart.FeatureCount(),
-		}
-		catalog = append(catalog, info)
+		catalog = append(catalog, ChartInfo{Path: path, Summary: chart.Summary()})
 	}
 
 	return catalog, nil
@@ -41,7 +33,7 @@ func buildCatalog(chartPaths []string) ([]ChartInfo, error) {
 func findChartsForLocation(catalog []ChartInfo, lon, lat float64) []ChartInfo {
 	var matches []ChartInfo
 	for _, info := range catalog {
-		if info.Bounds.Contains(lon, lat) {
+		if info.Summary.Bounds.Contains(lon, lat) {
 			matches = append(matches, info)
 		}
 	}
@@ -60,12 +52,14 @@ func main() {
 	fmt.Printf("Catalog contains %d charts\n\n", len(catalog))
 
 	for _, info := range catalog {
-		fmt.Printf("Chart: %s\n", info.Name)
+		summary := info.Summary
+		fmt.Printf("Chart: %s\n", summary.DatasetName)
 		fmt.Printf("  Path: %s\n", info.Path)
-		fmt.Printf("  Features: %d\n", info.Features)
+		fmt.Printf("  Edition: %s, update %s\n", summary.Edition, summary.UpdateNumber)
+		fmt.Printf("  Features: %d\n", summary.FeatureCount)
 		fmt.Printf("  Bounds: [%.4f,%.4f] to [%.4f,%.4f]\n",
-			info.Bounds.MinLon, info.Bounds.MinLat,
-			info.Bounds.MaxLon, info.Bounds.MaxLat)
+			summary.Bounds.MinLon, summary.Bounds.MinLat,
+			summary.Bounds.MaxLon, summary.Bounds.MaxLat)
 	}
 
 	// Example location query
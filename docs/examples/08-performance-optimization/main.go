@@ -11,12 +11,11 @@ import (
 func parseDepthDataOnly(path string) (*s57.Chart, error) {
 	parser := s57.NewParser()
 
-	opts := s57.ParseOptions{
-		ObjectClassFilter: []string{
-			"DEPCNT", // Depth contours
-			"DEPARE", // Depth areas
-			"SOUNDG", // Soundings
-		},
+	opts := s57.DefaultParseOptions()
+	opts.ObjectClassFilter = []string{
+		"DEPCNT", // Depth contours
+		"DEPARE", // Depth areas
+		"SOUNDG", // Soundings
 	}
 
 	return parser.ParseWithOptions(path, opts)
@@ -26,10 +25,8 @@ func parseDepthDataOnly(path string) (*s57.Chart, error) {
 func parseWithValidation(path string) (*s57.Chart, error) {
 	parser := s57.NewParser()
 
-	opts := s57.ParseOptions{
-		ValidateGeometry:    true,
-		SkipUnknownFeatures: true,
-	}
+	opts := s57.DefaultParseOptions() // Validates geometry
+	opts.SkipUnknownFeatures = true
 
 	return parser.ParseWithOptions(path, opts)
 }
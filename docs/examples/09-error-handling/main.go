@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -17,6 +18,9 @@ func safeParseChart(path string) (*s57.Chart, error) {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("chart file not found: %s", path)
 		}
+		if errors.Is(err, s57.ErrNotS57) {
+			return nil, fmt.Errorf("not an S-57 chart: %s", path)
+		}
 
 		// Log detailed error
 		log.Printf("Failed to parse %s: %v", path, err)
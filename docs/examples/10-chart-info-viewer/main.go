@@ -1,15 +1,18 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/beetlebugorg/s57/pkg/s57"
 )
 
 func main() {
 	chartPath := flag.String("chart", "", "Path to S-57 chart file")
+	asJSON := flag.Bool("json", false, "Print summary as JSON")
 	flag.Parse()
 
 	if *chartPath == "" {
@@ -23,27 +26,32 @@ func main() {
 		log.Fatal(err)
 	}
 
+	summary := chart.Summary()
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(summary); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Print metadata
 	fmt.Printf("=== Chart Information ===\n")
-	fmt.Printf("Dataset: %s\n", chart.DatasetName())
-	fmt.Printf("Edition: %s\n", chart.Edition())
-	fmt.Printf("Update: %s\n", chart.UpdateNumber())
-	fmt.Printf("Features: %d\n\n", chart.FeatureCount())
+	fmt.Printf("Dataset: %s\n", summary.DatasetName)
+	fmt.Printf("Edition: %s\n", summary.Edition)
+	fmt.Printf("Update: %s\n", summary.UpdateNumber)
+	fmt.Printf("Features: %d\n\n", summary.FeatureCount)
 
 	// Print bounds
-	bounds := chart.Bounds()
+	bounds := summary.Bounds
 	fmt.Printf("=== Geographic Bounds ===\n")
 	fmt.Printf("Longitude: %.6f to %.6f\n", bounds.MinLon, bounds.MaxLon)
 	fmt.Printf("Latitude: %.6f to %.6f\n\n", bounds.MinLat, bounds.MaxLat)
 
-	// Count features by type
-	counts := make(map[string]int)
-	for _, f := range chart.Features() {
-		counts[f.ObjectClass()]++
-	}
-
 	fmt.Printf("=== Feature Types ===\n")
-	for class, count := range counts {
+	for class, count := range summary.ObjectClassCounts {
 		fmt.Printf("%-10s: %d\n", class, count)
 	}
 }
@@ -0,0 +1,180 @@
+package parser
+
+// attf.go - ATTF subfield decoding driven by the DDR format controls
+//
+// ATTF is a repeating group of ATTL (attribute label) and ATVL (attribute value).
+// ENC producers almost always encode it as (b12,A): a 2-byte binary label and a
+// text value terminated by the unit separator. ISO 8211 lets the DDR declare other
+// implementations, including binary ATVL (e.g. b14 for a 4-byte unsigned integer
+// or b48 for an 8-byte float) and fixed-width text, so the format controls of the
+// ATTF field are consulted instead of assuming ASCII.
+//
+// Binary values are decoded to their decimal text form, the same representation
+// ASCII-encoded values have, so attribute consumers see one format.
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/beetlebugorg/iso8211/pkg/iso8211"
+)
+
+const (
+	unitTerminator  = 0x1F // ISO 8211 subfield delimiter
+	fieldTerminator = 0x1E // ISO 8211 field delimiter
+)
+
+// subfieldFormat is one ISO 8211 format control, e.g. A, I(5) or b14
+type subfieldFormat struct {
+	kind       byte // 'A', 'I', 'R' (text), 'b' (binary) or 'B' (bit string)
+	binaryType byte // For 'b': 1=unsigned int, 2=signed int, 4=floating point
+	width      int  // Bytes for 'b'/'B', characters for text; 0 = variable (delimited)
+}
+
+// attributeFormat holds the formats of the ATTL and ATVL subfields of ATTF
+type attributeFormat struct {
+	label subfieldFormat
+	value subfieldFormat
+}
+
+// defaultAttributeFormat is the (b12,A) encoding used by ENCs
+var defaultAttributeFormat = attributeFormat{
+	label: subfieldFormat{kind: 'b', binaryType: 1, width: 2},
+	value: subfieldFormat{kind: 'A'},
+}
+
+// attributeFormatFromDDR returns the ATTL/ATVL formats declared for a field
+// Falls back to defaultAttributeFormat when the DDR declares none or an unsupported one
+func attributeFormatFromDDR(ddr *iso8211.DataDescriptiveRecord, tag string) attributeFormat {
+	if ddr == nil {
+		return defaultAttributeFormat
+	}
+	control, ok := ddr.FieldControls[tag]
+	if !ok {
+		return defaultAttributeFormat
+	}
+
+	formats, ok := parseFormatControls(control.FormatControls)
+	if !ok || len(formats) != 2 {
+		return defaultAttributeFormat
+	}
+	return attributeFormat{label: formats[0], value: formats[1]}
+}
+
+// parseFormatControls parses ISO 8211 format controls such as "(b12,A)" or
+// "(A(3),2I(5))" into one format per subfield. Repeat counts are expanded.
+func parseFormatControls(controls string) ([]subfieldFormat, bool) {
+	controls = strings.TrimSpace(controls)
+	for len(controls) >= 2 && controls[0] == '(' && controls[len(controls)-1] == ')' {
+		controls = strings.TrimSpace(controls[1 : len(controls)-1])
+	}
+	if controls == "" {
+		return nil, false
+	}
+
+	var formats []subfieldFormat
+	for _, token := range strings.Split(controls, ",") {
+		token = strings.TrimSpace(token)
+
+		// Optional repeat count
+		repeat := 1
+		digits := 0
+		for digits < len(token) && token[digits] >= '0' && token[digits] <= '9' {
+			digits++
+		}
+		if digits > 0 {
+			repeat, _ = strconv.Atoi(token[:digits])
+			token = token[digits:]
+		}
+		if token == "" {
+			return nil, false
+		}
+
+		format := subfieldFormat{kind: token[0]}
+		rest := token[1:]
+		switch format.kind {
+		case 'b':
+			// b<type><width>, e.g. b12 = 2-byte unsigned integer
+			if len(rest) < 2 {
+				return nil, false
+			}
+			format.binaryType = rest[0] - '0'
+			width, err := strconv.Atoi(rest[1:])
+			if err != nil {
+				return nil, false
+			}
+			format.width = width
+		case 'A', 'I', 'R', 'B':
+			if rest != "" {
+				if rest[0] != '(' || rest[len(rest)-1] != ')' {
+					return nil, false
+				}
+				width, err := strconv.Atoi(rest[1 : len(rest)-1])
+				if err != nil {
+					return nil, false
+				}
+				format.width = width
+				if format.kind == 'B' {
+					format.width = (width + 7) / 8 // Bits to bytes
+				}
+			}
+		default:
+			return nil, false
+		}
+
+		for i := 0; i < repeat; i++ {
+			formats = append(formats, format)
+		}
+	}
+	return formats, true
+}
+
+// read reads one subfield value from data at offset
+// Returns the value as text and the offset of the next subfield
+func (f subfieldFormat) read(data []byte, offset int) (string, int, bool) {
+	if f.kind == 'b' || f.kind == 'B' || f.width > 0 {
+		end := offset + f.width
+		if f.width <= 0 || end > len(data) {
+			return "", len(data), false
+		}
+		raw := data[offset:end]
+		if f.kind == 'b' || f.kind == 'B' {
+			value, ok := decodeBinary(raw, f.binaryType)
+			return value, end, ok
+		}
+		return strings.TrimSpace(string(raw)), end, true
+	}
+
+	// Variable-width text, terminated by the unit separator or end of field
+	end := offset
+	for end < len(data) && data[end] != unitTerminator && data[end] != fieldTerminator {
+		end++
+	}
+	return string(data[offset:end]), end + 1, true
+}
+
+// decodeBinary decodes a little-endian ISO 8211 binary subfield to decimal text
+func decodeBinary(raw []byte, binaryType byte) (string, bool) {
+	var bits uint64
+	for i := len(raw) - 1; i >= 0; i-- {
+		bits = bits<<8 | uint64(raw[i])
+	}
+
+	switch binaryType {
+	case 2: // Signed integer: sign-extend from the subfield width
+		shift := 64 - 8*uint(len(raw))
+		return strconv.FormatInt(int64(bits<<shift)>>shift, 10), true
+	case 4: // IEEE floating point
+		switch len(raw) {
+		case 4:
+			return strconv.FormatFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(raw))), 'f', -1, 32), true
+		case 8:
+			return strconv.FormatFloat(math.Float64frombits(bits), 'f', -1, 64), true
+		}
+		return "", false
+	default: // Unsigned integer (type 1) and bit strings
+		return strconv.FormatUint(bits, 10), true
+	}
+}
@@ -0,0 +1,228 @@
+package parser
+
+// bounds.go - Coverage extent without a full parse
+//
+// Building a coverage map of thousands of cells only needs each cell's extent.
+// The ISO 8211 reader decodes every record of a file into its own field map;
+// here the records are walked in memory instead, and only the M_COVR feature
+// records, the spatial records they reference and the DSPM are decoded.
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+)
+
+// mcovrObjectClass is the OBJL code of M_COVR (coverage) meta features
+const mcovrObjectClass = 302
+
+// Extent is a geographic bounding box in decimal degrees
+type Extent struct {
+	MinLon, MinLat, MaxLon, MaxLat float64
+}
+
+// extend grows the extent to include a position
+func (e *Extent) extend(lon, lat float64, empty bool) {
+	if empty {
+		*e = Extent{MinLon: lon, MinLat: lat, MaxLon: lon, MaxLat: lat}
+		return
+	}
+	e.MinLon = math.Min(e.MinLon, lon)
+	e.MinLat = math.Min(e.MinLat, lat)
+	e.MaxLon = math.Max(e.MaxLon, lon)
+	e.MaxLat = math.Max(e.MaxLat, lat)
+}
+
+// rawSpatial holds the undecoded coordinate and pointer fields of a spatial record
+type rawSpatial struct {
+	coords []byte // SG2D or SG3D field
+	stride int    // Bytes per coordinate: 8 for SG2D, 12 for SG3D
+	vrpt   []byte // VRPT field
+}
+
+// ExtractBounds returns the extent of an S-57 file's data coverage without
+// decoding its features.
+//
+// The extent is the bounding box of the cell's M_COVR coverage areas, the same
+// area Chart.Bounds reports for a parsed cell. A file without M_COVR gives the
+// bounding box of all its spatial records (nodes and edges) instead. Update
+// files are neither discovered nor applied.
+func ExtractBounds(filename string) (Extent, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return Extent{}, err
+	}
+
+	params := defaultDatasetParams()
+	var coverRefs []spatialRef
+	spatials := make(map[spatialKey]rawSpatial)
+	hasDSID, hasDSPM := false, false
+
+	err = walkRecords(data, func(record rawRecord) {
+		if _, ok := record.field("DSID"); ok {
+			hasDSID = true
+		}
+		if dspm, ok := record.field("DSPM"); ok && !hasDSPM {
+			params, hasDSPM = parseDSPM(dspm), true
+		}
+
+		if frid, _ := record.field("FRID"); len(frid) >= 9 && frid[0] == 100 {
+			if binary.LittleEndian.Uint16(frid[7:9]) == mcovrObjectClass {
+				fspt, _ := record.field("FSPT")
+				coverRefs = append(coverRefs, parseSpatialPointers(fspt)...)
+			}
+			return
+		}
+
+		if vrid, _ := record.field("VRID"); len(vrid) >= 5 {
+			raw := rawSpatial{stride: 8}
+			raw.coords, _ = record.field("SG2D")
+			raw.vrpt, _ = record.field("VRPT")
+			if sg3d, ok := record.field("SG3D"); ok {
+				raw.coords, raw.stride = sg3d, 12
+			}
+			key := spatialKey{RCNM: int(vrid[0]), RCID: int64(binary.LittleEndian.Uint32(vrid[1:5]))}
+			spatials[key] = raw
+		}
+	})
+	if err != nil {
+		return Extent{}, fmt.Errorf("%w: %w", ErrNotS57, err)
+	}
+	if !hasDSID {
+		return Extent{}, fmt.Errorf("%s: %w", filename, ErrMissingDSID)
+	}
+
+	var extent Extent
+	empty := true
+	addCoords := func(raw rawSpatial) {
+		for i := 0; i+8 <= len(raw.coords); i += raw.stride {
+			lat := convertCoordinate(int32(binary.LittleEndian.Uint32(raw.coords[i:i+4])), params.COMF)
+			lon := convertCoordinate(int32(binary.LittleEndian.Uint32(raw.coords[i+4:i+8])), params.COMF)
+			extent.extend(lon, lat, empty)
+			empty = false
+		}
+	}
+
+	// Coverage areas: faces point to edges, and edges to their end nodes,
+	// whose positions are not repeated in the edge's own coordinates
+	visited := make(map[spatialKey]bool)
+	var visit func(key spatialKey)
+	visit = func(key spatialKey) {
+		raw, ok := spatials[key]
+		if !ok || visited[key] {
+			return
+		}
+		visited[key] = true
+		addCoords(raw)
+		for _, ptr := range parseVectorPointers(raw.vrpt) {
+			visit(spatialKey{RCNM: ptr.TargetRCNM, RCID: ptr.TargetRCID})
+		}
+	}
+	for _, ref := range coverRefs {
+		if ref.RCNM != 0 {
+			visit(spatialKey{RCNM: ref.RCNM, RCID: ref.RCID})
+			continue
+		}
+		for _, rcnm := range []spatialType{spatialTypeFace, spatialTypeEdge, spatialTypeConnectedNode, spatialTypeIsolatedNode} {
+			visit(spatialKey{RCNM: int(rcnm), RCID: ref.RCID})
+		}
+	}
+
+	// No coverage: fall back to the extent of all spatial data
+	if empty {
+		for _, raw := range spatials {
+			addCoords(raw)
+		}
+	}
+	if empty {
+		return Extent{}, fmt.Errorf("%s: no coordinates in dataset", filename)
+	}
+	return extent, nil
+}
+
+// rawRecord is an undecoded ISO 8211 data record held in memory
+type rawRecord struct {
+	directory    []byte // Directory entries, without the field terminator
+	fieldArea    []byte
+	sizeOfTag    int
+	sizeOfLength int
+	entrySize    int
+}
+
+// field returns the value of the field with the given tag, without the field
+// terminator and sharing the record's memory. Returns false if the record has
+// no such field or its directory entry is malformed.
+func (r rawRecord) field(tag string) ([]byte, bool) {
+	for i := 0; i+r.entrySize <= len(r.directory); i += r.entrySize {
+		entry := r.directory[i : i+r.entrySize]
+		if string(entry[:r.sizeOfTag]) != tag {
+			continue
+		}
+		length, ok1 := asciiInt(entry[r.sizeOfTag : r.sizeOfTag+r.sizeOfLength])
+		position, ok2 := asciiInt(entry[r.sizeOfTag+r.sizeOfLength:])
+		if !ok1 || !ok2 || position+length > len(r.fieldArea) {
+			return nil, false
+		}
+		value := r.fieldArea[position : position+length]
+		if len(value) > 0 && value[len(value)-1] == 0x1E {
+			value = value[:len(value)-1]
+		}
+		return value, true
+	}
+	return nil, false
+}
+
+// walkRecords calls fn with every data record of an ISO 8211 file held in
+// memory, skipping the DDR. Only record leaders are validated; fields are
+// located on request.
+func walkRecords(data []byte, fn func(record rawRecord)) error {
+	for offset, first := 0, true; offset < len(data); first = false {
+		if offset+24 > len(data) {
+			return fmt.Errorf("truncated record leader at offset %d", offset)
+		}
+		leader := data[offset : offset+24]
+		length, ok1 := asciiInt(leader[0:5])
+		fieldAreaStart, ok2 := asciiInt(leader[12:17])
+		sizeOfLength, ok3 := asciiInt(leader[20:21])
+		sizeOfPosition, ok4 := asciiInt(leader[21:22])
+		sizeOfTag, ok5 := asciiInt(leader[23:24])
+		if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 || sizeOfTag == 0 {
+			return fmt.Errorf("invalid record leader at offset %d", offset)
+		}
+		if length < 24 || offset+length > len(data) || fieldAreaStart < 25 || fieldAreaStart > length {
+			return fmt.Errorf("invalid record length at offset %d", offset)
+		}
+		record := data[offset : offset+length]
+		offset += length
+		if first {
+			continue // DDR: field descriptions, no data
+		}
+
+		fn(rawRecord{
+			directory:    record[24 : fieldAreaStart-1],
+			fieldArea:    record[fieldAreaStart:],
+			sizeOfTag:    sizeOfTag,
+			sizeOfLength: sizeOfLength,
+			entrySize:    sizeOfTag + sizeOfLength + sizeOfPosition,
+		})
+	}
+	return nil
+}
+
+// asciiInt parses the ASCII digits of a leader or directory entry. As in the
+// ISO 8211 reader, spaces count as zeros.
+func asciiInt(digits []byte) (int, bool) {
+	value := 0
+	for _, c := range digits {
+		switch {
+		case c >= '0' && c <= '9':
+			value = value*10 + int(c-'0')
+		case c == ' ':
+			value *= 10
+		default:
+			return 0, false
+		}
+	}
+	return value, true
+}
@@ -0,0 +1,70 @@
+package parser
+
+// catalogue.go - Supplementary object and attribute catalogues
+//
+// Product specifications built on S-57, such as Inland ENC (IENC), add object
+// classes and attributes that are not in the S-57 Object Catalogue. A Catalogue
+// registered through ParseOptions.Catalogue resolves those codes; codes in the
+// standard catalogue keep their standard meaning.
+
+// Catalogue holds object classes and attributes that supplement the S-57 catalogue
+type Catalogue struct {
+	objectClasses map[int]ObjectClassDef
+	attributes    map[int]string
+}
+
+// NewCatalogue creates an empty supplementary catalogue
+func NewCatalogue() *Catalogue {
+	return &Catalogue{
+		objectClasses: make(map[int]ObjectClassDef),
+		attributes:    make(map[int]string),
+	}
+}
+
+// AddObjectClass registers an object class by its numeric code (OBJL)
+func (c *Catalogue) AddObjectClass(def ObjectClassDef) {
+	c.objectClasses[def.Code] = def
+}
+
+// AddAttribute registers an attribute acronym by its numeric code (ATTL)
+func (c *Catalogue) AddAttribute(code int, acronym string) {
+	c.attributes[code] = acronym
+}
+
+// LookupObjectClassCode returns the supplementary entry for an object class code
+// A nil catalogue has no entries
+func (c *Catalogue) LookupObjectClassCode(code int) (ObjectClassDef, bool) {
+	if c == nil {
+		return ObjectClassDef{}, false
+	}
+	def, ok := c.objectClasses[code]
+	return def, ok
+}
+
+// ObjectClassToString converts an object class code to its acronym
+// Standard codes resolve through the S-57 catalogue first, then this catalogue
+func (c *Catalogue) ObjectClassToString(code int) (string, error) {
+	if code > 0 {
+		if _, standard := objectClassNames[code]; !standard {
+			if def, ok := c.LookupObjectClassCode(code); ok {
+				return def.Acronym, nil
+			}
+		}
+	}
+	return ObjectClassToString(code)
+}
+
+// AttributeCodeToString converts an attribute code to its acronym
+// Standard codes resolve through the S-57 catalogue first, then this catalogue
+func (c *Catalogue) AttributeCodeToString(code int) string {
+	attributeNamesOnce.Do(loadAttributeNames)
+	if name, ok := attributeNames[code]; ok {
+		return name
+	}
+	if c != nil {
+		if name, ok := c.attributes[code]; ok {
+			return name
+		}
+	}
+	return AttributeCodeToString(code)
+}
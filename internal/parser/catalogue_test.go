@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// TestCatalogue tests supplementary object class and attribute codes resolve
+func TestCatalogue(t *testing.T) {
+	catalogue := NewCatalogue()
+	catalogue.AddObjectClass(ObjectClassDef{Code: 17050, Acronym: "wtwaxs", Name: "Waterway axis", Class: "G"})
+	catalogue.AddAttribute(18001, "catbrt")
+	catalogue.AddObjectClass(ObjectClassDef{Code: 42, Acronym: "NOTDEP"}) // Standard codes win
+
+	tests := []struct {
+		code     int
+		expected string
+	}{
+		{17050, "wtwaxs"},
+		{42, "DEPARE"},
+		{17999, "OBJL_17999"},
+	}
+	for _, tt := range tests {
+		if got, err := catalogue.ObjectClassToString(tt.code); err != nil || got != tt.expected {
+			t.Errorf("ObjectClassToString(%d) = %q, %v; expected %q", tt.code, got, err, tt.expected)
+		}
+	}
+
+	// A nil catalogue behaves like the S-57 catalogue alone
+	var none *Catalogue
+	if got, _ := none.ObjectClassToString(17050); got != "OBJL_17050" {
+		t.Errorf("nil catalogue: ObjectClassToString(17050) = %q", got)
+	}
+
+	// Attribute decoding
+	var data []byte
+	data = binary.LittleEndian.AppendUint16(data, 18001)
+	data = append(data, "3"...)
+	data = append(data, unitTerminator)
+	data = binary.LittleEndian.AppendUint16(data, 87)
+	data = append(data, "5"...)
+	data = append(data, unitTerminator)
+
+	attributes := parseAttributes(data, defaultAttributeFormat, catalogue)
+	if attributes["catbrt"] != "3" || attributes["DRVAL1"] != "5" {
+		t.Errorf("Expected catbrt and DRVAL1, got %v", attributes)
+	}
+	if attributes = parseAttributes(data, defaultAttributeFormat, nil); attributes["ATTR_18001"] != "3" {
+		t.Errorf("Expected ATTR_18001 without a catalogue, got %v", attributes)
+	}
+}
+
+// TestCatalogueParse tests features with supplementary codes get catalogue names
+func TestCatalogueParse(t *testing.T) {
+	opts := DefaultParseOptions()
+	opts.ApplyUpdates = false
+	opts.Catalogue = NewCatalogue()
+	opts.Catalogue.AddObjectClass(ObjectClassDef{Code: 17050, Acronym: "wtwaxs", Class: "G"})
+
+	data, params, metadata, err := parseBaseFile("../../test/US4MD81M/US4MD81M.000", opts)
+	if err != nil {
+		t.Fatalf("Failed to parse base file: %v", err)
+	}
+
+	// Recode one depth contour as an IENC waterway axis
+	var recoded int64
+	for _, featureRec := range data.features {
+		if featureRec.ObjectClass == 43 { // DEPCNT
+			featureRec.ObjectClass = 17050
+			recoded = featureRec.ID
+			break
+		}
+	}
+	if recoded == 0 {
+		t.Fatal("Test chart has no DEPCNT feature")
+	}
+
+	opts.ObjectClassFilter = []string{"wtwaxs"}
+	chart, err := buildChart(data, metadata, params, opts)
+	if err != nil {
+		t.Fatalf("Failed to build chart: %v", err)
+	}
+	if len(chart.Features) != 1 || chart.Features[0].ID != recoded || chart.Features[0].ObjectClass != "wtwaxs" {
+		t.Errorf("Expected feature %d as wtwaxs, got %d features", recoded, len(chart.Features))
+	}
+}
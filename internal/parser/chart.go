@@ -10,6 +10,9 @@ type Chart struct {
 	params         datasetParams                 // Private - DSPM record data
 	Features       []Feature                     // Public - array of extracted features
 	spatialRecords map[spatialKey]*spatialRecord // Private - for update merging
+	structure      *StructureInfo                // Private - DSSI record data of the base cell
+	Warnings       []error                       // Public - non-fatal problems found while parsing
+	updateLog      []UpdateSummary               // Private - applied updates (ParseOptions.RecordUpdateLog)
 }
 
 // DatasetName returns the chart's dataset name (cell identifier).
@@ -117,6 +120,34 @@ func (c *Chart) CoordinateUnits() int {
 	return c.params.COUN
 }
 
+// IsBaseCell reports whether the dataset is a base cell (EXPP=1) rather than
+// an update (EXPP=2).
+func (c *Chart) IsBaseCell() bool {
+	if c.metadata == nil {
+		return false
+	}
+	return c.metadata.IsBaseCell()
+}
+
+// UsedDefaultParameters reports whether the dataset had no usable DSPM record,
+// so coordinates and soundings were scaled with the default COMF and SOMF unless
+// ParseOptions overrides replaced them; it stays true with an override.
+func (c *Chart) UsedDefaultParameters() bool {
+	return c.params.defaulted
+}
+
+// CoordinateFactor returns the coordinate multiplication factor (COMF) used to
+// scale coordinates, including any override from ParseOptions.
+func (c *Chart) CoordinateFactor() int32 {
+	return c.params.COMF
+}
+
+// SoundingFactor returns the sounding multiplication factor (SOMF) used to
+// scale sounding depths, including any override from ParseOptions.
+func (c *Chart) SoundingFactor() int32 {
+	return c.params.SOMF
+}
+
 // HorizontalDatum returns the horizontal datum code from the DSPM record.
 // S-57 §7.3.2.1 HDAT field: 2=WGS-84 (most common).
 func (c *Chart) HorizontalDatum() int {
@@ -128,3 +159,15 @@ func (c *Chart) HorizontalDatum() int {
 func (c *Chart) CompilationScale() int32 {
 	return c.params.CSCL
 }
+
+// UpdateLog returns a summary of each update file applied, in order.
+// Returns nil unless ParseOptions.RecordUpdateLog was set.
+func (c *Chart) UpdateLog() []UpdateSummary {
+	return c.updateLog
+}
+
+// StructureInfo returns the base cell's DSSI record (declared structure and counts).
+// Returns nil if the dataset has no DSSI field.
+func (c *Chart) StructureInfo() *StructureInfo {
+	return c.structure
+}
@@ -0,0 +1,79 @@
+package parser
+
+// counts.go - Verification of parsed feature counts against DSSI
+//
+// DSSI declares how many meta and geo feature records the base cell contains.
+// A feature that silently disappears between record parsing and chart
+// construction (an unresolvable topology reference skipped under
+// SkipUnknownFeatures or ContinueOnRecordError, for instance) shows up as a
+// difference between the declared and the built counts.
+//
+// Updates legitimately add and remove records, and ObjectClassFilter,
+// GeometryTypeFilter and DropInvalidPolygons legitimately drop features, so the
+// comparison is:
+//
+//	built + filtered == declared + (records after updates - base records)
+
+// featureCategory is the DSSI count a feature record contributes to
+type featureCategory string
+
+const (
+	featureCategoryMeta  featureCategory = "meta"
+	featureCategoryGeo   featureCategory = "geo"
+	featureCategoryOther featureCategory = "other" // Collection and cartographic records (not verified)
+)
+
+// categorizeFeature returns the DSSI category of an object class code.
+// Codes missing from the object catalogue are counted as geo features.
+func categorizeFeature(objectClass int) featureCategory {
+	def, ok := LookupObjectClassCode(objectClass)
+	if !ok {
+		return featureCategoryGeo
+	}
+	switch def.Class {
+	case "M":
+		return featureCategoryMeta
+	case "C", "$":
+		return featureCategoryOther
+	}
+	return featureCategoryGeo
+}
+
+// countFeatureRecords counts feature records per DSSI category
+func countFeatureRecords(features []*featureRecord) map[featureCategory]int {
+	counts := make(map[featureCategory]int)
+	for _, featureRec := range features {
+		counts[categorizeFeature(featureRec.ObjectClass)]++
+	}
+	return counts
+}
+
+// verifyFeatureCounts compares built and filtered feature counts with DSSI.
+// Returns one error per mismatched category, or nil when the counts agree or
+// the dataset has no DSSI.
+func verifyFeatureCounts(data *chartData, built, filtered map[featureCategory]int) []error {
+	if data.structure == nil {
+		return nil
+	}
+
+	current := countFeatureRecords(data.features)
+	declared := map[featureCategory]int{
+		featureCategoryMeta: data.structure.MetaRecords,
+		featureCategoryGeo:  data.structure.GeoRecords,
+	}
+
+	var errs []error
+	for _, category := range []featureCategory{featureCategoryMeta, featureCategoryGeo} {
+		expected := declared[category] + current[category] - data.baseCounts[category]
+		if built[category]+filtered[category] != expected {
+			errs = append(errs, &ErrFeatureCountMismatch{
+				Category: string(category),
+				Declared: declared[category],
+				Expected: expected,
+				Parsed:   built[category],
+				Filtered: filtered[category],
+			})
+		}
+	}
+	return errs
+}
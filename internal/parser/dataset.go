@@ -2,6 +2,8 @@ package parser
 
 import (
 	"encoding/binary"
+	"fmt"
+	"math"
 
 	"github.com/beetlebugorg/iso8211/pkg/iso8211"
 )
@@ -83,6 +85,12 @@ func (m *datasetMetadata) ExchangePurpose() string {
 	}
 }
 
+// IsBaseCell reports whether the dataset is a base cell rather than an update.
+// S-57 §7.3.1.1 EXPP: 1=new dataset, 2=revision (update); other values are neither
+func (m *datasetMetadata) IsBaseCell() bool {
+	return m.expp == 1
+}
+
 // ProductSpecification returns a human-readable product specification string.
 func (m *datasetMetadata) ProductSpecification() string {
 	switch m.prsp {
@@ -119,6 +127,23 @@ type datasetParams struct {
 	SDAT int   // Sounding datum
 	CSCL int32 // Compilation scale
 	COUN int   // Coordinate units: 1=lat/lon, 2=projected
+
+	precision int  // Decimal digits kept for lon/lat (ParseOptions.CoordinatePrecision), 0 = all
+	defaulted bool // DSPM absent or too short; COMF/SOMF are defaults rather than read from the file
+}
+
+// datumWGS84 is the DSPM HDAT value for WGS-84, the only datum permitted for ENCs
+// S-57 Appendix B.1 (ENC Product Specification) §3.1
+const datumWGS84 = 2
+
+// validateDatum rejects datasets whose coordinates are not WGS-84
+// HDAT=0 means no DSPM record was found; coordinates are assumed to be WGS-84
+func validateDatum(params datasetParams) error {
+	if params.HDAT != 0 && params.HDAT != datumWGS84 {
+		return fmt.Errorf("%w: HDAT=%d (only WGS-84, HDAT=%d, is supported)",
+			ErrUnsupportedDatum, params.HDAT, datumWGS84)
+	}
+	return nil
 }
 
 // defaultDatasetParams returns default parameters when DSPM is not found
@@ -131,8 +156,11 @@ func defaultDatasetParams() datasetParams {
 
 // extractDatasetParams extracts DSPM record parameters
 // S-57 §7.3.2.1: DSPM field structure
-func extractDatasetParams(isoFile *iso8211.ISO8211File) datasetParams {
+// ParseOptions.CoordinateFactorOverride / SoundingFactorOverride replace the file's
+// COMF / SOMF when set
+func extractDatasetParams(isoFile *iso8211.ISO8211File, opts ParseOptions) datasetParams {
 	params := defaultDatasetParams()
+	params.defaulted = true
 
 	// Look for DSPM record (Data Set Parameters)
 	for _, record := range isoFile.Records {
@@ -142,6 +170,14 @@ func extractDatasetParams(isoFile *iso8211.ISO8211File) datasetParams {
 		}
 	}
 
+	// Escape hatch for producer data with a present but wrong DSPM
+	if opts.CoordinateFactorOverride > 0 {
+		params.COMF = int32(opts.CoordinateFactorOverride)
+	}
+	if opts.SoundingFactorOverride > 0 {
+		params.SOMF = int32(opts.SoundingFactorOverride)
+	}
+
 	return params
 }
 
@@ -163,6 +199,7 @@ func extractDatasetParams(isoFile *iso8211.ISO8211File) datasetParams {
 //	COMT (variable) - Comment
 func parseDSPM(data []byte) datasetParams {
 	params := defaultDatasetParams()
+	params.defaulted = true
 
 	// Minimum size check: RCNM(1) + RCID(4) + HDAT(1) + VDAT(1) + SDAT(1) + CSCL(4)
 	//                     + DUNI(1) + HUNI(1) + PUNI(1) + COUN(1) + COMF(4) + SOMF(4) = 24 bytes
@@ -175,6 +212,7 @@ func parseDSPM(data []byte) datasetParams {
 	if rcnm != 20 {
 		return params
 	}
+	params.defaulted = false
 
 	// Extract fields at fixed offsets
 	offset := 1 // Skip RCNM
@@ -234,3 +272,79 @@ func convertCoordinate(value int32, comf int32) float64 {
 	}
 	return float64(value) / float64(comf)
 }
+
+// roundCoordinates rounds the longitude and latitude of each coordinate to
+// precision decimal digits in place. Depth values are left untouched.
+// Rounding is a pure function of the value, so coordinates shared between
+// edges and nodes (and the first and last point of a ring) stay identical.
+func roundCoordinates(coords [][]float64, precision int) {
+	if precision <= 0 {
+		return
+	}
+	scale := math.Pow(10, float64(precision))
+	for _, coord := range coords {
+		for i := 0; i < len(coord) && i < 2; i++ {
+			coord[i] = math.Round(coord[i]*scale) / scale
+		}
+	}
+}
+
+// StructureInfo holds the DSSI (Data Set Structure Information) field
+// S-57 §7.3.1.2: Declared topology level, lexical levels and record counts
+type StructureInfo struct {
+	DataStructure       int // DSTR: 1=cartographic spaghetti, 2=chain-node, 3=planar graph, 4=full topology, 255=N/A
+	ATTFLexicalLevel    int // AALL: lexical level of ATTF values (0=ASCII, 1=Latin-1)
+	NATFLexicalLevel    int // NALL: lexical level of NATF values (0, 1, or 2=UCS-2)
+	MetaRecords         int // NOMR: number of meta feature records
+	CartographicRecords int // NOCR: number of cartographic feature records
+	GeoRecords          int // NOGR: number of geo feature records
+	CollectionRecords   int // NOLR: number of collection feature records
+	IsolatedNodes       int // NOIN: number of isolated node records
+	ConnectedNodes      int // NOCN: number of connected node records
+	Edges               int // NOED: number of edge records
+	Faces               int // NOFA: number of face records
+}
+
+// extractDSSI extracts the DSSI field, which shares the DSID record
+// Returns nil if the dataset has no DSSI field
+func extractDSSI(isoFile *iso8211.ISO8211File) *StructureInfo {
+	for _, record := range isoFile.Records {
+		if dssiData, ok := record.Fields["DSSI"]; ok {
+			return parseDSSI(dssiData)
+		}
+	}
+	return nil
+}
+
+// parseDSSI parses the DSSI field per S-57 §7.3.1.2
+// Binary format:
+//
+//	DSTR (1 byte) - Data structure
+//	AALL (1 byte) - ATTF lexical level
+//	NALL (1 byte) - NATF lexical level
+//	NOMR, NOCR, NOGR, NOLR (4 bytes each) - Feature record counts
+//	NOIN, NOCN, NOED, NOFA (4 bytes each) - Spatial record counts
+func parseDSSI(data []byte) *StructureInfo {
+	// DSTR(1) + AALL(1) + NALL(1) + 8 counts * 4 bytes = 35 bytes
+	if len(data) < 35 {
+		return nil
+	}
+
+	count := func(offset int) int {
+		return int(binary.LittleEndian.Uint32(data[offset : offset+4]))
+	}
+
+	return &StructureInfo{
+		DataStructure:       int(data[0]),
+		ATTFLexicalLevel:    int(data[1]),
+		NATFLexicalLevel:    int(data[2]),
+		MetaRecords:         count(3),
+		CartographicRecords: count(7),
+		GeoRecords:          count(11),
+		CollectionRecords:   count(15),
+		IsolatedNodes:       count(19),
+		ConnectedNodes:      count(23),
+		Edges:               count(27),
+		Faces:               count(31),
+	}
+}
@@ -1,9 +1,44 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 )
 
+// Sentinel errors for common parse failures, wrapped with %w so callers can
+// test for them with errors.Is. A missing file is reported with the underlying
+// *os.PathError, so os.IsNotExist keeps working.
+var (
+	// ErrNotS57 indicates the file is not a readable ISO 8211 / S-57 dataset
+	ErrNotS57 = errors.New("not an S-57 dataset")
+
+	// ErrMissingDSID indicates the dataset has no DSID (Data Set Identification) record
+	ErrMissingDSID = errors.New("missing DSID record")
+
+	// ErrUnsupportedDatum indicates a horizontal datum other than WGS-84 (DSPM HDAT=2)
+	ErrUnsupportedDatum = errors.New("unsupported horizontal datum")
+
+	// ErrInvalidUpdateSequence indicates an update file is out of order or
+	// belongs to a different dataset
+	ErrInvalidUpdateSequence = errors.New("invalid update sequence")
+
+	// ErrMultipleDatasets indicates a file bundles more than one dataset (several
+	// DSID records); such files are read with ParseAll
+	ErrMultipleDatasets = errors.New("file contains multiple datasets")
+)
+
+// ErrStaleUpdate indicates an update file belongs to a different edition than
+// the base cell, e.g. a leftover .001 of edition 2 next to a re-issued edition 3
+// Such updates are skipped with a warning rather than applied
+type ErrStaleUpdate struct {
+	BaseEdition   string // EDTN of the base cell
+	UpdateEdition string // EDTN of the update file
+}
+
+func (e *ErrStaleUpdate) Error() string {
+	return fmt.Sprintf("update is for edition %s, base cell is edition %s (ignored)", e.UpdateEdition, e.BaseEdition)
+}
+
 // ErrInvalidCoordinate indicates coordinate out of valid bounds
 type ErrInvalidCoordinate struct {
 	Lat, Lon float64
@@ -25,12 +60,14 @@ func (e *ErrUnknownObjectClass) Error() string {
 
 // ErrInvalidGeometry indicates geometry violates S-57 rules
 type ErrInvalidGeometry struct {
-	Type   GeometryType
-	Reason string
+	FeatureID int64        // Feature being built; 0 if not known
+	Type      GeometryType // Geometry type, when HasType is set
+	HasType   bool         // Type is known (the zero Type is GeometryTypePoint)
+	Reason    string
 }
 
 func (e *ErrInvalidGeometry) Error() string {
-	if e.Type != 0 {
+	if e.HasType {
 		return fmt.Sprintf("invalid geometry (%v): %s", e.Type, e.Reason)
 	}
 	return fmt.Sprintf("invalid geometry: %s", e.Reason)
@@ -47,8 +84,23 @@ func (e *ErrMissingSpatialRecord) Error() string {
 		e.FeatureID, e.SpatialID)
 }
 
+// ErrMissingPoints indicates some of a point feature's spatial references
+// (e.g. the nodes of a SOUNDG multipoint) resolve to no coordinates, so the
+// feature was built without those points
+type ErrMissingPoints struct {
+	FeatureID  int64
+	Referenced int // FSPT references of the feature
+	Dropped    int // References that resolved to no node with coordinates
+}
+
+func (e *ErrMissingPoints) Error() string {
+	return fmt.Sprintf("feature %d: %d of %d referenced nodes are missing, their points were dropped",
+		e.FeatureID, e.Dropped, e.Referenced)
+}
+
 // ErrInvalidSpatialRecord indicates spatial record is not of expected type
 type ErrInvalidSpatialRecord struct {
+	FeatureID int64 // Feature referencing the record; 0 if not known
 	SpatialID int64
 	Reason    string
 }
@@ -56,3 +108,87 @@ type ErrInvalidSpatialRecord struct {
 func (e *ErrInvalidSpatialRecord) Error() string {
 	return fmt.Sprintf("invalid spatial record %d: %s", e.SpatialID, e.Reason)
 }
+
+// setFeatureID records the feature being built in the geometry errors of err
+// that were raised below the feature level, where its ID is not known
+func setFeatureID(err error, featureID int64) {
+	var missing *ErrMissingSpatialRecord
+	if errors.As(err, &missing) && missing.FeatureID == 0 {
+		missing.FeatureID = featureID
+	}
+	var invalidSpatial *ErrInvalidSpatialRecord
+	if errors.As(err, &invalidSpatial) && invalidSpatial.FeatureID == 0 {
+		invalidSpatial.FeatureID = featureID
+	}
+	var invalidGeometry *ErrInvalidGeometry
+	if errors.As(err, &invalidGeometry) && invalidGeometry.FeatureID == 0 {
+		invalidGeometry.FeatureID = featureID
+	}
+}
+
+// ErrPrimitiveMismatch indicates a feature's resolved geometry is not a primitive
+// permitted for its object class by the S-57 Object Catalogue
+type ErrPrimitiveMismatch struct {
+	ObjectClass string
+	Type        GeometryType
+}
+
+func (e *ErrPrimitiveMismatch) Error() string {
+	return fmt.Sprintf("object class %s does not permit %v geometry", e.ObjectClass, e.Type)
+}
+
+// ErrPrimMismatch indicates a feature's FRID PRIM contradicts the types of the
+// spatial records it references (e.g. a point feature referencing edges)
+type ErrPrimMismatch struct {
+	FeatureID int64
+	Prim      int
+	Resolved  GeometryType
+	Reason    string
+}
+
+func (e *ErrPrimMismatch) Error() string {
+	return fmt.Sprintf("feature %d: PRIM=%d contradicts its spatial records: %s (resolves to %v)",
+		e.FeatureID, e.Prim, e.Reason, e.Resolved)
+}
+
+// ErrDuplicateFeature indicates a base cell holds more than one feature record
+// with the same FOID (AGEN, FIDN, FIDS); only the copy with the highest RVER is kept
+type ErrDuplicateFeature struct {
+	AGEN           uint16
+	FIDN           uint32
+	FIDS           uint16
+	KeptVersion    int // RVER of the record kept
+	DroppedVersion int // RVER of the record dropped
+}
+
+func (e *ErrDuplicateFeature) Error() string {
+	return fmt.Sprintf("duplicate feature record (AGEN=%d, FIDN=%d, FIDS=%d): kept RVER %d, dropped RVER %d",
+		e.AGEN, e.FIDN, e.FIDS, e.KeptVersion, e.DroppedVersion)
+}
+
+// ErrNotABaseCell indicates a file passed to Parse is an update dataset
+// (e.g., a .001 file) rather than a base cell
+type ErrNotABaseCell struct {
+	Filename     string
+	UpdateNumber string
+}
+
+func (e *ErrNotABaseCell) Error() string {
+	return fmt.Sprintf("%s is an update dataset (update %s), not a base cell; parse the .000 file instead",
+		e.Filename, e.UpdateNumber)
+}
+
+// ErrFeatureCountMismatch indicates the number of features built for a DSSI
+// category differs from the count the dataset declares (see ParseOptions.VerifyCounts)
+type ErrFeatureCountMismatch struct {
+	Category string // "meta" or "geo"
+	Declared int    // Count declared by the base cell's DSSI
+	Expected int    // Declared count adjusted for records inserted or deleted by updates
+	Parsed   int    // Features built
+	Filtered int    // Features dropped by ObjectClassFilter, GeometryTypeFilter or DropInvalidPolygons
+}
+
+func (e *ErrFeatureCountMismatch) Error() string {
+	return fmt.Sprintf("%s feature count mismatch: DSSI declares %d (expected %d after updates), parsed %d, filtered %d",
+		e.Category, e.Declared, e.Expected, e.Parsed, e.Filtered)
+}
@@ -2,6 +2,8 @@ package parser
 
 import (
 	"encoding/binary"
+	"strconv"
+	"strings"
 
 	"github.com/beetlebugorg/iso8211/pkg/iso8211"
 )
@@ -11,18 +13,28 @@ import (
 type Feature struct {
 	// ID is the unique feature identifier from the FRID record
 	ID int64
+	// Agency and Subdivision complete the FOID key (AGEN, FIDN, FIDS) together with ID
+	Agency      uint16
+	Subdivision uint16
 	// ObjectClass is the S-57 object class code (e.g., "DEPCNT", "DEPARE", "BOYCAR")
 	ObjectClass string
+	// Group is the GRUP code from FRID: 1 for skin of the earth objects, 2 for
+	// all others, 255 for no group
+	Group int
 	// Geometry is the spatial representation of the feature
 	Geometry Geometry
 	// Attributes contains feature attributes as key-value pairs
 	// Common attributes: DRVAL1 (depth), COLOUR (color), OBJNAM (name)
 	Attributes map[string]interface{}
+	// RawFields contains the original ISO 8211 fields keyed by tag
+	// Only populated when ParseOptions.RetainRawFields is set
+	RawFields map[string][]byte
 }
 
 // spatialRef represents a feature-to-spatial pointer with orientation
 // S-57 §7.6.8: FSPT field contains RCID + ORNT + USAG + MASK
 type spatialRef struct {
+	RCNM        int   // Spatial record type (110, 120, 130, 140); 0 if unknown
 	RCID        int64 // Spatial record ID
 	Orientation int   // 1=Forward, 2=Reverse, 255=Null
 	Usage       int   // 1=Exterior, 2=Interior, 3=Exterior truncated
@@ -43,12 +55,13 @@ type featureRecord struct {
 	UpdateInstr   int                    // RUIN - update instruction
 	Attributes    map[string]interface{} // Feature attributes
 	SpatialRefs   []spatialRef           // References to spatial records (from FSPT) with orientation
+	RawFields     map[string][]byte      // Original ISO 8211 fields (only if RetainRawFields)
 }
 
 // parseFeatureRecord extracts feature data from an ISO 8211 record
 // Returns nil if record is not a feature record
 // S-57 §7.6.1: Feature records identified by FRID field
-func parseFeatureRecord(record *iso8211.DataRecord) *featureRecord {
+func parseFeatureRecord(record *iso8211.DataRecord, attf attributeFormat, catalogue *Catalogue) *featureRecord {
 	// Check if this is a feature record (has FRID field)
 	fridData, hasFRID := record.Fields["FRID"]
 	if !hasFRID || len(fridData) < 12 {
@@ -111,7 +124,7 @@ func parseFeatureRecord(record *iso8211.DataRecord) *featureRecord {
 
 	// Parse ATTF (Feature Record Attribute) for attributes
 	if attfData, ok := record.Fields["ATTF"]; ok {
-		featureRec.Attributes = parseAttributes(attfData)
+		featureRec.Attributes = parseAttributes(attfData, attf, catalogue)
 	}
 
 	// Parse FSPT (Feature to Spatial Pointer) for spatial references
@@ -124,30 +137,37 @@ func parseFeatureRecord(record *iso8211.DataRecord) *featureRecord {
 
 // parseAttributes extracts attributes from ATTF field
 // S-57 Appendix B.1: ATTF contains repeated attribute structures
-func parseAttributes(data []byte) map[string]interface{} {
+// The ATTL/ATVL encoding comes from the DDR format controls (see attf.go); codes outside
+// the S-57 attribute catalogue are looked up in catalogue (may be nil)
+func parseAttributes(data []byte, format attributeFormat, catalogue *Catalogue) map[string]interface{} {
 	attributes := make(map[string]interface{})
 
-	// ATTF structure: repeated [ATTL(2 bytes), ATVL(variable)]
-	// This is a simplified parser - real implementation needs subfield parsing
+	// ATTF structure: repeated [ATTL, ATVL]
 	offset := 0
-	for offset+2 <= len(data) {
-		// Extract attribute code (2 bytes)
-		attrCode := binary.LittleEndian.Uint16(data[offset : offset+2])
-		offset += 2
-
-		// Find attribute value (terminated by unit separator 0x1F or end)
-		valueEnd := offset
-		for valueEnd < len(data) && data[valueEnd] != 0x1F {
-			valueEnd++
+	for offset < len(data) {
+		// Extract attribute code
+		label, next, ok := format.label.read(data, offset)
+		if !ok {
+			break
+		}
+		attrCode, err := strconv.Atoi(strings.TrimSpace(label))
+		if err != nil {
+			break
+		}
+
+		// Extract attribute value
+		value, next, ok := format.value.read(data, next)
+		if !ok {
+			break
 		}
 
-		if valueEnd > offset {
+		if value != "" {
 			// Convert attribute code to name using attribute catalogue
-			attrName := AttributeCodeToString(int(attrCode))
-			attributes[attrName] = string(data[offset:valueEnd])
+			attrName := catalogue.AttributeCodeToString(attrCode)
+			attributes[attrName] = value
 		}
 
-		offset = valueEnd + 1 // Skip unit separator
+		offset = next
 	}
 
 	return attributes
@@ -168,7 +188,8 @@ func parseSpatialPointers(data []byte) []spatialRef {
 
 	// Binary mode: fixed 8-byte stride (not ASCII with separators)
 	for i := 0; i+7 < len(data); i += 8 {
-		// Extract NAME_RCID - this is the spatial record ID
+		// Extract NAME_RCNM and NAME_RCID - the spatial record type and ID
+		rcnm := int(data[i])
 		rcid := int64(binary.LittleEndian.Uint32(data[i+1 : i+5]))
 
 		// Extract ORNT, USAG, MASK per S-57 §4.7.3.2
@@ -177,6 +198,7 @@ func parseSpatialPointers(data []byte) []spatialRef {
 		mask := int(data[i+7])
 
 		refs = append(refs, spatialRef{
+			RCNM:        rcnm,
 			RCID:        rcid,
 			Orientation: orientation,
 			Usage:       usage,
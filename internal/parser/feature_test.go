@@ -1,7 +1,11 @@
 package parser
 
 import (
+	"encoding/binary"
+	"math"
 	"testing"
+
+	"github.com/beetlebugorg/iso8211/pkg/iso8211"
 )
 
 // TestFeatureCreation tests basic feature creation
@@ -73,3 +77,149 @@ func TestChart(t *testing.T) {
 		t.Errorf("Expected 2 features, got %d", len(chart.Features))
 	}
 }
+
+func TestLookupObjectClass(t *testing.T) {
+	tests := []struct {
+		acronym string
+		code    int
+		name    string
+		class   string
+		allowed GeometryType
+		denied  GeometryType
+	}{
+		{"DEPCNT", 43, "Depth contour", "G", GeometryTypeLineString, GeometryTypePolygon},
+		{"DEPARE", 42, "Depth area", "G", GeometryTypePolygon, GeometryTypePoint},
+		{"LIGHTS", 75, "Light", "G", GeometryTypePoint, GeometryTypeLineString},
+		{"M_COVR", 302, "Coverage", "M", GeometryTypePolygon, GeometryTypePoint},
+	}
+
+	for _, tt := range tests {
+		def, ok := LookupObjectClass(tt.acronym)
+		if !ok {
+			t.Errorf("%s: not found in object catalogue", tt.acronym)
+			continue
+		}
+		if def.Code != tt.code || def.Name != tt.name || def.Class != tt.class {
+			t.Errorf("%s: got code=%d name=%q class=%s", tt.acronym, def.Code, def.Name, def.Class)
+		}
+		if !def.Allows(tt.allowed) {
+			t.Errorf("%s should allow %s", tt.acronym, tt.allowed)
+		}
+		if def.Allows(tt.denied) {
+			t.Errorf("%s should not allow %s", tt.acronym, tt.denied)
+		}
+	}
+
+	// Every class in the name table must be in the catalogue
+	for code, acronym := range objectClassNames {
+		def, ok := LookupObjectClassCode(code)
+		if !ok || def.Acronym != acronym {
+			t.Errorf("Object class %d (%s) missing from catalogue", code, acronym)
+		}
+	}
+
+	// Reverse lookup
+	if code, err := ObjectClassToInt("SOUNDG"); err != nil || code != 129 {
+		t.Errorf("ObjectClassToInt(SOUNDG) = %d, %v", code, err)
+	}
+}
+
+// TestParseAttributesBinary tests ATTF decoding when the DDR declares binary ATVL
+func TestParseAttributesBinary(t *testing.T) {
+	ddr := &iso8211.DataDescriptiveRecord{
+		FieldControls: map[string]*iso8211.FieldControl{
+			"ATTF": {FormatControls: "(b12,b24)"},
+		},
+	}
+	format := attributeFormatFromDDR(ddr, "ATTF")
+
+	// SCAMIN (133) = 22000, VALSOU (179) = -3
+	var data []byte
+	data = binary.LittleEndian.AppendUint16(data, 133)
+	data = binary.LittleEndian.AppendUint32(data, 22000)
+	data = binary.LittleEndian.AppendUint16(data, 179)
+	data = binary.LittleEndian.AppendUint32(data, uint32(0xFFFFFFFD))
+	data = append(data, fieldTerminator)
+
+	attributes := parseAttributes(data, format, nil)
+	if got := attributes["SCAMIN"]; got != "22000" {
+		t.Errorf("SCAMIN = %v, want 22000", got)
+	}
+	if got := attributes["VALSOU"]; got != "-3" {
+		t.Errorf("VALSOU = %v, want -3", got)
+	}
+	if len(attributes) != 2 {
+		t.Errorf("Expected 2 attributes, got %d: %v", len(attributes), attributes)
+	}
+
+	// Floating point ATVL
+	format = attributeFormat{label: defaultAttributeFormat.label, value: subfieldFormat{kind: 'b', binaryType: 4, width: 8}}
+	data = binary.LittleEndian.AppendUint16(nil, 87)
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(12.5))
+	attributes = parseAttributes(data, format, nil)
+	if got := attributes["DRVAL1"]; got != "12.5" {
+		t.Errorf("DRVAL1 = %v, want 12.5", got)
+	}
+}
+
+// TestParseAttributesDefault tests the (b12,A) encoding used by ENCs
+func TestParseAttributesDefault(t *testing.T) {
+	// An empty DDR falls back to (b12,A)
+	format := attributeFormatFromDDR(&iso8211.DataDescriptiveRecord{}, "ATTF")
+	if format != defaultAttributeFormat {
+		t.Fatalf("Expected default format, got %+v", format)
+	}
+
+	var data []byte
+	data = binary.LittleEndian.AppendUint16(data, 116)
+	data = append(data, "Chesapeake Bay"...)
+	data = append(data, unitTerminator)
+	data = binary.LittleEndian.AppendUint16(data, 87)
+	data = append(data, unitTerminator) // Empty value is omitted
+	data = binary.LittleEndian.AppendUint16(data, 179)
+	data = append(data, "4.6"...)
+	data = append(data, unitTerminator, fieldTerminator)
+
+	attributes := parseAttributes(data, format, nil)
+	if got := attributes["OBJNAM"]; got != "Chesapeake Bay" {
+		t.Errorf("OBJNAM = %v, want Chesapeake Bay", got)
+	}
+	if got := attributes["VALSOU"]; got != "4.6" {
+		t.Errorf("VALSOU = %v, want 4.6", got)
+	}
+	if _, ok := attributes["DRVAL1"]; ok {
+		t.Error("Empty DRVAL1 should be omitted")
+	}
+}
+
+// TestParseFormatControls tests ISO 8211 format control parsing
+func TestParseFormatControls(t *testing.T) {
+	tests := []struct {
+		controls string
+		want     []subfieldFormat
+		ok       bool
+	}{
+		{"(b12,A)", []subfieldFormat{{kind: 'b', binaryType: 1, width: 2}, {kind: 'A'}}, true},
+		{"(b11,2b24)", []subfieldFormat{{kind: 'b', binaryType: 1, width: 1}, {kind: 'b', binaryType: 2, width: 4}, {kind: 'b', binaryType: 2, width: 4}}, true},
+		{"(A(3),I(5))", []subfieldFormat{{kind: 'A', width: 3}, {kind: 'I', width: 5}}, true},
+		{"", nil, false},
+		{"(X)", nil, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseFormatControls(tt.controls)
+		if ok != tt.ok {
+			t.Errorf("parseFormatControls(%q) ok = %v, want %v", tt.controls, ok, tt.ok)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("parseFormatControls(%q) = %+v, want %+v", tt.controls, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("parseFormatControls(%q)[%d] = %+v, want %+v", tt.controls, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
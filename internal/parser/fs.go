@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ParseFS parses an S-57 base cell from a filesystem abstraction.
+//
+// This allows charts to be read from embed.FS, a zip.Reader, or any other
+// fs.FS implementation. Update files (.001, .002, etc.) are discovered in the
+// same directory of the same filesystem.
+//
+// The ISO 8211 reader only reads from disk, so the cell and its updates are
+// staged into a temporary directory that is removed before returning.
+func (p *defaultParser) ParseFS(fsys fs.FS, name string, opts ParseOptions) (*Chart, error) {
+	files := []string{name}
+	if opts.ApplyUpdates {
+		updates, err := findUpdateFilesFS(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover update files: %w", err)
+		}
+		files = append(files, updates...)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "s57-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for _, file := range files {
+		if err := copyFromFS(fsys, file, filepath.Join(tmpDir, path.Base(file))); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.ParseWithOptions(filepath.Join(tmpDir, path.Base(name)), opts)
+}
+
+// findUpdateFilesFS discovers sequential update files for a base cell in fsys
+//
+// This mirrors findUpdateFiles but uses fs.Stat and slash-separated paths.
+func findUpdateFilesFS(fsys fs.FS, baseName string) ([]string, error) {
+	dir := path.Dir(baseName)
+	base := path.Base(baseName)
+	cellName := strings.TrimSuffix(base, path.Ext(base))
+
+	var updates []string
+
+	// Look for sequential updates: .001, .002, .003, etc.
+	for updateNum := 1; updateNum <= 999; updateNum++ {
+		updateFile := path.Join(dir, fmt.Sprintf("%s.%03d", cellName, updateNum))
+
+		if _, err := fs.Stat(fsys, updateFile); err == nil {
+			updates = append(updates, updateFile)
+		} else if errors.Is(err, fs.ErrNotExist) {
+			// Stop at first missing update (updates must be sequential)
+			break
+		} else {
+			return nil, fmt.Errorf("error checking for update file %s: %w", updateFile, err)
+		}
+	}
+
+	return updates, nil
+}
+
+// copyFromFS copies a file out of fsys to a local path
+func copyFromFS(fsys fs.FS, name, dest string) error {
+	src, err := fsys.Open(name)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("failed to stage %s: %w", name, err)
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return fmt.Errorf("failed to stage %s: %w", name, err)
+	}
+	return dst.Close()
+}
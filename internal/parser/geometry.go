@@ -1,5 +1,7 @@
 package parser
 
+import "math"
+
 // GeometryType represents the type of geometry for a feature
 type GeometryType int
 
@@ -10,6 +12,10 @@ const (
 	GeometryTypeLineString
 	// GeometryTypePolygon represents a closed polygon area
 	GeometryTypePolygon
+	// GeometryTypeMultiLineString represents a line feature made of several
+	// separate lines, e.g. edges that do not join end to end, or the exterior
+	// and interior boundaries of a feature
+	GeometryTypeMultiLineString
 )
 
 // String returns the string representation of the geometry type
@@ -21,6 +27,8 @@ func (g GeometryType) String() string {
 		return "LineString"
 	case GeometryTypePolygon:
 		return "Polygon"
+	case GeometryTypeMultiLineString:
+		return "MultiLineString"
 	default:
 		return "Unknown"
 	}
@@ -34,14 +42,30 @@ type Geometry struct {
 	// Coordinates is an array of [longitude, latitude] pairs
 	// Per GeoJSON convention: [lon, lat]
 	Coordinates [][]float64
+	// Boundary holds the visible parts of a polygon's outline as lines when some of
+	// its edges are masked (MASK=1); nil means the whole ring is visible
+	Boundary [][][]float64
+	// Parts holds the separate lines of a MultiLineString; Coordinates then holds
+	// the coordinates of all parts in order. Nil for other geometry types
+	Parts [][][]float64
 }
 
 // constructGeometry builds a Geometry from feature and spatial records
 // S-57 §2.1: Features reference spatial records to build geometry
-func constructGeometry(featureRec *featureRecord, spatialRecords map[spatialKey]*spatialRecord) (Geometry, error) {
+// When PRIM contradicts the referenced spatial records, the geometry is healed
+// if opts.HealPrimitiveMismatch is set, otherwise the mismatch is reported as a
+// warning (see primitive.go)
+func constructGeometry(featureRec *featureRecord, spatialRecords map[spatialKey]*spatialRecord, opts ParseOptions, warnings *warningCollector) (Geometry, error) {
 	// PRIM=255 means N/A (no geometry) - these are meta-features like C_AGGR, M_COVR, etc.
-	// Return empty point geometry for these
+	// Area meta-features still get their coverage polygon if their FSPT resolves,
+	// since some producers encode PRIM=255 for them; otherwise return empty point geometry
 	if featureRec.GeomPrim == 255 {
+		if len(featureRec.SpatialRefs) > 0 && isAreaMetaClass(featureRec.ObjectClass) {
+			geom, err := constructPolygonGeometry(featureRec, spatialRecords, ringClosureTolerance(opts), opts.Keep3D)
+			if err == nil && len(geom.Coordinates) > 0 {
+				return geom, nil
+			}
+		}
 		return Geometry{
 			Type:        GeometryTypePoint,
 			Coordinates: [][]float64{},
@@ -60,28 +84,83 @@ func constructGeometry(featureRec *featureRecord, spatialRecords map[spatialKey]
 	// PRIM: 1=Point, 2=Line, 3=Area, 255=N/A
 	geomType := geomTypeFromPrim(featureRec.GeomPrim)
 
+	// Check PRIM against the referenced spatial record types
+	if mismatch := detectPrimitiveMismatch(featureRec, spatialRecords); mismatch != nil {
+		if opts.HealPrimitiveMismatch {
+			healed := *featureRec
+			healed.SpatialRefs = mismatch.refs
+			if mismatch.resolved == GeometryTypePoint {
+				return constructPointGeometry(&healed, spatialRecords)
+			}
+			return constructLineStringGeometry(&healed, spatialRecords, opts.Keep3D)
+		}
+		warnings.warn(&ErrPrimMismatch{
+			FeatureID: featureRec.ID,
+			Prim:      featureRec.GeomPrim,
+			Resolved:  mismatch.resolved,
+			Reason:    mismatch.reason,
+		})
+	}
+
 	// For polygon features (PRIM=3), use VRPT topology resolver
 	if geomType == GeometryTypePolygon {
-		return constructPolygonGeometry(featureRec, spatialRecords)
+		return constructPolygonGeometry(featureRec, spatialRecords, ringClosureTolerance(opts), opts.Keep3D)
 	}
 
 	// For Point features (PRIM=1), use only the FIRST spatial ref
 	// S-57 §7.6: Point features reference a single isolated node
 	if geomType == GeometryTypePoint {
+		if dropped := unresolvedPointRefs(featureRec, spatialRecords); dropped > 0 {
+			warnings.warn(&ErrMissingPoints{
+				FeatureID:  featureRec.ID,
+				Referenced: len(featureRec.SpatialRefs),
+				Dropped:    dropped,
+			})
+		}
 		return constructPointGeometry(featureRec, spatialRecords)
 	}
 
 	// For LineString features (PRIM=2), collect coordinates from all spatial refs
 	// S-57 §7.6: Line features may reference edges (RCNM=130) which require topology resolution
-	return constructLineStringGeometry(featureRec, spatialRecords)
+	return constructLineStringGeometry(featureRec, spatialRecords, opts.Keep3D)
+}
+
+// copyCoordinate copies a source coordinate, keeping its depth only when keep3D is set
+func copyCoordinate(coord []float64, keep3D bool) []float64 {
+	if keep3D && len(coord) >= 3 {
+		return []float64{coord[0], coord[1], coord[2]}
+	}
+	return []float64{coord[0], coord[1]}
+}
+
+// areaMetaClasses are meta-feature classes whose spatial references describe
+// an area (S-57 Appendix A: permitted primitive "Area" only)
+var areaMetaClasses = map[string]bool{
+	"M_COVR": true,
+	"M_QUAL": true,
+	"M_NSYS": true,
+	"M_CSCL": true,
+}
+
+// isAreaMetaClass reports whether an object class code is an area meta-feature class
+func isAreaMetaClass(objectClass int) bool {
+	def, ok := LookupObjectClassCode(objectClass)
+	return ok && areaMetaClasses[def.Acronym]
 }
 
 // constructLineStringGeometry builds linestring geometry from spatial references
 // S-57 §7.6: Line features reference edges (RCNM=130) or connected nodes
-func constructLineStringGeometry(featureRec *featureRecord, spatialRecords map[spatialKey]*spatialRecord) (Geometry, error) {
-	allCoords := make([][]float64, 0)
+// A feature whose edges fall into separate lines yields a MultiLineString
+func constructLineStringGeometry(featureRec *featureRecord, spatialRecords map[spatialKey]*spatialRecord, keep3D bool) (Geometry, error) {
 	resolver := newPolygonBuilder(spatialRecords)
-
+	resolver.keep3D = keep3D
+
+	// Interior boundary edges (USAG=2) are not connected to the exterior
+	// ones, so a change of usage starts a new part. So does an edge that does
+	// not begin where the previous one ended, rather than joining the two
+	// with a segment that is not part of the feature.
+	var parts [][][]float64
+	currentUsage := 0
 	for _, spatialRef := range featureRec.SpatialRefs {
 		// Find the spatial record - try all possible RCNMs since FSPT only gives RCID
 		// S-57 spatial records can be: 110=isolated node, 120=connected node, 130=edge, 140=face
@@ -99,6 +178,7 @@ func constructLineStringGeometry(featureRec *featureRecord, spatialRecords map[s
 			continue
 		}
 
+		var refCoords [][]float64
 		// If this is an edge (RCNM=130), use full edge resolution including nodes
 		if spatial.RecordType == spatialTypeEdge {
 			edge, err := resolver.loadEdge(spatial.ID)
@@ -106,35 +186,81 @@ func constructLineStringGeometry(featureRec *featureRecord, spatialRecords map[s
 				continue // Skip edges that can't be loaded
 			}
 			// Get full edge coordinates with nodes (use orientation from FSPT)
-			edgeCoords := resolver.getFullEdgeCoordinates(edge, spatialRef.Orientation)
-			for _, coord := range edgeCoords {
-				allCoords = append(allCoords, []float64{coord[0], coord[1]})
-			}
+			refCoords = resolver.getFullEdgeCoordinates(edge, spatialRef.Orientation)
 		} else if len(spatial.Coordinates) > 0 {
 			// Direct coordinates from node
 			for _, coord := range spatial.Coordinates {
-				allCoords = append(allCoords, []float64{coord[0], coord[1]})
+				refCoords = append(refCoords, copyCoordinate(coord, keep3D))
 			}
 		} else if len(spatial.VectorPointers) > 0 {
 			// Follow VRPT pointers
-			coordsFromPointers := resolveVectorPointers(spatial, spatialRecords)
-			allCoords = append(allCoords, coordsFromPointers...)
+			refCoords = resolveVectorPointers(spatial, spatialRecords)
+		}
+		if len(refCoords) == 0 {
+			continue
 		}
+
+		usage := lineUsage(spatialRef.Usage)
+		if len(parts) == 0 || usage != currentUsage || !continuesLine(parts[len(parts)-1], refCoords[0]) {
+			parts = append(parts, nil)
+			currentUsage = usage
+		}
+		parts[len(parts)-1] = append(parts[len(parts)-1], refCoords...)
 	}
 
-	if len(allCoords) < 2 {
+	return lineGeometry(parts), nil
+}
+
+// lineUsage groups FSPT USAG values into exterior (1) and interior (2) usage.
+// Exterior truncated (3) and null (255) usage count as exterior.
+func lineUsage(usage int) int {
+	if usage == 2 {
+		return 2
+	}
+	return 1
+}
+
+// continuesLine reports whether coord is the last coordinate of part
+// (horizontal position only)
+func continuesLine(part [][]float64, coord []float64) bool {
+	last := part[len(part)-1]
+	return last[0] == coord[0] && last[1] == coord[1]
+}
+
+// lineGeometry builds a LineString from a single part, or a MultiLineString
+// from several. Parts with fewer than two coordinates are dropped.
+func lineGeometry(parts [][][]float64) Geometry {
+	var lines [][][]float64
+	for _, part := range parts {
+		if len(part) >= 2 {
+			lines = append(lines, part)
+		}
+	}
+
+	switch len(lines) {
+	case 0:
 		// Not enough coordinates for a valid line
 		// Return empty geometry (feature will be skipped by caller)
 		return Geometry{
 			Type:        GeometryTypeLineString,
 			Coordinates: [][]float64{},
-		}, nil
+		}
+	case 1:
+		return Geometry{
+			Type:        GeometryTypeLineString,
+			Coordinates: lines[0],
+		}
 	}
 
+	var allCoords [][]float64
+	for _, line := range lines {
+		allCoords = append(allCoords, line...)
+	}
 	return Geometry{
-		Type:        GeometryTypeLineString,
+		Type:        GeometryTypeMultiLineString,
 		Coordinates: allCoords,
-	}, nil
+		Parts:       lines,
+	}
 }
 
 // constructPointGeometry builds point geometry from spatial references
@@ -147,28 +273,9 @@ func constructPointGeometry(featureRec *featureRecord, spatialRecords map[spatia
 	allCoords := make([][]float64, 0)
 
 	for _, spatialRef := range featureRec.SpatialRefs {
-		// Try to find the spatial record - check isolated node first, then connected node
-		// Point features can reference either RCNM=110 (isolated) or RCNM=120 (connected)
-		// NOTE: Check isolated node FIRST - for multipoint features like SOUNDG,
-		// the SG3D coordinates are stored in the isolated node, not the connected node
-		var spatial *spatialRecord
-		for _, rcnm := range []int{int(spatialTypeIsolatedNode), int(spatialTypeConnectedNode)} {
-			key := spatialKey{RCNM: rcnm, RCID: spatialRef.RCID}
-			if sp, ok := spatialRecords[key]; ok {
-				spatial = sp
-				break
-			}
-		}
-
-		if spatial == nil {
-			// Skip missing spatial records (don't fail entire feature)
-			continue
-		}
-
-		// Get coordinates from this spatial record
-		if len(spatial.Coordinates) > 0 {
-			// Extract ALL coordinates from spatial record
-			// Preserve all dimensions (2D or 3D) - don't strip Z coordinates
+		// Get coordinates from every node record the reference can mean
+		// Preserve all dimensions (2D or 3D) - don't strip Z coordinates
+		for _, spatial := range pointSpatialRecords(spatialRef, spatialRecords) {
 			allCoords = append(allCoords, spatial.Coordinates...)
 		}
 	}
@@ -188,11 +295,122 @@ func constructPointGeometry(featureRec *featureRecord, spatialRecords map[spatia
 	}, nil
 }
 
+// unresolvedPointRefs returns the number of a point feature's spatial references
+// that resolve to no node record with coordinates
+func unresolvedPointRefs(featureRec *featureRecord, spatialRecords map[spatialKey]*spatialRecord) int {
+	dropped := 0
+	for _, spatialRef := range featureRec.SpatialRefs {
+		resolved := false
+		for _, spatial := range pointSpatialRecords(spatialRef, spatialRecords) {
+			if len(spatial.Coordinates) > 0 {
+				resolved = true
+				break
+			}
+		}
+		if !resolved {
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// normalizeDimensions makes a geometry uniformly 2D or 3D
+// Coordinates are [lon, lat] or [lon, lat, depth]. A geometry is 2D only when none
+// of its coordinates has a depth; a geometry mixing both (e.g. a 3D edge between 2D
+// nodes) is made 3D, each missing depth interpolated from the nearest coordinates
+// before and after it that have one, or copied from the nearest one at either end.
+// Coordinate slices shared with spatial records are not modified.
+func normalizeDimensions(geom *Geometry) {
+	if !hasMissingDepth(geom.Coordinates) {
+		return
+	}
+	geom.Coordinates = fillDepths(geom.Coordinates)
+
+	// Parts hold the same coordinates as Coordinates, in order
+	offset := 0
+	for i, part := range geom.Parts {
+		if offset+len(part) > len(geom.Coordinates) {
+			break
+		}
+		geom.Parts[i] = geom.Coordinates[offset : offset+len(part) : offset+len(part)]
+		offset += len(part)
+	}
+}
+
+// hasMissingDepth reports whether some, but not all, coordinates have a depth
+func hasMissingDepth(coords [][]float64) bool {
+	with := 0
+	for _, coord := range coords {
+		if len(coord) >= 3 {
+			with++
+		}
+	}
+	return with > 0 && with < len(coords)
+}
+
+// fillDepths returns coords with a depth for every coordinate (see normalizeDimensions)
+// Coordinates missing a depth are replaced with new slices; the others are kept
+func fillDepths(coords [][]float64) [][]float64 {
+	filled := make([][]float64, len(coords))
+	copy(filled, coords)
+
+	prev := -1 // Index of the last coordinate with a depth
+	for i := 0; i <= len(coords); i++ {
+		if i < len(coords) && len(coords[i]) < 3 {
+			continue
+		}
+		// Fill the gap between prev and i
+		for j := prev + 1; j < i && j < len(coords); j++ {
+			var depth float64
+			switch {
+			case prev < 0:
+				depth = coords[i][2]
+			case i == len(coords):
+				depth = coords[prev][2]
+			default:
+				t := float64(j-prev) / float64(i-prev)
+				depth = coords[prev][2] + t*(coords[i][2]-coords[prev][2])
+			}
+			filled[j] = []float64{coords[j][0], coords[j][1], depth}
+		}
+		prev = i
+	}
+	return filled
+}
+
+// pointSpatialRecords returns the node records a point feature's spatial reference
+// resolves to.
+//
+// Precedence: when FSPT carries the record name (RCNM), only that record is used,
+// since isolated (RCNM=110) and connected (RCNM=120) nodes have independent ID
+// spaces. Without RCNM, every node record with the RCID is returned, isolated node
+// first: SOUNDG keeps its SG3D soundings in isolated nodes, and stopping at the
+// first match could return a single connected node instead of the full multipoint.
+// Missing records are skipped (they don't fail the entire feature).
+func pointSpatialRecords(ref spatialRef, spatialRecords map[spatialKey]*spatialRecord) []*spatialRecord {
+	if ref.RCNM != 0 {
+		if sp, ok := spatialRecords[spatialKey{RCNM: ref.RCNM, RCID: ref.RCID}]; ok {
+			return []*spatialRecord{sp}
+		}
+		return nil
+	}
+
+	var records []*spatialRecord
+	for _, rcnm := range []spatialType{spatialTypeIsolatedNode, spatialTypeConnectedNode} {
+		if sp, ok := spatialRecords[spatialKey{RCNM: int(rcnm), RCID: ref.RCID}]; ok {
+			records = append(records, sp)
+		}
+	}
+	return records
+}
+
 // constructPolygonGeometry builds polygon geometry using VRPT topology resolution
 // S-57 §7.3: Area features use VRPT to reference edge topology
-func constructPolygonGeometry(featureRec *featureRecord, spatialRecords map[spatialKey]*spatialRecord) (Geometry, error) {
+func constructPolygonGeometry(featureRec *featureRecord, spatialRecords map[spatialKey]*spatialRecord, closureTolerance float64, keep3D bool) (Geometry, error) {
 	// Create polygon builder
 	resolver := newPolygonBuilder(spatialRecords)
+	resolver.closureTolerance = closureTolerance
+	resolver.keep3D = keep3D
 
 	// Check if feature references face records (spatial primitives with VRPT)
 	// Collect edge references WITH orientation from FSPT
@@ -244,13 +462,13 @@ func constructPolygonGeometry(featureRec *featureRecord, spatialRecords map[spat
 				edgeKey := spatialKey{RCNM: int(spatialTypeEdge), RCID: edgeRef.RCID}
 				if edge, ok := spatialRecords[edgeKey]; ok && len(edge.Coordinates) > 0 {
 					for _, coord := range edge.Coordinates {
-						allCoords = append(allCoords, []float64{coord[0], coord[1]})
+						allCoords = append(allCoords, copyCoordinate(coord, keep3D))
 					}
 				}
 			}
 
 			if len(allCoords) > 0 {
-				allCoords = ensurePolygonClosure(allCoords)
+				allCoords = ensurePolygonClosure(allCoords, closureTolerance)
 				return Geometry{
 					Type:        GeometryTypePolygon,
 					Coordinates: allCoords,
@@ -263,14 +481,14 @@ func constructPolygonGeometry(featureRec *featureRecord, spatialRecords map[spat
 				for key, spatial := range spatialRecords {
 					if key.RCID == spatialRef.RCID && len(spatial.Coordinates) > 0 {
 						for _, coord := range spatial.Coordinates {
-							allCoords = append(allCoords, []float64{coord[0], coord[1]})
+							allCoords = append(allCoords, copyCoordinate(coord, keep3D))
 						}
 					}
 				}
 			}
 
 			if len(allCoords) > 0 {
-				allCoords = ensurePolygonClosure(allCoords)
+				allCoords = ensurePolygonClosure(allCoords, closureTolerance)
 				return Geometry{
 					Type:        GeometryTypePolygon,
 					Coordinates: allCoords,
@@ -284,9 +502,7 @@ func constructPolygonGeometry(featureRec *featureRecord, spatialRecords map[spat
 		// Convert rings to coordinate format
 		allCoords := make([][]float64, 0)
 		for _, ring := range rings {
-			for _, point := range ring {
-				allCoords = append(allCoords, []float64{point[0], point[1]})
-			}
+			allCoords = append(allCoords, ring...)
 		}
 
 		// Check if we have enough coordinates for a valid polygon
@@ -298,9 +514,22 @@ func constructPolygonGeometry(featureRec *featureRecord, spatialRecords map[spat
 			}, nil
 		}
 
+		// Masked edges close the ring but are left out of the drawn boundary
+		var boundary [][][]float64
+		if runs := resolver.visibleBoundary(edgeRefs); runs != nil {
+			boundary = make([][][]float64, len(runs))
+			for i, run := range runs {
+				boundary[i] = make([][]float64, len(run))
+				for j, point := range run {
+					boundary[i][j] = []float64{point[0], point[1]}
+				}
+			}
+		}
+
 		return Geometry{
 			Type:        GeometryTypePolygon,
 			Coordinates: allCoords,
+			Boundary:    boundary,
 		}, nil
 	}
 
@@ -311,7 +540,7 @@ func constructPolygonGeometry(featureRec *featureRecord, spatialRecords map[spat
 		for key, spatial := range spatialRecords {
 			if key.RCID == spatialRef.RCID && len(spatial.Coordinates) > 0 {
 				for _, coord := range spatial.Coordinates {
-					allCoords = append(allCoords, []float64{coord[0], coord[1]})
+					allCoords = append(allCoords, copyCoordinate(coord, keep3D))
 				}
 			}
 		}
@@ -327,7 +556,7 @@ func constructPolygonGeometry(featureRec *featureRecord, spatialRecords map[spat
 	}
 
 	// Ensure polygon closure
-	allCoords = ensurePolygonClosure(allCoords)
+	allCoords = ensurePolygonClosure(allCoords, closureTolerance)
 
 	return Geometry{
 		Type:        GeometryTypePolygon,
@@ -351,7 +580,10 @@ func geomTypeFromPrim(prim int) GeometryType {
 }
 
 // ensurePolygonClosure ensures a polygon is closed (first coordinate == last)
-func ensurePolygonClosure(coords [][]float64) [][]float64 {
+// A ring whose ends are within tolerance degrees is snapped shut instead of
+// getting a duplicate closing vertex (a zero-length final segment). The
+// caller's slice is never modified; a closed ring is returned as a copy
+func ensurePolygonClosure(coords [][]float64, tolerance float64) [][]float64 {
 	if len(coords) < 3 {
 		return coords // Not enough points for polygon
 	}
@@ -360,20 +592,28 @@ func ensurePolygonClosure(coords [][]float64) [][]float64 {
 	first := coords[0]
 	last := coords[len(coords)-1]
 
-	if len(first) == 2 && len(last) == 2 {
-		if first[0] == last[0] && first[1] == last[1] {
-			return coords // Already closed
-		}
+	if math.Abs(first[0]-last[0]) <= tolerance && math.Abs(first[1]-last[1]) <= tolerance {
+		// Snap the last coordinate in a copy
+		snapped := make([][]float64, len(coords))
+		copy(snapped, coords)
+		snapped[len(coords)-1] = closingCoordinate(first)
+		return snapped
 	}
 
 	// Add closing point
 	closed := make([][]float64, len(coords)+1)
 	copy(closed, coords)
-	closed[len(coords)] = []float64{first[0], first[1]}
+	closed[len(coords)] = closingCoordinate(first)
 
 	return closed
 }
 
+// closingCoordinate returns a copy of a ring's first coordinate to close it with,
+// so the two ends of the ring do not share a slice
+func closingCoordinate(first []float64) []float64 {
+	return append([]float64(nil), first...)
+}
+
 // resolveVectorPointers recursively resolves VRPT pointers to collect coordinates
 func resolveVectorPointers(spatial *spatialRecord, spatialRecords map[spatialKey]*spatialRecord) [][]float64 {
 	visited := make(map[int64]bool)
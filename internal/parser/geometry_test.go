@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"errors"
+	"math"
 	"testing"
 )
 
@@ -13,6 +15,7 @@ func TestGeometryTypes(t *testing.T) {
 		{GeometryTypePoint, "Point"},
 		{GeometryTypeLineString, "LineString"},
 		{GeometryTypePolygon, "Polygon"},
+		{GeometryTypeMultiLineString, "MultiLineString"},
 	}
 
 	for _, tt := range tests {
@@ -76,3 +79,381 @@ func TestGeometryCreation(t *testing.T) {
 		})
 	}
 }
+
+// primitiveTestRecords builds three connected nodes joined by two edges:
+// node 1 -(edge 10)- node 2 -(edge 11)- node 3, which is an open chain
+func primitiveTestRecords() map[spatialKey]*spatialRecord {
+	node := func(id int64, lon, lat float64) *spatialRecord {
+		return &spatialRecord{ID: id, RecordType: spatialTypeConnectedNode, Coordinates: [][]float64{{lon, lat}}}
+	}
+	edge := func(id, start, end int64) *spatialRecord {
+		return &spatialRecord{
+			ID:         id,
+			RecordType: spatialTypeEdge,
+			VectorPointers: []vectorPointer{
+				{TargetRCNM: int(spatialTypeConnectedNode), TargetRCID: start, Topology: 1},
+				{TargetRCNM: int(spatialTypeConnectedNode), TargetRCID: end, Topology: 2},
+			},
+		}
+	}
+	return map[spatialKey]*spatialRecord{
+		{RCNM: int(spatialTypeConnectedNode), RCID: 1}: node(1, 0.0, 0.0),
+		{RCNM: int(spatialTypeConnectedNode), RCID: 2}: node(2, 1.0, 0.0),
+		{RCNM: int(spatialTypeConnectedNode), RCID: 3}: node(3, 1.0, 1.0),
+		{RCNM: int(spatialTypeEdge), RCID: 10}:         edge(10, 1, 2),
+		{RCNM: int(spatialTypeEdge), RCID: 11}:         edge(11, 2, 3),
+		{RCNM: int(spatialTypeEdge), RCID: 12}:         edge(12, 3, 1),
+	}
+}
+
+// TestPrimitiveMismatch tests detection and healing of PRIM/spatial type conflicts
+func TestPrimitiveMismatch(t *testing.T) {
+	edgeRef := func(id int64) spatialRef {
+		return spatialRef{RCNM: int(spatialTypeEdge), RCID: id, Orientation: 1}
+	}
+	nodeRef := func(id int64) spatialRef {
+		return spatialRef{RCNM: int(spatialTypeConnectedNode), RCID: id, Orientation: 255}
+	}
+
+	tests := []struct {
+		name     string
+		prim     int
+		refs     []spatialRef
+		mismatch bool
+		healed   GeometryType
+		coords   int
+	}{
+		{"point referencing edges", 1, []spatialRef{edgeRef(10), edgeRef(11)}, true, GeometryTypeLineString, 4},
+		{"line referencing nodes", 2, []spatialRef{nodeRef(1), nodeRef(3)}, true, GeometryTypePoint, 2},
+		{"unclosed area", 3, []spatialRef{edgeRef(10), edgeRef(11)}, true, GeometryTypeLineString, 4},
+		{"closed area", 3, []spatialRef{edgeRef(10), edgeRef(11), edgeRef(12)}, false, GeometryTypePolygon, 4},
+		{"consistent point", 1, []spatialRef{nodeRef(2)}, false, GeometryTypePoint, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spatialRecords := primitiveTestRecords()
+			featureRec := &featureRecord{ID: 42, GeomPrim: tt.prim, SpatialRefs: tt.refs}
+
+			// Without healing: geometry follows PRIM and the mismatch is a warning
+			var reported []error
+			warnings := newWarningCollector(func(err error) { reported = append(reported, err) })
+			geom, err := constructGeometry(featureRec, spatialRecords, DefaultParseOptions(), warnings)
+			if err != nil {
+				t.Fatalf("constructGeometry failed: %v", err)
+			}
+			if geom.Type != geomTypeFromPrim(tt.prim) {
+				t.Errorf("Expected unhealed type %v, got %v", geomTypeFromPrim(tt.prim), geom.Type)
+			}
+			if got := len(reported) > 0; got != tt.mismatch {
+				t.Fatalf("Expected mismatch=%v, got warnings %v", tt.mismatch, reported)
+			}
+			if tt.mismatch {
+				var primErr *ErrPrimMismatch
+				if !errors.As(reported[0], &primErr) || primErr.FeatureID != 42 || primErr.Resolved != tt.healed {
+					t.Errorf("Unexpected warning: %v", reported[0])
+				}
+			}
+
+			// With healing: geometry follows the spatial records, without a warning
+			reported = nil
+			opts := DefaultParseOptions()
+			opts.HealPrimitiveMismatch = true
+			geom, err = constructGeometry(featureRec, spatialRecords, opts, warnings)
+			if err != nil {
+				t.Fatalf("constructGeometry failed: %v", err)
+			}
+			if geom.Type != tt.healed {
+				t.Errorf("Expected healed type %v, got %v", tt.healed, geom.Type)
+			}
+			if len(geom.Coordinates) != tt.coords {
+				t.Errorf("Expected %d coordinates, got %v", tt.coords, geom.Coordinates)
+			}
+			if len(reported) != 0 {
+				t.Errorf("Healing should not warn, got %v", reported)
+			}
+		})
+	}
+}
+
+// TestPointGeometryNodeTypes tests that a point feature collects every sounding
+// of the node record it references when isolated and connected nodes share an ID
+func TestPointGeometryNodeTypes(t *testing.T) {
+	spatialRecords := map[spatialKey]*spatialRecord{
+		{RCNM: int(spatialTypeIsolatedNode), RCID: 7}: {
+			ID:         7,
+			RecordType: spatialTypeIsolatedNode,
+			Coordinates: [][]float64{
+				{-76.1, 38.1, 2.4},
+				{-76.2, 38.2, 6.0},
+				{-76.3, 38.3, 9.1},
+			},
+		},
+		{RCNM: int(spatialTypeConnectedNode), RCID: 7}: {
+			ID:          7,
+			RecordType:  spatialTypeConnectedNode,
+			Coordinates: [][]float64{{-76.9, 38.9}},
+		},
+	}
+
+	// FSPT names the isolated node: all soundings, nothing from the connected node
+	soundg := &featureRecord{
+		ID:          1,
+		GeomPrim:    1,
+		SpatialRefs: []spatialRef{{RCNM: int(spatialTypeIsolatedNode), RCID: 7}},
+	}
+	geom, err := constructPointGeometry(soundg, spatialRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(geom.Coordinates) != 3 {
+		t.Fatalf("Expected 3 soundings, got %v", geom.Coordinates)
+	}
+	for i, coord := range geom.Coordinates {
+		if len(coord) != 3 {
+			t.Errorf("Sounding %d lost its depth: %v", i, coord)
+		}
+	}
+
+	// FSPT names the connected node
+	soundg.SpatialRefs[0].RCNM = int(spatialTypeConnectedNode)
+	geom, _ = constructPointGeometry(soundg, spatialRecords)
+	if len(geom.Coordinates) != 1 || geom.Coordinates[0][0] != -76.9 {
+		t.Errorf("Expected the connected node only, got %v", geom.Coordinates)
+	}
+
+	// Without RCNM every matching node is collected, isolated node first
+	soundg.SpatialRefs[0].RCNM = 0
+	geom, _ = constructPointGeometry(soundg, spatialRecords)
+	if len(geom.Coordinates) != 4 || geom.Coordinates[0][2] != 2.4 || geom.Coordinates[3][0] != -76.9 {
+		t.Errorf("Expected isolated node soundings then connected node, got %v", geom.Coordinates)
+	}
+}
+
+// TestAreaMetaFeatureWithoutPrim tests PRIM=255 area meta-features still get coverage geometry
+func TestAreaMetaFeatureWithoutPrim(t *testing.T) {
+	spatialRecords := map[spatialKey]*spatialRecord{
+		{RCNM: int(spatialTypeEdge), RCID: 1}: {
+			ID:          1,
+			RecordType:  spatialTypeEdge,
+			Coordinates: [][]float64{{-76, 38}, {-75, 38}, {-75, 39}, {-76, 39}, {-76, 38}},
+		},
+	}
+	refs := []spatialRef{{RCID: 1, Orientation: 1}}
+	opts := DefaultParseOptions()
+	warnings := newWarningCollector(nil)
+
+	// M_COVR (302) with PRIM=255 but a valid edge
+	mcovr := &featureRecord{ID: 1, ObjectClass: 302, GeomPrim: 255, SpatialRefs: refs}
+	geom, err := constructGeometry(mcovr, spatialRecords, opts, warnings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if geom.Type != GeometryTypePolygon || len(geom.Coordinates) != 5 {
+		t.Errorf("M_COVR: expected 5-coordinate polygon, got %v %v", geom.Type, geom.Coordinates)
+	}
+
+	// Unresolvable references fall back to empty geometry
+	mcovr.SpatialRefs = []spatialRef{{RCID: 99, Orientation: 1}}
+	geom, err = constructGeometry(mcovr, spatialRecords, opts, warnings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if geom.Type != GeometryTypePoint || len(geom.Coordinates) != 0 {
+		t.Errorf("Unresolved M_COVR: expected empty point, got %v %v", geom.Type, geom.Coordinates)
+	}
+
+	// Other PRIM=255 features (C_AGGR, 400) stay empty
+	aggr := &featureRecord{ID: 2, ObjectClass: 400, GeomPrim: 255, SpatialRefs: refs}
+	geom, err = constructGeometry(aggr, spatialRecords, opts, warnings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if geom.Type != GeometryTypePoint || len(geom.Coordinates) != 0 {
+		t.Errorf("C_AGGR: expected empty point, got %v %v", geom.Type, geom.Coordinates)
+	}
+}
+
+// TestNormalizeDimensions tests mixed 2D/3D geometries are made 3D, filling the
+// missing depths from their neighbours
+func TestNormalizeDimensions(t *testing.T) {
+	shared := []float64{-76.2, 38.2, 7}
+	geom := Geometry{
+		Type:        GeometryTypeLineString,
+		Coordinates: [][]float64{{-76.1, 38.1}, shared, {-76.3, 38.3}, {-76.4, 38.4}, {-76.5, 38.5, 13}, {-76.6, 38.6}},
+	}
+	normalizeDimensions(&geom)
+	expected := []float64{7, 7, 9, 11, 13, 13}
+	for i, coord := range geom.Coordinates {
+		if len(coord) != 3 || math.Abs(coord[2]-expected[i]) > 1e-9 {
+			t.Errorf("Coordinate %d: expected depth %v, got %v", i, expected[i], coord)
+		}
+	}
+	if &geom.Coordinates[1][0] != &shared[0] {
+		t.Error("Coordinate with a depth was copied")
+	}
+
+	// Parts follow the filled coordinates
+	geom = Geometry{
+		Type:        GeometryTypeMultiLineString,
+		Coordinates: [][]float64{{0, 0}, {1, 0, 2}, {5, 5}, {6, 5}},
+	}
+	geom.Parts = [][][]float64{geom.Coordinates[:2], geom.Coordinates[2:]}
+	normalizeDimensions(&geom)
+	if len(geom.Parts[0][0]) != 3 || len(geom.Parts[1][1]) != 3 || geom.Parts[1][1][2] != 2 {
+		t.Errorf("Expected 3D parts, got %v", geom.Parts)
+	}
+
+	// Geometries without any depth stay 2D
+	geom = Geometry{
+		Type:        GeometryTypeLineString,
+		Coordinates: [][]float64{{-76.1, 38.1}, {-76.2, 38.2}},
+	}
+	normalizeDimensions(&geom)
+	if len(geom.Coordinates[0]) != 2 || len(geom.Coordinates[1]) != 2 {
+		t.Errorf("Expected 2D coordinates kept, got %v", geom.Coordinates)
+	}
+
+	// Uniform geometries are left alone
+	geom = Geometry{
+		Type:        GeometryTypePoint,
+		Coordinates: [][]float64{{-76.1, 38.1, 4}, {-76.2, 38.2, 7}},
+	}
+	normalizeDimensions(&geom)
+	if len(geom.Coordinates[0]) != 3 || len(geom.Coordinates[1]) != 3 {
+		t.Errorf("Expected 3D coordinates kept, got %v", geom.Coordinates)
+	}
+}
+
+// TestLineInteriorUsage tests that exterior and interior boundary edges of a
+// line feature become separate parts instead of one line
+func TestLineInteriorUsage(t *testing.T) {
+	spatialRecords := map[spatialKey]*spatialRecord{
+		{RCNM: int(spatialTypeEdge), RCID: 1}: {
+			ID: 1, RecordType: spatialTypeEdge, Coordinates: [][]float64{{0, 0}, {1, 0}, {1, 1}},
+		},
+		{RCNM: int(spatialTypeEdge), RCID: 2}: {
+			ID: 2, RecordType: spatialTypeEdge, Coordinates: [][]float64{{1, 1}, {0, 1}},
+		},
+		{RCNM: int(spatialTypeEdge), RCID: 3}: {
+			ID: 3, RecordType: spatialTypeEdge, Coordinates: [][]float64{{5, 5}, {6, 5}, {6, 6}},
+		},
+	}
+	featureRec := &featureRecord{ID: 1, GeomPrim: 2, SpatialRefs: []spatialRef{
+		{RCNM: int(spatialTypeEdge), RCID: 1, Orientation: 1, Usage: 1},
+		{RCNM: int(spatialTypeEdge), RCID: 2, Orientation: 1, Usage: 1},
+		{RCNM: int(spatialTypeEdge), RCID: 3, Orientation: 1, Usage: 2},
+	}}
+
+	geom, err := constructLineStringGeometry(featureRec, spatialRecords, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if geom.Type != GeometryTypeMultiLineString {
+		t.Fatalf("Expected MultiLineString, got %v", geom.Type)
+	}
+	if len(geom.Parts) != 2 {
+		t.Fatalf("Expected 2 parts, got %v", geom.Parts)
+	}
+	if len(geom.Parts[0]) != 5 || geom.Parts[1][0][0] != 5 {
+		t.Errorf("Unexpected parts: %v", geom.Parts)
+	}
+	if len(geom.Coordinates) != len(geom.Parts[0])+len(geom.Parts[1]) {
+		t.Errorf("Expected Coordinates to hold every part, got %d coordinates", len(geom.Coordinates))
+	}
+
+	// The exterior edges alone stay a single LineString
+	featureRec.SpatialRefs = featureRec.SpatialRefs[:2]
+	geom, _ = constructLineStringGeometry(featureRec, spatialRecords, false)
+	if geom.Type != GeometryTypeLineString || geom.Parts != nil {
+		t.Errorf("Expected a LineString without parts, got %v with %d parts", geom.Type, len(geom.Parts))
+	}
+
+	// A multi-part line is still the line primitive
+	if err := ValidatePrimitive("COALNE", &Geometry{Type: GeometryTypeMultiLineString, Coordinates: [][]float64{{0, 0}}}); err != nil {
+		t.Errorf("Expected MultiLineString to be a valid COALNE primitive: %v", err)
+	}
+}
+
+// TestLineDisconnectedEdges tests that edges that do not join end to end become
+// separate parts
+func TestLineDisconnectedEdges(t *testing.T) {
+	spatialRecords := map[spatialKey]*spatialRecord{
+		{RCNM: int(spatialTypeEdge), RCID: 1}: {
+			ID: 1, RecordType: spatialTypeEdge, Coordinates: [][]float64{{0, 0}, {1, 0}},
+		},
+		{RCNM: int(spatialTypeEdge), RCID: 2}: {
+			ID: 2, RecordType: spatialTypeEdge, Coordinates: [][]float64{{5, 5}, {6, 5}},
+		},
+		{RCNM: int(spatialTypeEdge), RCID: 3}: {
+			ID: 3, RecordType: spatialTypeEdge, Coordinates: [][]float64{{6, 5}, {6, 6}},
+		},
+	}
+	featureRec := &featureRecord{ID: 1, GeomPrim: 2, SpatialRefs: []spatialRef{
+		{RCNM: int(spatialTypeEdge), RCID: 1, Orientation: 1, Usage: 1},
+		{RCNM: int(spatialTypeEdge), RCID: 2, Orientation: 1, Usage: 1},
+	}}
+
+	geom, err := constructLineStringGeometry(featureRec, spatialRecords, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if geom.Type != GeometryTypeMultiLineString || len(geom.Parts) != 2 {
+		t.Fatalf("Expected a MultiLineString of 2 parts, got %v with %v", geom.Type, geom.Parts)
+	}
+	if geom.Parts[0][1][0] != 1 || geom.Parts[1][0][0] != 5 {
+		t.Errorf("Unexpected parts: %v", geom.Parts)
+	}
+
+	// Edges 2 and 3 share a node, so they form one line
+	featureRec.SpatialRefs[0].RCID = 3
+	featureRec.SpatialRefs[0], featureRec.SpatialRefs[1] = featureRec.SpatialRefs[1], featureRec.SpatialRefs[0]
+	geom, _ = constructLineStringGeometry(featureRec, spatialRecords, false)
+	if geom.Type != GeometryTypeLineString {
+		t.Errorf("Expected connected edges to give a LineString, got %v with %v", geom.Type, geom.Parts)
+	}
+}
+
+// TestSoundingMissingNode tests that a SOUNDG referencing a missing node keeps
+// its other soundings and reports the dropped reference
+func TestSoundingMissingNode(t *testing.T) {
+	spatialRecords := map[spatialKey]*spatialRecord{
+		{RCNM: int(spatialTypeIsolatedNode), RCID: 1}: {
+			ID:          1,
+			RecordType:  spatialTypeIsolatedNode,
+			Coordinates: [][]float64{{-76.5, 38.5, 2.4}, {-76.6, 38.6, 3.1}},
+		},
+	}
+	soundg := &featureRecord{ID: 9, GeomPrim: 1, SpatialRefs: []spatialRef{
+		{RCNM: int(spatialTypeIsolatedNode), RCID: 1},
+		{RCNM: int(spatialTypeIsolatedNode), RCID: 2},
+	}}
+
+	warnings := newWarningCollector(nil)
+	geom, err := constructGeometry(soundg, spatialRecords, DefaultParseOptions(), warnings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(geom.Coordinates) != 2 {
+		t.Errorf("Expected the 2 soundings of the resolved node, got %v", geom.Coordinates)
+	}
+
+	list := warnings.list()
+	var missing *ErrMissingPoints
+	if len(list) != 1 || !errors.As(list[0], &missing) {
+		t.Fatalf("Expected one ErrMissingPoints warning, got %v", list)
+	}
+	if missing.FeatureID != 9 || missing.Referenced != 2 || missing.Dropped != 1 {
+		t.Errorf("Unexpected warning: %+v", missing)
+	}
+
+	// Every node present: no warning
+	warnings = newWarningCollector(nil)
+	soundg.SpatialRefs = soundg.SpatialRefs[:1]
+	if _, err := constructGeometry(soundg, spatialRecords, DefaultParseOptions(), warnings); err != nil {
+		t.Fatal(err)
+	}
+	if list := warnings.list(); len(list) != 0 {
+		t.Errorf("Expected no warnings, got %v", list)
+	}
+}
@@ -0,0 +1,28 @@
+package parser
+
+import "fmt"
+
+// ExtractMetadata reads the dataset identification (DSID, DSSI) and parameters
+// (DSPM) of an S-57 file without building features or geometry.
+//
+// Unlike Parse it accepts update files (.001, .002, ...), so callers can sort
+// an unorganized set of files with Chart.IsBaseCell before parsing them. The
+// returned chart has no features; updates are not applied. For a file bundling
+// several datasets the first is described.
+func ExtractMetadata(filename string) (*Chart, error) {
+	isoFile, err := parseISO8211(filename, DefaultParseOptions(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := extractDSID(isoFile)
+	if metadata == nil {
+		return nil, fmt.Errorf("%s: %w", filename, ErrMissingDSID)
+	}
+
+	return &Chart{
+		metadata:  metadata,
+		params:    extractDatasetParams(isoFile, DefaultParseOptions()),
+		structure: extractDSSI(isoFile),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package parser
+
+// multidataset.go - Files bundling several datasets
+//
+// An exchange file normally holds exactly one dataset: a DSID record followed by
+// the DSPM, feature and spatial records of that cell. Rarely a producer
+// concatenates several datasets behind one DDR. Each DSID then starts a new
+// dataset, and its records must be kept apart: merging them would mix features
+// of different cells (and different COMF/SOMF) into one chart.
+
+import (
+	"fmt"
+
+	"github.com/beetlebugorg/iso8211/pkg/iso8211"
+)
+
+// splitDatasets splits the records of a file into one file per dataset.
+// A DSID record starts a new dataset; records before the first DSID belong to
+// the first dataset. A file without DSID yields a single dataset.
+func splitDatasets(isoFile *iso8211.ISO8211File) []*iso8211.ISO8211File {
+	current := &iso8211.ISO8211File{DDR: isoFile.DDR}
+	datasets := []*iso8211.ISO8211File{current}
+	hasDSID := false
+
+	for _, record := range isoFile.Records {
+		if _, ok := record.Fields["DSID"]; ok {
+			if hasDSID {
+				current = &iso8211.ISO8211File{DDR: isoFile.DDR}
+				datasets = append(datasets, current)
+			}
+			hasDSID = true
+		}
+		current.Records = append(current.Records, record)
+	}
+	return datasets
+}
+
+// ParseAll parses every dataset of a file, returning one chart per DSID record
+// in file order.
+//
+// A file holding a single dataset gives the same chart as ParseWithOptions,
+// with its updates applied if enabled. Update files are named after one cell,
+// so they are not applied to the datasets of a multi-dataset file.
+func (p *defaultParser) ParseAll(filename string, opts ParseOptions) ([]*Chart, error) {
+	fileWarnings := newWarningCollector(opts.OnWarning)
+	isoFile, err := parseISO8211(filename, opts, fileWarnings)
+	if err != nil {
+		return nil, err
+	}
+
+	datasets := splitDatasets(isoFile)
+	if len(datasets) == 1 {
+		data, params, metadata, err := parseDataset(filename, isoFile, opts, fileWarnings)
+		if err != nil {
+			return nil, err
+		}
+		chart, err := completeChart(filename, data, params, metadata, opts)
+		if err != nil {
+			return nil, err
+		}
+		return []*Chart{chart}, nil
+	}
+
+	charts := make([]*Chart, 0, len(datasets))
+	for i, dataset := range datasets {
+		// Each chart keeps the file-level warnings (e.g. salvaged records)
+		warnings := newWarningCollector(opts.OnWarning)
+		warnings.warnings = append(warnings.warnings, fileWarnings.list()...)
+
+		data, params, metadata, err := parseDataset(filename, dataset, opts, warnings)
+		if err != nil {
+			return nil, fmt.Errorf("dataset %d: %w", i+1, err)
+		}
+		chart, err := buildChart(data, metadata, params, opts)
+		if err != nil {
+			return nil, fmt.Errorf("dataset %d: %w", i+1, err)
+		}
+		charts = append(charts, chart)
+	}
+	return charts, nil
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// writeTwoDatasetFile writes a synthetic file holding the test cell twice
+// behind one DDR, i.e. with two DSID records
+func writeTwoDatasetFile(t *testing.T) string {
+	t.Helper()
+	data, err := os.ReadFile("../../test/US4MD81M/US4MD81M.000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first 5 bytes of the DDR leader give its length
+	ddrLength, err := strconv.Atoi(string(data[:5]))
+	if err != nil {
+		t.Fatalf("Invalid DDR leader: %v", err)
+	}
+
+	combined := append(append([]byte{}, data...), data[ddrLength:]...)
+	path := filepath.Join(t.TempDir(), "MULTI.000")
+	if err := os.WriteFile(path, combined, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestParseAll tests that the datasets of a multi-dataset file are kept apart
+func TestParseAll(t *testing.T) {
+	path := writeTwoDatasetFile(t)
+	parser := NewParser()
+	opts := DefaultParseOptions()
+	opts.ApplyUpdates = false
+
+	// A single-chart parse must not merge the two cells
+	if _, err := parser.ParseWithOptions(path, opts); !errors.Is(err, ErrMultipleDatasets) {
+		t.Fatalf("Expected ErrMultipleDatasets, got %v", err)
+	}
+
+	single, err := parser.ParseWithOptions("../../test/US4MD81M/US4MD81M.000", opts)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	charts, err := parser.ParseAll(path, opts)
+	if err != nil {
+		t.Fatalf("ParseAll failed: %v", err)
+	}
+	if len(charts) != 2 {
+		t.Fatalf("Expected 2 charts, got %d", len(charts))
+	}
+	for i, chart := range charts {
+		if len(chart.Features) != len(single.Features) {
+			t.Errorf("Chart %d: expected %d features, got %d", i, len(single.Features), len(chart.Features))
+		}
+		if chart.DatasetName() != single.DatasetName() {
+			t.Errorf("Chart %d: expected dataset %s, got %s", i, single.DatasetName(), chart.DatasetName())
+		}
+	}
+}
+
+// TestParseAllSingleDataset tests ParseAll on an ordinary cell
+func TestParseAllSingleDataset(t *testing.T) {
+	path := "../../test/US4MD81M/US4MD81M.000"
+	parser := NewParser()
+
+	charts, err := parser.ParseAll(path, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseAll failed: %v", err)
+	}
+	if len(charts) != 1 {
+		t.Fatalf("Expected 1 chart, got %d", len(charts))
+	}
+
+	// Updates are applied as for ParseWithOptions
+	chart, err := parser.Parse(path)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+	if charts[0].UpdateNumber() != chart.UpdateNumber() || len(charts[0].Features) != len(chart.Features) {
+		t.Errorf("ParseAll gave update %s with %d features, Parse gave update %s with %d",
+			charts[0].UpdateNumber(), len(charts[0].Features), chart.UpdateNumber(), len(chart.Features))
+	}
+}
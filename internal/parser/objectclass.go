@@ -171,6 +171,10 @@ var objectClassNames = map[int]string{
 	157: "WATFAL",
 	158: "WEDKLP",
 	159: "WRECKS",
+	160: "TS_FEB",
+	161: "ARCSLN",
+	162: "ASLXIS",
+	163: "NEWOBJ",
 	300: "M_ACCY",
 	301: "M_CSCL",
 	302: "M_COVR",
@@ -189,10 +193,11 @@ var objectClassNames = map[int]string{
 	402: "C_STAC",
 }
 
-//go:embed s57attributes.csv
 // S-57 attribute catalogue CSV from GDAL project
 // Source: https://gdal.org/ - licensed under MIT/X11
 // This file maps S-57 attribute codes to their standard acronyms per IHO S-57 Appendix A Chapter 2
+//
+//go:embed s57attributes.csv
 var s57AttributesCSV string
 
 var (
@@ -230,6 +235,103 @@ func loadAttributeNames() {
 	}
 }
 
+// S-57 object catalogue: long names, object types and permitted primitives
+// Columns follow GDAL's s57objectclasses.csv layout (Code, ObjectClass, Acronym, Class, Primitives)
+// Source: IHO S-57 Edition 3.1 Appendix A Chapter 1 - Object Classes
+//
+//go:embed s57objectclasses.csv
+var s57ObjectClassesCSV string
+
+// ObjectClassDef describes an object class from the S-57 Object Catalogue
+type ObjectClassDef struct {
+	Code       int            // Numeric object class code (OBJL)
+	Acronym    string         // Six-character acronym (e.g., "DEPCNT")
+	Name       string         // Long name (e.g., "Depth contour")
+	Class      string         // Object type: "G"=geo, "M"=meta, "C"=collection, "$"=cartographic
+	Primitives []GeometryType // Permitted geometric primitives (empty for collections)
+}
+
+// Allows reports whether the object class permits the given geometry type
+// A MultiLineString is the S-57 line primitive, like a LineString
+func (d ObjectClassDef) Allows(geomType GeometryType) bool {
+	if geomType == GeometryTypeMultiLineString {
+		geomType = GeometryTypeLineString
+	}
+	for _, prim := range d.Primitives {
+		if prim == geomType {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	objectClassDefs       map[string]ObjectClassDef
+	objectClassDefsByCode map[int]ObjectClassDef
+	objectClassDefsOnce   sync.Once
+)
+
+// loadObjectClassDefs loads the S-57 object catalogue from embedded CSV
+func loadObjectClassDefs() {
+	objectClassDefs = make(map[string]ObjectClassDef)
+	objectClassDefsByCode = make(map[int]ObjectClassDef)
+
+	reader := csv.NewReader(strings.NewReader(s57ObjectClassesCSV))
+	records, err := reader.ReadAll()
+	if err != nil {
+		// Fall back to empty maps on error
+		return
+	}
+
+	// Skip header row
+	for _, record := range records[1:] {
+		if len(record) < 5 {
+			continue
+		}
+
+		// Parse: Code, ObjectClass, Acronym, Class, Primitives
+		code, err := strconv.Atoi(record[0])
+		if err != nil {
+			continue
+		}
+
+		def := ObjectClassDef{
+			Code:    code,
+			Name:    record[1],
+			Acronym: record[2],
+			Class:   record[3],
+		}
+		for _, prim := range strings.Split(record[4], ";") {
+			switch prim {
+			case "Point":
+				def.Primitives = append(def.Primitives, GeometryTypePoint)
+			case "Line":
+				def.Primitives = append(def.Primitives, GeometryTypeLineString)
+			case "Area":
+				def.Primitives = append(def.Primitives, GeometryTypePolygon)
+			}
+		}
+
+		objectClassDefs[def.Acronym] = def
+		objectClassDefsByCode[def.Code] = def
+	}
+}
+
+// LookupObjectClass returns the Object Catalogue entry for an acronym (e.g., "DEPCNT")
+// S-57 Appendix A Chapter 1: Object Classes
+func LookupObjectClass(acronym string) (ObjectClassDef, bool) {
+	objectClassDefsOnce.Do(loadObjectClassDefs)
+	def, ok := objectClassDefs[acronym]
+	return def, ok
+}
+
+// LookupObjectClassCode returns the Object Catalogue entry for a numeric code (OBJL)
+func LookupObjectClassCode(code int) (ObjectClassDef, bool) {
+	objectClassDefsOnce.Do(loadObjectClassDefs)
+	def, ok := objectClassDefsByCode[code]
+	return def, ok
+}
+
 // AttributeCodeToString converts S-57 numeric attribute code to string acronym
 // S-57 Appendix A Chapter 2: Attribute Catalogue
 func AttributeCodeToString(code int) string {
@@ -264,8 +366,10 @@ func ObjectClassToString(code int) (string, error) {
 // ObjectClassToInt converts string code to numeric object class
 // S-57 object classes are identified by numeric codes in the binary data
 func ObjectClassToInt(code string) (int, error) {
-	// TODO: Implement reverse lookup from object catalogue
-	return 0, fmt.Errorf("object class lookup not yet implemented: %s", code)
+	if def, ok := LookupObjectClass(code); ok {
+		return def.Code, nil
+	}
+	return 0, fmt.Errorf("unknown S-57 object class: %s", code)
 }
 
 // IsSupported checks if an object class code is valid
@@ -3,6 +3,8 @@ package parser
 import (
 	"encoding/binary"
 	"fmt"
+	"io/fs"
+	"sort"
 	"strings"
 
 	"github.com/beetlebugorg/iso8211/pkg/iso8211"
@@ -25,6 +27,12 @@ type Parser interface {
 	// ParseWithOptions parses with custom options
 	ParseWithOptions(filename string, opts ParseOptions) (*Chart, error)
 
+	// ParseFS parses a base cell (and its updates) from a filesystem abstraction
+	ParseFS(fsys fs.FS, name string, opts ParseOptions) (*Chart, error)
+
+	// ParseAll parses every dataset of a file that bundles several (one DSID each)
+	ParseAll(filename string, opts ParseOptions) ([]*Chart, error)
+
 	// SupportedObjectClasses returns list of supported S-57 object classes
 	SupportedObjectClasses() []string
 }
@@ -43,9 +51,110 @@ type ParseOptions struct {
 	// Empty means extract all supported types
 	ObjectClassFilter []string
 
+	// GeometryTypeFilter: if non-empty, only keep features whose constructed geometry
+	// is one of these types (LineString also keeps MultiLineString)
+	// Empty means keep all geometry types
+	GeometryTypeFilter []GeometryType
+
 	// ApplyUpdates: if true, automatically discover and apply update files (.001, .002, etc.)
 	// Default: true
 	ApplyUpdates bool
+
+	// RetainRawFields: if true, keep the original ISO 8211 fields of each feature record
+	// so callers can inspect fields the parser does not interpret (e.g., FFPT)
+	// Default: false (raw bytes increase memory use)
+	RetainRawFields bool
+
+	// ContinueOnRecordError: if true, a record that fails to parse (truncated or corrupt
+	// file, unresolvable feature, failed update instruction) is reported as a warning and
+	// skipped instead of failing the whole chart
+	// Default: false
+	ContinueOnRecordError bool
+
+	// ValidatePrimitive: if true, check each feature's resolved geometry type against the
+	// primitives permitted for its object class and report mismatches as warnings
+	// Mismatches usually indicate a topology-resolution bug or corrupt data
+	// Default: false
+	ValidatePrimitive bool
+
+	// ValidatePolygons: if true, check polygon rings for closure, a non-sliver area and
+	// self-intersection (see ValidatePolygon) and report invalid polygons as warnings
+	// Self-intersecting rings usually come from edges with wrong orientation (ORNT)
+	// Default: false
+	ValidatePolygons bool
+
+	// DropInvalidPolygons: if true (with ValidatePolygons), invalid polygons are dropped
+	// from the chart after being reported instead of being kept
+	// Default: false
+	DropInvalidPolygons bool
+
+	// CoordinatePrecision: number of decimal digits kept for longitude/latitude when
+	// coordinates are converted (e.g., 6 ≈ 10 cm); depths are not rounded
+	// Default: 0 (no rounding)
+	CoordinatePrecision int
+
+	// CoordinateFactorOverride: if positive, used as COMF (coordinate multiplication
+	// factor) instead of the value in the file's DSPM record
+	// Escape hatch for non-conformant producer data whose DSPM is present but wrong,
+	// yielding coordinates off by orders of magnitude
+	// Default: 0 (use the file value)
+	CoordinateFactorOverride int
+
+	// SoundingFactorOverride: if positive, used as SOMF (sounding multiplication
+	// factor) instead of the value in the file's DSPM record
+	// Default: 0 (use the file value)
+	SoundingFactorOverride int
+
+	// RingClosureTolerance: largest gap, in degrees, between a polygon ring's first and
+	// last coordinates that is treated as closed; such rings are snapped shut instead of
+	// getting a duplicate closing vertex
+	// Default: 0 (use 1e-8, about 1 mm); negative requires exact equality
+	RingClosureTolerance float64
+
+	// Keep3D: if true, line and area geometries keep the depth of 3D (SG3D) edge and
+	// node coordinates as [lon, lat, depth]; depths missing from some coordinates are
+	// filled from their neighbours, so a geometry is only 2D if no coordinate has one
+	// Default: false (lines and areas are 2D)
+	Keep3D bool
+
+	// Catalogue: supplementary object classes and attributes for product specifications
+	// that extend S-57 (e.g. Inland ENC); codes missing from the S-57 catalogue are
+	// resolved here before falling back to OBJL_<code> / ATTR_<code>
+	// Default: nil (S-57 catalogue only)
+	Catalogue *Catalogue
+
+	// RecordUpdateLog: if true, the chart keeps a summary of each applied update file
+	// with its counts of inserted, deleted and modified feature and spatial records
+	// Default: false
+	RecordUpdateLog bool
+
+	// SortFeatures: if true, features are ordered by FOID (AGEN, FIDN, FIDS) instead of
+	// record order, so a chart yields the same order regardless of its update history
+	// Default: false (record order; features inserted by updates come last)
+	SortFeatures bool
+
+	// HealPrimitiveMismatch: if true, features whose FRID PRIM contradicts their spatial
+	// records are built from the spatial records instead (e.g. a point referencing edges
+	// becomes a line, an area whose edges never close becomes a line)
+	// If false, geometry follows PRIM and the mismatch is reported as a warning
+	// Default: false
+	HealPrimitiveMismatch bool
+
+	// VerifyCounts: if true, compare the number of meta and geo features built against
+	// the counts declared in DSSI, accounting for update inserts/deletes and for features
+	// removed by ObjectClassFilter, GeometryTypeFilter or DropInvalidPolygons
+	// Mismatches (silent feature loss) are reported as ErrFeatureCountMismatch warnings
+	// Default: false
+	VerifyCounts bool
+
+	// StrictCounts: if true (with VerifyCounts), a count mismatch fails the parse
+	// instead of being reported as a warning
+	// Default: false
+	StrictCounts bool
+
+	// OnWarning: optional callback for non-fatal problems found while parsing
+	// Warnings are also available from Chart.Warnings
+	OnWarning func(err error)
 }
 
 // DefaultParseOptions returns parse options with defaults
@@ -85,6 +194,12 @@ func (p *defaultParser) ParseWithOptions(filename string, opts ParseOptions) (*C
 		return nil, err
 	}
 
+	return completeChart(filename, baseData, params, metadata, opts)
+}
+
+// completeChart applies the update files of a base cell, if enabled, and builds
+// the final chart
+func completeChart(filename string, baseData *chartData, params datasetParams, metadata *datasetMetadata, opts ParseOptions) (*Chart, error) {
 	// 2. Discover and apply updates if enabled
 	if opts.ApplyUpdates {
 		updateFiles, err := findUpdateFiles(filename)
@@ -92,7 +207,7 @@ func (p *defaultParser) ParseWithOptions(filename string, opts ParseOptions) (*C
 			return nil, fmt.Errorf("failed to discover update files: %w", err)
 		}
 		if len(updateFiles) > 0 {
-			if err := applyUpdates(baseData, updateFiles, params); err != nil {
+			if err := applyUpdates(baseData, updateFiles, params, opts); err != nil {
 				return nil, fmt.Errorf("failed to apply updates: %w", err)
 			}
 		}
@@ -105,41 +220,88 @@ func (p *defaultParser) ParseWithOptions(filename string, opts ParseOptions) (*C
 // parseBaseFile extracts raw feature and spatial records without building geometries.
 // This allows update files to be applied before geometry construction.
 func parseBaseFile(filename string, opts ParseOptions) (*chartData, datasetParams, *datasetMetadata, error) {
-	// Open ISO 8211 file
-	reader, err := iso8211.NewReader(filename)
+	warnings := newWarningCollector(opts.OnWarning)
+
+	// Open and parse ISO 8211 structure
+	isoFile, err := parseISO8211(filename, opts, warnings)
 	if err != nil {
-		return nil, datasetParams{}, nil, fmt.Errorf("failed to open file: %w", err)
+		return nil, datasetParams{}, nil, err
 	}
-	defer reader.Close()
 
-	// Parse ISO 8211 structure
-	isoFile, err := reader.Parse()
-	if err != nil {
-		return nil, datasetParams{}, nil, fmt.Errorf("failed to parse ISO 8211: %w", err)
+	// Records of different cells must not be merged into one chart
+	if datasets := splitDatasets(isoFile); len(datasets) > 1 {
+		return nil, datasetParams{}, nil, fmt.Errorf("%s: %w (%d DSID records, use ParseAll)",
+			filename, ErrMultipleDatasets, len(datasets))
 	}
 
+	return parseDataset(filename, isoFile, opts, warnings)
+}
+
+// parseDataset extracts the raw records of a single dataset (one DSID) of a file
+func parseDataset(filename string, isoFile *iso8211.ISO8211File, opts ParseOptions, warnings *warningCollector) (*chartData, datasetParams, *datasetMetadata, error) {
 	// Extract dataset parameters (COMF, SOMF, etc.) from DSPM record
-	params := extractDatasetParams(isoFile)
+	params := extractDatasetParams(isoFile, opts)
+	params.precision = opts.CoordinatePrecision
+	if err := validateDatum(params); err != nil {
+		return nil, datasetParams{}, nil, fmt.Errorf("%s: %w", filename, err)
+	}
 
 	// Extract dataset metadata from DSID record
 	metadata := extractDSID(isoFile)
+	if metadata == nil {
+		return nil, datasetParams{}, nil, fmt.Errorf("%s: %w", filename, ErrMissingDSID)
+	}
 
 	// Extract feature records (without geometry)
 	features := []*featureRecord{}
+	hasUpdateInstructions := false
 	featuresByID := make(map[featureID]*featureRecord)
+	attf := attributeFormatFromDDR(isoFile.DDR, "ATTF")
 	for _, record := range isoFile.Records {
-		if featureRec := parseFeatureRecord(record); featureRec != nil {
-			features = append(features, featureRec)
+		if featureRec := parseFeatureRecord(record, attf, opts.Catalogue); featureRec != nil {
+			if opts.RetainRawFields {
+				featureRec.RawFields = record.Fields
+			}
+			if UpdateInstruction(featureRec.UpdateInstr) == UpdateDelete || UpdateInstruction(featureRec.UpdateInstr) == UpdateModify {
+				hasUpdateInstructions = true
+			}
 			// Create composite key from FOID fields
 			key := featureID{
 				AGEN: featureRec.AGEN,
 				FIDN: featureRec.FIDN,
 				FIDS: featureRec.FIDS,
 			}
+
+			// A FOID must be unique within a cell; keep one copy of a duplicate
+			// (the highest RVER, the first on a tie) so updates and geometry
+			// see a single feature
+			if existing, ok := featuresByID[key]; ok {
+				kept, dropped := existing.RecordVersion, featureRec.RecordVersion
+				if featureRec.RecordVersion > existing.RecordVersion {
+					kept, dropped = dropped, kept
+					*existing = *featureRec
+				}
+				warnings.warn(&ErrDuplicateFeature{
+					AGEN: key.AGEN, FIDN: key.FIDN, FIDS: key.FIDS,
+					KeptVersion: kept, DroppedVersion: dropped,
+				})
+				continue
+			}
+
+			features = append(features, featureRec)
 			featuresByID[key] = featureRec
 		}
 	}
 
+	// An update dataset (EXPP=2, or records that delete/modify existing ones)
+	// only makes sense applied to its base cell
+	if metadata.expp == 2 || hasUpdateInstructions {
+		return nil, datasetParams{}, nil, &ErrNotABaseCell{
+			Filename:     filename,
+			UpdateNumber: metadata.UpdateNumber(),
+		}
+	}
+
 	// Extract spatial records
 	spatialRecords := make(map[spatialKey]*spatialRecord)
 	for _, record := range isoFile.Records {
@@ -150,10 +312,14 @@ func parseBaseFile(filename string, opts ParseOptions) (*chartData, datasetParam
 	}
 
 	return &chartData{
-		features:       features,
-		spatialRecords: spatialRecords,
-		metadata:       metadata,
-		featuresByID:   featuresByID,
+		features:        features,
+		spatialRecords:  spatialRecords,
+		metadata:        metadata,
+		structure:       extractDSSI(isoFile),
+		baseCounts:      countFeatureRecords(features),
+		featuresByID:    featuresByID,
+		retainRawFields: opts.RetainRawFields,
+		warnings:        warnings,
 	}, params, metadata, nil
 }
 
@@ -163,39 +329,75 @@ func buildChart(data *chartData, metadata *datasetMetadata, params datasetParams
 	// Build geometries for all features
 	finalFeatures := []Feature{}
 
+	// Per-category counts for VerifyCounts
+	built := make(map[featureCategory]int)
+	filtered := make(map[featureCategory]int)
+
 	for _, featureRec := range data.features {
 		// Check object class filter
 		if len(opts.ObjectClassFilter) > 0 {
-			objClass, _ := ObjectClassToString(featureRec.ObjectClass)
+			objClass, _ := opts.Catalogue.ObjectClassToString(featureRec.ObjectClass)
 			if !contains(opts.ObjectClassFilter, objClass) {
+				filtered[categorizeFeature(featureRec.ObjectClass)]++
 				continue // Filtered out
 			}
 		}
 
 		// Construct geometry from spatial records
-		geometry, err := constructGeometry(featureRec, data.spatialRecords)
+		geometry, err := constructGeometry(featureRec, data.spatialRecords, opts, data.warnings)
 		if err != nil {
+			setFeatureID(err, featureRec.ID)
 			if opts.SkipUnknownFeatures {
 				continue // Skip this feature
 			}
 			// Add context about which feature failed
-			objClass, _ := ObjectClassToString(featureRec.ObjectClass)
-			return nil, fmt.Errorf("feature ID=%d, ObjectClass=%s (OBJL=%d), GeomPrim=%d: %w",
+			objClass, _ := opts.Catalogue.ObjectClassToString(featureRec.ObjectClass)
+			err = fmt.Errorf("feature ID=%d, ObjectClass=%s (OBJL=%d), GeomPrim=%d: %w",
 				featureRec.ID, objClass, featureRec.ObjectClass, featureRec.GeomPrim, err)
+			if opts.ContinueOnRecordError {
+				data.warnings.warn(err)
+				continue
+			}
+			return nil, err
+		}
+		normalizeDimensions(&geometry)
+
+		// Check geometry type filter
+		if len(opts.GeometryTypeFilter) > 0 && !containsGeometryType(opts.GeometryTypeFilter, geometry.Type) {
+			filtered[categorizeFeature(featureRec.ObjectClass)]++
+			continue // Filtered out
 		}
 
 		// Apply geometry validation if enabled
 		if opts.ValidateGeometry {
 			if err := ValidateGeometry(&geometry); err != nil {
+				setFeatureID(err, featureRec.ID)
 				if opts.SkipUnknownFeatures {
 					continue
 				}
-				return nil, fmt.Errorf("feature %d: %w", featureRec.ID, err)
+				err = fmt.Errorf("feature %d: %w", featureRec.ID, err)
+				if opts.ContinueOnRecordError {
+					data.warnings.warn(err)
+					continue
+				}
+				return nil, err
+			}
+		}
+
+		// Check polygon rings for self-intersection and slivers
+		if opts.ValidatePolygons {
+			if err := ValidatePolygon(&geometry); err != nil {
+				setFeatureID(err, featureRec.ID)
+				data.warnings.warn(fmt.Errorf("feature %d: %w", featureRec.ID, err))
+				if opts.DropInvalidPolygons {
+					filtered[categorizeFeature(featureRec.ObjectClass)]++
+					continue
+				}
 			}
 		}
 
 		// Convert object class code to string
-		objClass, err := ObjectClassToString(featureRec.ObjectClass)
+		objClass, err := opts.Catalogue.ObjectClassToString(featureRec.ObjectClass)
 		if err != nil {
 			if opts.SkipUnknownFeatures {
 				continue
@@ -203,15 +405,41 @@ func buildChart(data *chartData, metadata *datasetMetadata, params datasetParams
 			return nil, err
 		}
 
+		// Check geometry against the object catalogue; mismatches are not fatal
+		if opts.ValidatePrimitive {
+			if err := ValidatePrimitive(objClass, &geometry); err != nil {
+				data.warnings.warn(fmt.Errorf("feature %d: %w", featureRec.ID, err))
+			}
+		}
+
 		// Create feature
 		feature := Feature{
 			ID:          featureRec.ID,
+			Agency:      featureRec.AGEN,
+			Subdivision: featureRec.FIDS,
 			ObjectClass: objClass,
+			Group:       featureRec.Group,
 			Geometry:    geometry,
 			Attributes:  featureRec.Attributes,
+			RawFields:   featureRec.RawFields,
 		}
 
 		finalFeatures = append(finalFeatures, feature)
+		built[categorizeFeature(featureRec.ObjectClass)]++
+	}
+
+	// Compare against the counts declared in DSSI
+	if opts.VerifyCounts {
+		for _, err := range verifyFeatureCounts(data, built, filtered) {
+			if opts.StrictCounts {
+				return nil, err
+			}
+			data.warnings.warn(err)
+		}
+	}
+
+	if opts.SortFeatures {
+		sortFeaturesByFOID(finalFeatures)
 	}
 
 	return &Chart{
@@ -219,9 +447,27 @@ func buildChart(data *chartData, metadata *datasetMetadata, params datasetParams
 		params:         params,
 		Features:       finalFeatures,
 		spatialRecords: data.spatialRecords, // Keep for potential future updates
+		structure:      data.structure,
+		Warnings:       data.warnings.list(),
+		updateLog:      data.updateLog,
 	}, nil
 }
 
+// sortFeaturesByFOID orders features by their composite key (AGEN, FIDN, FIDS)
+// S-57 §7.6.2: FOID uniquely identifies a feature object across updates
+func sortFeaturesByFOID(features []Feature) {
+	sort.SliceStable(features, func(i, j int) bool {
+		a, b := features[i], features[j]
+		if a.Agency != b.Agency {
+			return a.Agency < b.Agency
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Subdivision < b.Subdivision
+	})
+}
+
 // extractDSID extracts and parses the DSID record from the ISO 8211 file.
 //
 // DSID (Data Set Identification) is the first field in every S-57 dataset's general
@@ -402,3 +648,14 @@ func contains(slice []string, item string) bool {
 	}
 	return false
 }
+
+// containsGeometryType checks if a geometry type is in the slice
+// A LineString entry also matches MultiLineString, so line layers keep split lines
+func containsGeometryType(types []GeometryType, t GeometryType) bool {
+	for _, candidate := range types {
+		if candidate == t || (candidate == GeometryTypeLineString && t == GeometryTypeMultiLineString) {
+			return true
+		}
+	}
+	return false
+}
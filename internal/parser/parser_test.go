@@ -2,7 +2,10 @@ package parser
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/beetlebugorg/iso8211/pkg/iso8211"
@@ -314,3 +317,324 @@ func TestDSIDFieldStructure(t *testing.T) {
 		}
 	}
 }
+
+// TestContinueOnRecordError verifies a truncated cell still yields a usable chart
+func TestContinueOnRecordError(t *testing.T) {
+	data, err := os.ReadFile("../../test/US4MD81M/US4MD81M.000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Truncate mid-record, as a partial download would
+	truncated := filepath.Join(t.TempDir(), "US4MD81M.000")
+	if err := os.WriteFile(truncated, data[:len(data)*2/3+7], 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	parser := NewParser()
+	opts := DefaultParseOptions()
+	opts.ApplyUpdates = false
+
+	// Without the option the whole chart fails
+	if _, err := parser.ParseWithOptions(truncated, opts); err == nil {
+		t.Fatal("Expected truncated cell to fail without ContinueOnRecordError")
+	}
+
+	var reported []error
+	opts.ContinueOnRecordError = true
+	opts.OnWarning = func(err error) { reported = append(reported, err) }
+
+	chart, err := parser.ParseWithOptions(truncated, opts)
+	if err != nil {
+		t.Fatalf("Expected partial chart, got error: %v", err)
+	}
+	if len(chart.Features) == 0 {
+		t.Error("Expected features from the salvaged records")
+	}
+	if len(chart.Warnings) == 0 {
+		t.Error("Expected the truncation to be recorded as a warning")
+	}
+	if len(reported) != len(chart.Warnings) {
+		t.Errorf("OnWarning called %d times, chart recorded %d warnings", len(reported), len(chart.Warnings))
+	}
+
+	t.Logf("Salvaged %d features, first warning: %v", len(chart.Features), chart.Warnings[0])
+}
+
+// TestVerifyCounts checks built feature counts against the DSSI declaration
+func TestVerifyCounts(t *testing.T) {
+	basePath := "../../test/US4MD81M/US4MD81M.000"
+	opts := DefaultParseOptions()
+	opts.VerifyCounts = true
+
+	// The test cell is complete, with or without its updates applied
+	for _, applyUpdates := range []bool{false, true} {
+		opts.ApplyUpdates = applyUpdates
+		parser, err := DefaultParser()
+		if err != nil {
+			t.Fatalf("Failed to create parser: %v", err)
+		}
+		parsed, err := parser.ParseWithOptions(basePath, opts)
+		if err != nil {
+			t.Fatalf("Failed to parse chart (updates=%v): %v", applyUpdates, err)
+		}
+		for _, w := range parsed.Warnings {
+			var mismatch *ErrFeatureCountMismatch
+			if errors.As(w, &mismatch) {
+				t.Errorf("Unexpected count mismatch (updates=%v): %v", applyUpdates, w)
+			}
+		}
+	}
+	opts.ApplyUpdates = false
+
+	// Filtered features are accounted for
+	filterOpts := opts
+	filterOpts.ObjectClassFilter = []string{"DEPARE"}
+	data, params, metadata, err := parseBaseFile(basePath, filterOpts)
+	if err != nil {
+		t.Fatalf("Failed to parse base file: %v", err)
+	}
+	chart, err := buildChart(data, metadata, params, filterOpts)
+	if err != nil {
+		t.Fatalf("Failed to build filtered chart: %v", err)
+	}
+	if len(chart.Warnings) != 0 {
+		t.Errorf("Expected no count warnings with a class filter, got %v", chart.Warnings)
+	}
+
+	// A geo feature whose spatial references are lost is silently skipped
+	// under SkipUnknownFeatures, the kind of loss VerifyCounts exists to catch
+	opts.SkipUnknownFeatures = true
+	dropGeoFeature := func(data *chartData) {
+		for _, featureRec := range data.features {
+			if categorizeFeature(featureRec.ObjectClass) == featureCategoryGeo && featureRec.GeomPrim != 255 {
+				featureRec.SpatialRefs = nil
+				return
+			}
+		}
+	}
+
+	data, params, metadata, err = parseBaseFile(basePath, opts)
+	if err != nil {
+		t.Fatalf("Failed to parse base file: %v", err)
+	}
+	dropGeoFeature(data)
+	chart, err = buildChart(data, metadata, params, opts)
+	if err != nil {
+		t.Fatalf("Mismatch should be a warning without StrictCounts: %v", err)
+	}
+	var mismatch *ErrFeatureCountMismatch
+	if len(chart.Warnings) != 1 || !errors.As(chart.Warnings[0], &mismatch) {
+		t.Fatalf("Expected one ErrFeatureCountMismatch warning, got %v", chart.Warnings)
+	}
+	if mismatch.Category != "geo" || mismatch.Parsed != mismatch.Expected-1 {
+		t.Errorf("Unexpected mismatch: %+v", mismatch)
+	}
+
+	// Strict mode fails the parse
+	strictOpts := opts
+	strictOpts.StrictCounts = true
+	data, params, metadata, err = parseBaseFile(basePath, strictOpts)
+	if err != nil {
+		t.Fatalf("Failed to parse base file: %v", err)
+	}
+	dropGeoFeature(data)
+	if _, err := buildChart(data, metadata, params, strictOpts); !errors.As(err, &mismatch) {
+		t.Errorf("Expected ErrFeatureCountMismatch in strict mode, got %v", err)
+	}
+}
+
+// TestSortFeatures tests base-only and updated parses share one FOID ordering
+func TestSortFeatures(t *testing.T) {
+	basePath := "../../test/US4MD81M/US4MD81M.000"
+	opts := DefaultParseOptions()
+	opts.SortFeatures = true
+
+	baseOpts := opts
+	baseOpts.ApplyUpdates = false
+	base, err := NewParser().ParseWithOptions(basePath, baseOpts)
+	if err != nil {
+		t.Fatalf("Failed to parse base cell: %v", err)
+	}
+	updated, err := NewParser().ParseWithOptions(basePath, opts)
+	if err != nil {
+		t.Fatalf("Failed to parse updated cell: %v", err)
+	}
+
+	key := func(f Feature) featureID { return featureID{AGEN: f.Agency, FIDN: uint32(f.ID), FIDS: f.Subdivision} }
+	less := func(a, b featureID) bool {
+		if a.AGEN != b.AGEN {
+			return a.AGEN < b.AGEN
+		}
+		if a.FIDN != b.FIDN {
+			return a.FIDN < b.FIDN
+		}
+		return a.FIDS < b.FIDS
+	}
+
+	for name, chart := range map[string]*Chart{"base": base, "updated": updated} {
+		for i := 1; i < len(chart.Features); i++ {
+			if less(key(chart.Features[i]), key(chart.Features[i-1])) {
+				t.Fatalf("%s: features %d and %d are out of FOID order", name, i-1, i)
+			}
+		}
+	}
+
+	// Features present in both parses appear in the same relative order
+	inBase := make(map[featureID]bool)
+	for _, f := range base.Features {
+		inBase[key(f)] = true
+	}
+	var common []featureID
+	for _, f := range updated.Features {
+		if inBase[key(f)] {
+			common = append(common, key(f))
+		}
+	}
+	inUpdated := make(map[featureID]bool)
+	for _, k := range common {
+		inUpdated[k] = true
+	}
+	i := 0
+	for _, f := range base.Features {
+		if !inUpdated[key(f)] {
+			continue // Deleted by an update
+		}
+		if key(f) != common[i] {
+			t.Fatalf("Common feature %d differs: base %v, updated %v", i, key(f), common[i])
+		}
+		i++
+	}
+	if i != len(common) {
+		t.Errorf("Compared %d of %d common features", i, len(common))
+	}
+}
+
+// TestDuplicateFeatureRecords tests that a FOID repeated within a base cell
+// yields one feature, the highest RVER, and a warning
+func TestDuplicateFeatureRecords(t *testing.T) {
+	path := "../../test/US4MD81M/US4MD81M.000"
+	opts := DefaultParseOptions()
+	isoFile, err := parseISO8211(path, opts, nil)
+	if err != nil {
+		t.Fatalf("Failed to read ISO 8211 file: %v", err)
+	}
+
+	// Duplicate the first feature record with a higher RVER
+	var original *iso8211.DataRecord
+	for _, record := range isoFile.Records {
+		if _, ok := record.Fields["FRID"]; ok {
+			original = record
+			break
+		}
+	}
+	if original == nil {
+		t.Fatal("No feature record in test chart")
+	}
+	fields := make(map[string][]byte, len(original.Fields))
+	for tag, data := range original.Fields {
+		fields[tag] = data
+	}
+	frid := append([]byte{}, original.Fields["FRID"]...)
+	binary.LittleEndian.PutUint16(frid[9:11], binary.LittleEndian.Uint16(frid[9:11])+1)
+	fields["FRID"] = frid
+	isoFile.Records = append(isoFile.Records, &iso8211.DataRecord{Fields: fields})
+
+	warnings := newWarningCollector(nil)
+	data, params, metadata, err := parseDataset(path, isoFile, opts, warnings)
+	if err != nil {
+		t.Fatalf("Failed to parse dataset: %v", err)
+	}
+
+	foid := original.Fields["FOID"]
+	key := featureID{
+		AGEN: binary.LittleEndian.Uint16(foid[0:2]),
+		FIDN: binary.LittleEndian.Uint32(foid[2:6]),
+		FIDS: binary.LittleEndian.Uint16(foid[6:8]),
+	}
+	copies := 0
+	for _, featureRec := range data.features {
+		if featureRec.AGEN == key.AGEN && featureRec.FIDN == key.FIDN && featureRec.FIDS == key.FIDS {
+			copies++
+		}
+	}
+	if copies != 1 {
+		t.Errorf("Expected 1 feature record for the duplicated FOID, got %d", copies)
+	}
+	if kept := data.featuresByID[key].RecordVersion; kept != int(binary.LittleEndian.Uint16(frid[9:11])) {
+		t.Errorf("Expected the higher RVER to be kept, got %d", kept)
+	}
+
+	var duplicate *ErrDuplicateFeature
+	if len(warnings.list()) != 1 || !errors.As(warnings.list()[0], &duplicate) {
+		t.Fatalf("Expected one ErrDuplicateFeature warning, got %v", warnings.list())
+	}
+	if duplicate.FIDN != key.FIDN || duplicate.KeptVersion != duplicate.DroppedVersion+1 {
+		t.Errorf("Unexpected warning: %v", duplicate)
+	}
+
+	chart, err := buildChart(data, metadata, params, opts)
+	if err != nil {
+		t.Fatalf("Failed to build chart: %v", err)
+	}
+	built := 0
+	for _, f := range chart.Features {
+		if f.Agency == key.AGEN && f.ID == int64(key.FIDN) && f.Subdivision == key.FIDS {
+			built++
+		}
+	}
+	if built != 1 {
+		t.Errorf("Expected a single feature for the duplicated FOID, got %d", built)
+	}
+}
+
+// TestGeometryErrorFeatureID tests that geometry errors raised while building a
+// feature carry its ID and the offending spatial record
+func TestGeometryErrorFeatureID(t *testing.T) {
+	data := &chartData{
+		features: []*featureRecord{{
+			ID:          42,
+			ObjectClass: 42, // DEPARE
+			GeomPrim:    3,
+			SpatialRefs: []spatialRef{{RCNM: int(spatialTypeFace), RCID: 1}},
+		}},
+		spatialRecords: map[spatialKey]*spatialRecord{
+			{RCNM: int(spatialTypeFace), RCID: 1}: {
+				ID:         1,
+				RecordType: spatialTypeFace,
+				VectorPointers: []vectorPointer{
+					{TargetRCNM: int(spatialTypeEdge), TargetRCID: 7, Orientation: 1, Usage: 1},
+				},
+			},
+		},
+		warnings: newWarningCollector(nil),
+	}
+
+	_, err := buildChart(data, nil, defaultDatasetParams(), DefaultParseOptions())
+	var missing *ErrMissingSpatialRecord
+	if !errors.As(err, &missing) {
+		t.Fatalf("Expected ErrMissingSpatialRecord, got %v", err)
+	}
+	if missing.FeatureID != 42 || missing.SpatialID != 7 {
+		t.Errorf("Expected feature 42 and spatial record 7, got %+v", missing)
+	}
+
+	// The same error is reported as a warning under ContinueOnRecordError
+	opts := DefaultParseOptions()
+	opts.ContinueOnRecordError = true
+	data.warnings = newWarningCollector(nil)
+	if _, err := buildChart(data, nil, defaultDatasetParams(), opts); err != nil {
+		t.Fatalf("Expected the feature to be skipped, got %v", err)
+	}
+	warnings := data.warnings.list()
+	if len(warnings) != 1 || !errors.As(warnings[0], &missing) || missing.FeatureID != 42 {
+		t.Errorf("Expected an ErrMissingSpatialRecord warning for feature 42, got %v", warnings)
+	}
+
+	// Errors raised with the feature unknown get its ID
+	invalid := &ErrInvalidGeometry{Reason: "no coordinates"}
+	setFeatureID(fmt.Errorf("wrapped: %w", invalid), 9)
+	if invalid.FeatureID != 9 {
+		t.Errorf("Expected feature 9, got %d", invalid.FeatureID)
+	}
+}
@@ -0,0 +1,133 @@
+package parser
+
+// primitive.go - Detection and healing of FRID PRIM / spatial type mismatches
+//
+// The FRID PRIM subfield declares a feature's geometric primitive, but some
+// producer data contradicts it: PRIM=1 (point) features that reference edges,
+// PRIM=2 (line) features that reference only nodes, or PRIM=3 (area) features
+// whose edges never form a closed boundary. Building geometry from PRIM alone
+// silently produces degenerate geometry for these features.
+//
+// Healing heuristic (ParseOptions.HealPrimitiveMismatch):
+//   - Point referencing edges and no nodes: built as a LineString from the edges
+//   - Line referencing nodes and no edges: built as a (multi)Point from the nodes
+//   - Area whose boundary edges do not close: built as a LineString along the
+//     boundary instead of being force-closed into a polygon
+//
+// Without healing, the feature is built from PRIM as before and the mismatch is
+// reported as a warning.
+
+// primitiveMismatch describes how a feature's spatial references contradict PRIM
+type primitiveMismatch struct {
+	resolved GeometryType // Geometry type implied by the referenced spatial records
+	reason   string       // Human-readable description for warnings
+	refs     []spatialRef // Spatial references to build the resolved geometry from
+}
+
+// detectPrimitiveMismatch compares PRIM with the types of the referenced spatial records
+// Returns nil when they agree or when the references are too incomplete to judge
+func detectPrimitiveMismatch(featureRec *featureRecord, spatialRecords map[spatialKey]*spatialRecord) *primitiveMismatch {
+	var nodes, edges int
+	edgeRefs := make([]spatialRef, 0)
+
+	for _, ref := range featureRec.SpatialRefs {
+		spatial := lookupSpatialRef(ref, spatialRecords)
+		if spatial == nil {
+			continue
+		}
+
+		switch spatial.RecordType {
+		case spatialTypeIsolatedNode, spatialTypeConnectedNode:
+			nodes++
+		case spatialTypeEdge:
+			edges++
+			edgeRefs = append(edgeRefs, ref)
+		case spatialTypeFace:
+			// Faces contribute their boundary edges
+			for _, ptr := range spatial.VectorPointers {
+				if ptr.TargetRCNM == int(spatialTypeEdge) {
+					edges++
+					edgeRefs = append(edgeRefs, spatialRef{
+						RCNM:        ptr.TargetRCNM,
+						RCID:        ptr.TargetRCID,
+						Orientation: ptr.Orientation,
+						Usage:       ptr.Usage,
+						Mask:        ptr.Mask,
+					})
+				}
+			}
+		}
+	}
+
+	switch featureRec.GeomPrim {
+	case 1: // Point
+		if nodes == 0 && edges > 0 {
+			return &primitiveMismatch{
+				resolved: GeometryTypeLineString,
+				reason:   "point feature references edges",
+				refs:     edgeRefs,
+			}
+		}
+	case 2: // Line
+		if edges == 0 && nodes > 0 {
+			return &primitiveMismatch{
+				resolved: GeometryTypePoint,
+				reason:   "line feature references only nodes",
+				refs:     featureRec.SpatialRefs,
+			}
+		}
+	case 3: // Area
+		if edges > 0 && !edgesClose(edgeRefs, spatialRecords) {
+			return &primitiveMismatch{
+				resolved: GeometryTypeLineString,
+				reason:   "area boundary edges do not close",
+				refs:     edgeRefs,
+			}
+		}
+	}
+
+	return nil
+}
+
+// edgesClose reports whether a set of edges forms closed rings.
+//
+// Edges form closed rings exactly when every node is the endpoint of an even
+// number of edges. Edges whose nodes are unknown cannot be judged, so they are
+// assumed to close.
+func edgesClose(edgeRefs []spatialRef, spatialRecords map[spatialKey]*spatialRecord) bool {
+	resolver := newPolygonBuilder(spatialRecords)
+	degree := make(map[int64]int)
+
+	for _, ref := range edgeRefs {
+		edge, err := resolver.loadEdge(ref.RCID)
+		if err != nil {
+			continue
+		}
+		if edge.StartNodeID == 0 || edge.EndNodeID == 0 {
+			return true
+		}
+		degree[edge.StartNodeID]++
+		degree[edge.EndNodeID]++
+	}
+
+	for _, count := range degree {
+		if count%2 != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupSpatialRef finds the spatial record a feature references.
+// Uses the RCNM from FSPT when known, otherwise tries each record type in turn.
+func lookupSpatialRef(ref spatialRef, spatialRecords map[spatialKey]*spatialRecord) *spatialRecord {
+	if ref.RCNM != 0 {
+		return spatialRecords[spatialKey{RCNM: ref.RCNM, RCID: ref.RCID}]
+	}
+	for _, rcnm := range []spatialType{spatialTypeIsolatedNode, spatialTypeConnectedNode, spatialTypeEdge, spatialTypeFace} {
+		if sp, ok := spatialRecords[spatialKey{RCNM: int(rcnm), RCID: ref.RCID}]; ok {
+			return sp
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/beetlebugorg/iso8211/pkg/iso8211"
+)
+
+// warningCollector records non-fatal problems found while parsing
+// Each warning is kept for Chart.Warnings and forwarded to the optional handler
+type warningCollector struct {
+	handler  func(error)
+	warnings []error
+}
+
+// newWarningCollector creates a collector forwarding to handler (may be nil)
+func newWarningCollector(handler func(error)) *warningCollector {
+	return &warningCollector{handler: handler}
+}
+
+// warn records a warning and forwards it to the handler
+func (w *warningCollector) warn(err error) {
+	if w == nil {
+		return
+	}
+	w.warnings = append(w.warnings, err)
+	if w.handler != nil {
+		w.handler(err)
+	}
+}
+
+// list returns the recorded warnings
+func (w *warningCollector) list() []error {
+	if w == nil {
+		return nil
+	}
+	return w.warnings
+}
+
+// parseISO8211 reads an ISO 8211 file, salvaging complete records from a
+// truncated or corrupt file when opts.ContinueOnRecordError is set
+func parseISO8211(filename string, opts ParseOptions, warnings *warningCollector) (*iso8211.ISO8211File, error) {
+	// Report a missing or unreadable file as the bare *os.PathError so
+	// os.IsNotExist and os.IsPermission work for callers
+	if _, err := os.Stat(filename); err != nil {
+		return nil, err
+	}
+
+	reader, err := iso8211.NewReader(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer reader.Close()
+
+	isoFile, err := reader.Parse()
+	if err == nil {
+		return isoFile, nil
+	}
+	if !opts.ContinueOnRecordError {
+		return nil, fmt.Errorf("%w: failed to parse ISO 8211: %w", ErrNotS57, err)
+	}
+
+	salvaged, dropped, salvageErr := salvageISO8211(filename)
+	if salvageErr != nil {
+		return nil, fmt.Errorf("%w: failed to parse ISO 8211: %w", ErrNotS57, err)
+	}
+
+	warnings.warn(fmt.Errorf("%s: %w (recovered %d records, dropped %d bytes)",
+		filename, err, len(salvaged.Records), dropped))
+	return salvaged, nil
+}
+
+// salvageISO8211 re-parses the longest prefix of complete records in a file.
+//
+// ISO 8211 records start with a 24-byte leader whose first 5 ASCII digits give
+// the record length, so complete records can be located without decoding them.
+// Everything from the first incomplete or malformed record onward is dropped.
+// Returns the parsed prefix and the number of bytes dropped.
+func salvageISO8211(filename string) (*iso8211.ISO8211File, int, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Walk record leaders to find where complete records end
+	end := 0
+	for end+24 <= len(data) {
+		length, err := strconv.Atoi(string(data[end : end+5]))
+		if err != nil || length < 24 || end+length > len(data) {
+			break
+		}
+		end += length
+	}
+	if end == 0 {
+		return nil, 0, fmt.Errorf("no complete ISO 8211 records")
+	}
+
+	// The ISO 8211 reader only reads files, so stage the salvaged prefix
+	tmp, err := os.CreateTemp("", "s57-salvage-")
+	if err != nil {
+		return nil, 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data[:end]); err != nil {
+		tmp.Close()
+		return nil, 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, 0, err
+	}
+
+	reader, err := iso8211.NewReader(tmp.Name())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer reader.Close()
+
+	isoFile, err := reader.Parse()
+	if err != nil {
+		return nil, 0, err
+	}
+	return isoFile, len(data) - end, nil
+}
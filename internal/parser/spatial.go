@@ -43,7 +43,11 @@ const (
 // Returns nil if record is not a spatial record
 // S-57 §7.7.1.1: Spatial records identified by VRID field
 func parseSpatialRecordWithParams(record *iso8211.DataRecord, params datasetParams) *spatialRecord {
-	return parseSpatialRecordInternal(record, params.COMF, params.SOMF)
+	spatialRec := parseSpatialRecordInternal(record, params.COMF, params.SOMF)
+	if spatialRec != nil {
+		roundCoordinates(spatialRec.Coordinates, params.precision)
+	}
+	return spatialRec
 }
 
 // parseSpatialRecordInternal is the internal implementation
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"encoding/binary"
+	"math"
 	"testing"
 )
 
@@ -249,3 +250,139 @@ func TestCircularReferenceDetection(t *testing.T) {
 		t.Errorf("Expected at least 1 coordinate, got %d", len(coords))
 	}
 }
+
+// TestCoordinatePrecision verifies rounding keeps polygon rings closed
+func TestCoordinatePrecision(t *testing.T) {
+	opts := DefaultParseOptions()
+	opts.CoordinatePrecision = 6
+
+	chart, err := NewParser().ParseWithOptions("../../test/US4MD81M/US4MD81M.000", opts)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	polygons := 0
+	for _, f := range chart.Features {
+		coords := f.Geometry.Coordinates
+		for _, coord := range coords {
+			for _, v := range coord[:2] {
+				if scaled := v * 1e6; math.Abs(scaled-math.Round(scaled)) > 1e-6 {
+					t.Fatalf("Feature %d coordinate %v not rounded to 6 decimals", f.ID, coord)
+				}
+			}
+		}
+
+		if f.Geometry.Type != GeometryTypePolygon || len(coords) == 0 {
+			continue
+		}
+		polygons++
+		for _, ring := range PolygonRings(coords) {
+			first, last := ring[0], ring[len(ring)-1]
+			if first[0] != last[0] || first[1] != last[1] {
+				t.Errorf("Feature %d ring not closed after rounding: %v != %v", f.ID, first, last)
+			}
+		}
+	}
+	if polygons == 0 {
+		t.Fatal("Expected polygon features in test chart")
+	}
+
+	// Edge shape points are rounded with the node they share coordinates with
+	coords := [][]float64{{-76.12345678, 38.98765432, 12.345}, {-76.12345678, 38.98765432}}
+	roundCoordinates(coords, 6)
+	if coords[0][0] != coords[1][0] || coords[0][1] != coords[1][1] {
+		t.Errorf("Identical coordinates rounded differently: %v", coords)
+	}
+	if coords[0][0] != -76.123457 || coords[0][2] != 12.345 {
+		t.Errorf("Unexpected rounding result: %v", coords[0])
+	}
+}
+
+// TestFactorOverride tests forcing COMF/SOMF for producer data with a wrong DSPM
+func TestFactorOverride(t *testing.T) {
+	path := "../../test/US4MD81M/US4MD81M.000"
+	baseline, err := NewParser().ParseWithOptions(path, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	// The file declares COMF=10^7 and SOMF=10; forcing ten times those
+	// scales every coordinate and depth down by ten
+	opts := DefaultParseOptions()
+	opts.CoordinateFactorOverride = 100000000
+	opts.SoundingFactorOverride = 100
+	chart, err := NewParser().ParseWithOptions(path, opts)
+	if err != nil {
+		t.Fatalf("Failed to parse chart with overrides: %v", err)
+	}
+	if len(chart.Features) != len(baseline.Features) {
+		t.Fatalf("Expected %d features, got %d", len(baseline.Features), len(chart.Features))
+	}
+
+	soundings := 0
+	for i, f := range chart.Features {
+		for j, coord := range f.Geometry.Coordinates {
+			if coord[0] < -7.8 || coord[0] > -7.4 || coord[1] < 3.7 || coord[1] > 4.0 {
+				t.Fatalf("Feature %d coordinate %v outside the expected range", f.ID, coord)
+			}
+			if len(coord) > 2 {
+				want := baseline.Features[i].Geometry.Coordinates[j][2] / 10
+				if math.Abs(coord[2]-want) > 1e-9 {
+					t.Fatalf("Feature %d depth %v, expected %v", f.ID, coord[2], want)
+				}
+				soundings++
+			}
+		}
+	}
+	if soundings == 0 {
+		t.Fatal("Expected soundings in test chart")
+	}
+}
+
+// TestUsedDefaultParameters tests the flag raised when DSPM is missing or short
+func TestUsedDefaultParameters(t *testing.T) {
+	path := "../../test/US4MD81M/US4MD81M.000"
+	isoFile, err := parseISO8211(path, DefaultParseOptions(), newWarningCollector(nil))
+	if err != nil {
+		t.Fatalf("Failed to read ISO 8211 file: %v", err)
+	}
+	if params := extractDatasetParams(isoFile, DefaultParseOptions()); params.defaulted {
+		t.Error("Expected DSPM parameters from the test chart, got defaults")
+	}
+
+	// Synthetic cell: the same records with the DSPM field removed
+	withoutDSPM := *isoFile
+	withoutDSPM.Records = nil
+	for _, record := range isoFile.Records {
+		if _, ok := record.Fields["DSPM"]; !ok {
+			withoutDSPM.Records = append(withoutDSPM.Records, record)
+		}
+	}
+	params := extractDatasetParams(&withoutDSPM, DefaultParseOptions())
+	if !params.defaulted {
+		t.Error("Expected default parameters for a cell without DSPM")
+	}
+	if params.COMF != 10000000 || params.SOMF != 10 {
+		t.Errorf("Expected default COMF/SOMF, got %d/%d", params.COMF, params.SOMF)
+	}
+
+	// A truncated DSPM falls back to defaults too
+	if !parseDSPM([]byte{20, 1, 0, 0, 0, 2}).defaulted {
+		t.Error("Expected default parameters for a short DSPM")
+	}
+
+	data, _, metadata, err := parseBaseFile(path, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("Failed to parse base file: %v", err)
+	}
+	chart, err := buildChart(data, metadata, params, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("Failed to build chart: %v", err)
+	}
+	if !chart.UsedDefaultParameters() {
+		t.Error("Expected UsedDefaultParameters for a chart without DSPM")
+	}
+	if chart.CoordinateUnits() != 0 {
+		t.Errorf("Expected unknown coordinate units, got %d", chart.CoordinateUnits())
+	}
+}
@@ -1,5 +1,10 @@
 package parser
 
+import (
+	"math"
+	"sort"
+)
+
 // topology.go - VRPT (Vector Record Pointer Table) topology resolution
 // Implements S-57 Edition 3.1 polygon construction from edge references
 
@@ -13,24 +18,27 @@ type spatialKey struct {
 // edge represents a spatial edge record with connectivity information
 // S-57 §5.1.3.2 (31Main.pdf): Edges connect nodes to form polygon boundaries
 type edge struct {
-	ID          int64        // Edge record ID (RCID)
-	Points      [][2]float64 // Coordinate points along the edge [lon, lat]
-	StartNodeID int64        // ID of starting node
-	EndNodeID   int64        // ID of ending node
+	ID          int64       // Edge record ID (RCID)
+	Points      [][]float64 // Coordinate points along the edge [lon, lat] or [lon, lat, depth]
+	StartNodeID int64       // ID of starting node
+	EndNodeID   int64       // ID of ending node
 }
 
 // polygonBuilder constructs polygon geometries from topological primitives (edges/nodes)
 // Caches edges to avoid redundant lookups during ring construction
 type polygonBuilder struct {
-	spatialRecords map[spatialKey]*spatialRecord // Spatial records indexed by (RCNM, RCID)
-	edgeCache      map[int64]*edge               // Cached edges for reuse
+	spatialRecords   map[spatialKey]*spatialRecord // Spatial records indexed by (RCNM, RCID)
+	edgeCache        map[int64]*edge               // Cached edges for reuse
+	closureTolerance float64                       // Max endpoint gap, in degrees, of a closed ring
+	keep3D           bool                          // Carry depths through to coordinates (ParseOptions.Keep3D)
 }
 
 // newPolygonBuilder creates a new polygon builder with given spatial records
 func newPolygonBuilder(spatialRecords map[spatialKey]*spatialRecord) *polygonBuilder {
 	return &polygonBuilder{
-		spatialRecords: spatialRecords,
-		edgeCache:      make(map[int64]*edge),
+		spatialRecords:   spatialRecords,
+		edgeCache:        make(map[int64]*edge),
+		closureTolerance: defaultRingClosureTolerance,
 	}
 }
 
@@ -52,37 +60,38 @@ func (r *polygonBuilder) getNode(nodeID int64) *spatialRecord {
 
 // getFullEdgeCoordinates builds full edge coordinates: start node + SG2D + end node
 // Reverses the entire array if orientation==2 (like marinejet does)
-func (r *polygonBuilder) getFullEdgeCoordinates(edge *edge, orientation int) [][2]float64 {
-	coords := make([][2]float64, 0)
+// Each coordinate is a new slice carrying its own depth when keep3D is set
+func (r *polygonBuilder) getFullEdgeCoordinates(edge *edge, orientation int) [][]float64 {
+	coords := make([][]float64, 0, len(edge.Points)+2)
 
 	// Add start node
 	if edge.StartNodeID != 0 {
 		if node := r.getNode(edge.StartNodeID); node != nil && len(node.Coordinates) > 0 {
-			// Extract 2D coordinate (first 2 values) from variable-length coordinate
 			coord := node.Coordinates[0]
 			if len(coord) >= 2 {
-				coords = append(coords, [2]float64{coord[0], coord[1]})
+				coords = append(coords, copyCoordinate(coord, r.keep3D))
 			}
 		}
 	}
 
 	// Add SG2D intermediate points
-	coords = append(coords, edge.Points...)
+	for _, point := range edge.Points {
+		coords = append(coords, copyCoordinate(point, r.keep3D))
+	}
 
 	// Add end node
 	if edge.EndNodeID != 0 {
 		if node := r.getNode(edge.EndNodeID); node != nil && len(node.Coordinates) > 0 {
-			// Extract 2D coordinate (first 2 values) from variable-length coordinate
 			coord := node.Coordinates[0]
 			if len(coord) >= 2 {
-				coords = append(coords, [2]float64{coord[0], coord[1]})
+				coords = append(coords, copyCoordinate(coord, r.keep3D))
 			}
 		}
 	}
 
 	// Reverse if orientation is 2
 	if orientation == 2 {
-		reversed := make([][2]float64, len(coords))
+		reversed := make([][]float64, len(coords))
 		for i, coord := range coords {
 			reversed[len(coords)-1-i] = coord
 		}
@@ -143,11 +152,11 @@ func (r *polygonBuilder) loadEdge(edgeID int64) (*edge, error) {
 	// Nodes are stored separately and referenced via VRPT
 
 	// Edge.Points = SG2D coordinates only (may be empty for straight-line edges)
-	// Convert variable-length coordinates to fixed 2D coordinates
-	points := make([][2]float64, 0, len(spatial.Coordinates))
+	// Points keep their depth, if any; getFullEdgeCoordinates drops it unless keep3D
+	points := make([][]float64, 0, len(spatial.Coordinates))
 	for _, coord := range spatial.Coordinates {
 		if len(coord) >= 2 {
-			points = append(points, [2]float64{coord[0], coord[1]})
+			points = append(points, coord)
 		}
 	}
 
@@ -169,23 +178,34 @@ func (r *polygonBuilder) loadEdge(edgeID int64) (*edge, error) {
 // IMPORTANT: Despite S-57 §4.7.3 (31Main.pdf) saying edges "must be referenced sequentially",
 // real-world ENC files do NOT provide edges in sequential order. We must follow
 // topology graph by matching node connectivity.
-func (r *polygonBuilder) resolvePolygon(edgeRefs []spatialRef) ([][][2]float64, error) {
+func (r *polygonBuilder) resolvePolygon(edgeRefs []spatialRef) ([][][]float64, error) {
 	if len(edgeRefs) == 0 {
 		return nil, &ErrInvalidGeometry{
-			Reason: "no edge references provided",
+			Type:    GeometryTypePolygon,
+			HasType: true,
+			Reason:  "no edge references provided",
 		}
 	}
 
 	// Pre-load all edges and store with their orientations
 	edgeOrientations := make(map[int64]int) // edgeID -> orientation
+	var loadErr error
 	for _, edgeRef := range edgeRefs {
 		if _, err := r.loadEdge(edgeRef.RCID); err != nil {
 			// Skip edges that fail to load
+			if loadErr == nil {
+				loadErr = err
+			}
 			continue
 		}
 		edgeOrientations[edgeRef.RCID] = edgeRef.Orientation
 	}
 
+	// Report why when not a single edge could be loaded
+	if len(edgeOrientations) == 0 && loadErr != nil {
+		return nil, loadErr
+	}
+
 	// Build rings by following topology graph
 	return r.buildRingsWithOrientation(edgeRefs, edgeOrientations)
 }
@@ -193,9 +213,11 @@ func (r *polygonBuilder) resolvePolygon(edgeRefs []spatialRef) ([][][2]float64,
 // buildRingsWithOrientation constructs polygon rings using FSPT edge order
 // Follows marinejet's approach: iterate edges in FSPT order, apply orientation, deduplicate nodes
 // Per S-57 §4.7.3 (31Main.pdf): "vector records making up an area boundary must be referenced sequentially"
-func (r *polygonBuilder) buildRingsWithOrientation(edgeRefs []spatialRef, orientations map[int64]int) ([][][2]float64, error) {
-	// Build single ring from edges in FSPT order (matching marinejet lines 373-446)
-	coords := make([][2]float64, 0)
+// The exterior boundary comes first; each interior boundary (hole) follows as its own
+// ring once the previous ring has closed on its first vertex.
+func (r *polygonBuilder) buildRingsWithOrientation(edgeRefs []spatialRef, orientations map[int64]int) ([][][]float64, error) {
+	rings := make([][][]float64, 0, 1)
+	coords := make([][]float64, 0)
 
 	for _, edgeRef := range edgeRefs {
 		// Load edge
@@ -217,28 +239,217 @@ func (r *polygonBuilder) buildRingsWithOrientation(edgeRefs []spatialRef, orient
 		}
 
 		coords = append(coords, edgeCoords...)
+
+		// A ring is complete when it returns to its start; the next edge begins a hole
+		if len(coords) >= 4 && isRingClosed(coords, r.closureTolerance) {
+			coords[len(coords)-1] = closingCoordinate(coords[0])
+			rings = append(rings, coords)
+			coords = make([][]float64, 0)
+		}
 	}
 
-	// Ensure ring closure
-	if len(coords) > 0 && !isRingClosed(coords) {
-		coords = append(coords, coords[0])
+	// Close a trailing ring whose edges never returned to its start
+	if len(coords) > 0 {
+		if isRingClosed(coords, r.closureTolerance) {
+			coords[len(coords)-1] = closingCoordinate(coords[0])
+		} else {
+			coords = append(coords, closingCoordinate(coords[0]))
+		}
+		rings = append(rings, coords)
 	}
 
-	if len(coords) == 0 {
+	if len(rings) == 0 {
 		return nil, &ErrInvalidGeometry{
-			Reason: "no coordinates collected from edges",
+			Type:    GeometryTypePolygon,
+			HasType: true,
+			Reason:  "no coordinates collected from edges",
+		}
+	}
+
+	return rings, nil
+}
+
+// maskIndicatorMask is the FSPT/VRPT MASK value for a masked (hidden) edge
+// S-57 §7.6.8: MASK 1=Mask, 2=Show, 255=Null
+const maskIndicatorMask = 1
+
+// visibleBoundary returns the runs of a polygon's outline that are not masked.
+//
+// Masked edges (MASK=1) still close the ring used for fill, but must not be drawn
+// as boundary, e.g. where a coastline is masked against a neighbouring cell. Edges
+// are walked in FSPT order like buildRingsWithOrientation; consecutive visible
+// edges that join end to start form one run. Returns nil when no edge is masked,
+// meaning the whole ring is visible.
+func (r *polygonBuilder) visibleBoundary(edgeRefs []spatialRef) [][][]float64 {
+	masked := false
+	for _, edgeRef := range edgeRefs {
+		if edgeRef.Mask == maskIndicatorMask {
+			masked = true
+			break
+		}
+	}
+	if !masked {
+		return nil
+	}
+
+	runs := make([][][]float64, 0)
+	var current [][]float64
+	flush := func() {
+		if len(current) >= 2 {
+			runs = append(runs, current)
 		}
+		current = nil
 	}
 
-	return [][][2]float64{coords}, nil
+	for _, edgeRef := range edgeRefs {
+		edge, err := r.loadEdge(edgeRef.RCID)
+		if err != nil {
+			continue // Skip failed edges
+		}
+		if edgeRef.Mask == maskIndicatorMask {
+			flush()
+			continue
+		}
+
+		edgeCoords := r.getFullEdgeCoordinates(edge, edgeRef.Orientation)
+		if len(current) > 0 && len(edgeCoords) > 0 {
+			last, first := current[len(current)-1], edgeCoords[0]
+			if last[0] == first[0] && last[1] == first[1] {
+				edgeCoords = edgeCoords[1:]
+			} else {
+				flush() // Not connected - start a new run
+			}
+		}
+		current = append(current, edgeCoords...)
+	}
+	flush()
+
+	return runs
 }
 
-// isRingClosed checks if a ring is properly closed
-func isRingClosed(ring [][2]float64) bool {
+// defaultRingClosureTolerance is the largest gap, in degrees, between a ring's first
+// and last coordinates that still counts as closed (about 1 mm, a tenth of the
+// 10^-7 degree ENC coordinate resolution). Larger gaps are real openings.
+const defaultRingClosureTolerance = 1e-8
+
+// ringClosureTolerance returns the closure tolerance for ParseOptions.RingClosureTolerance
+// 0 selects the default, a negative value requires exact equality
+func ringClosureTolerance(opts ParseOptions) float64 {
+	switch {
+	case opts.RingClosureTolerance == 0:
+		return defaultRingClosureTolerance
+	case opts.RingClosureTolerance < 0:
+		return 0
+	}
+	return opts.RingClosureTolerance
+}
+
+// isRingClosed checks if a ring is closed, allowing a floating-point residue of
+// up to tolerance degrees between its first and last coordinates
+func isRingClosed(ring [][]float64, tolerance float64) bool {
 	if len(ring) < 3 {
 		return false
 	}
 	first := ring[0]
 	last := ring[len(ring)-1]
-	return first[0] == last[0] && first[1] == last[1]
+	return math.Abs(first[0]-last[0]) <= tolerance && math.Abs(first[1]-last[1]) <= tolerance
+}
+
+// TopologyNode is a node primitive with the edges that meet at it
+type TopologyNode struct {
+	ID          int64       // Node record ID (RCID)
+	Connected   bool        // True for connected nodes (RCNM=120), false for isolated (RCNM=110)
+	Coordinates [][]float64 // Node position(s) [lon, lat] or [lon, lat, depth]
+	Edges       []int64     // IDs of edges starting or ending at this node
+}
+
+// TopologyEdge is an edge primitive with its bounding nodes and faces
+type TopologyEdge struct {
+	ID          int64        // Edge record ID (RCID)
+	StartNode   int64        // Beginning node ID (0 if missing)
+	EndNode     int64        // End node ID (0 if missing)
+	LeftFace    int64        // Face on the left (0 unless full topology)
+	RightFace   int64        // Face on the right (0 unless full topology)
+	Coordinates [][2]float64 // Start node + SG2D shape points + end node
+}
+
+// Topology is the resolved edge/node graph of a chart
+type Topology struct {
+	Nodes []TopologyNode // Sorted by ID, isolated before connected for equal IDs
+	Edges []TopologyEdge // Sorted by ID
+}
+
+// Topology builds the edge/node graph from the chart's spatial records
+// S-57 §5.1.3.2 (31Main.pdf): Edges reference their nodes and faces via VRPT
+func (c *Chart) Topology() *Topology {
+	resolver := newPolygonBuilder(c.spatialRecords)
+	nodes := make(map[spatialKey]*TopologyNode)
+	topo := &Topology{}
+
+	for key, spatial := range c.spatialRecords {
+		switch spatialType(key.RCNM) {
+		case spatialTypeIsolatedNode, spatialTypeConnectedNode:
+			// Isolated and connected nodes have separate RCID ranges
+			nodes[key] = &TopologyNode{
+				ID:          key.RCID,
+				Connected:   key.RCNM == int(spatialTypeConnectedNode),
+				Coordinates: spatial.Coordinates,
+			}
+		}
+	}
+
+	for key, spatial := range c.spatialRecords {
+		if key.RCNM != int(spatialTypeEdge) {
+			continue
+		}
+		e, err := resolver.loadEdge(key.RCID)
+		if err != nil {
+			continue
+		}
+
+		topoEdge := TopologyEdge{
+			ID:        e.ID,
+			StartNode: e.StartNodeID,
+			EndNode:   e.EndNodeID,
+		}
+		for _, coord := range resolver.getFullEdgeCoordinates(e, 1) {
+			topoEdge.Coordinates = append(topoEdge.Coordinates, [2]float64{coord[0], coord[1]})
+		}
+		for _, ptr := range spatial.VectorPointers {
+			if ptr.TargetRCNM != int(spatialTypeFace) {
+				continue
+			}
+			switch ptr.Topology {
+			case 3: // Left face
+				topoEdge.LeftFace = ptr.TargetRCID
+			case 4: // Right face
+				topoEdge.RightFace = ptr.TargetRCID
+			}
+		}
+		topo.Edges = append(topo.Edges, topoEdge)
+
+		for _, nodeID := range []int64{e.StartNodeID, e.EndNodeID} {
+			// Edges end at connected nodes; fall back to isolated like getNode
+			if node, ok := nodes[spatialKey{RCNM: int(spatialTypeConnectedNode), RCID: nodeID}]; ok {
+				node.Edges = append(node.Edges, e.ID)
+			} else if node, ok := nodes[spatialKey{RCNM: int(spatialTypeIsolatedNode), RCID: nodeID}]; ok {
+				node.Edges = append(node.Edges, e.ID)
+			}
+		}
+	}
+
+	sort.Slice(topo.Edges, func(i, j int) bool { return topo.Edges[i].ID < topo.Edges[j].ID })
+	topo.Nodes = make([]TopologyNode, 0, len(nodes))
+	for _, node := range nodes {
+		sort.Slice(node.Edges, func(i, j int) bool { return node.Edges[i] < node.Edges[j] })
+		topo.Nodes = append(topo.Nodes, *node)
+	}
+	sort.Slice(topo.Nodes, func(i, j int) bool {
+		if topo.Nodes[i].ID != topo.Nodes[j].ID {
+			return topo.Nodes[i].ID < topo.Nodes[j].ID
+		}
+		return !topo.Nodes[i].Connected && topo.Nodes[j].Connected
+	})
+
+	return topo
 }
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -181,7 +182,7 @@ func TestVRPTResolver(t *testing.T) {
 
 			// Validate ring closure
 			for i, ring := range rings {
-				if !isRingClosed(ring) {
+				if !isRingClosed(ring, defaultRingClosureTolerance) {
 					t.Errorf("%s: ring %d is not closed", tt.description, i)
 				}
 			}
@@ -263,12 +264,12 @@ func TestLoadEdge(t *testing.T) {
 func TestRingClosure(t *testing.T) {
 	tests := []struct {
 		name     string
-		ring     [][2]float64
+		ring     [][]float64
 		expected bool
 	}{
 		{
 			name: "Closed ring",
-			ring: [][2]float64{
+			ring: [][]float64{
 				{0.0, 0.0},
 				{1.0, 0.0},
 				{1.0, 1.0},
@@ -278,7 +279,7 @@ func TestRingClosure(t *testing.T) {
 		},
 		{
 			name: "Open ring",
-			ring: [][2]float64{
+			ring: [][]float64{
 				{0.0, 0.0},
 				{1.0, 0.0},
 				{1.0, 1.0},
@@ -286,9 +287,19 @@ func TestRingClosure(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "Near-closed ring within tolerance",
+			ring: [][]float64{
+				{0.0, 0.0},
+				{1.0, 0.0},
+				{1.0, 1.0},
+				{1e-9, -1e-9}, // Floating-point residue of the first point
+			},
+			expected: true,
+		},
 		{
 			name: "Too few points",
-			ring: [][2]float64{
+			ring: [][]float64{
 				{0.0, 0.0},
 				{1.0, 1.0},
 			},
@@ -296,17 +307,224 @@ func TestRingClosure(t *testing.T) {
 		},
 		{
 			name:     "Empty ring",
-			ring:     [][2]float64{},
+			ring:     [][]float64{},
 			expected: false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := isRingClosed(tt.ring)
+			result := isRingClosed(tt.ring, defaultRingClosureTolerance)
 			if result != tt.expected {
 				t.Errorf("Expected %v, got %v for ring: %v", tt.expected, result, tt.ring)
 			}
 		})
 	}
 }
+
+// TestMaskedEdges tests that masked edges close the ring but are left out of the boundary
+func TestMaskedEdges(t *testing.T) {
+	// Unit square from four edges; edge 3 (top) is masked against a neighbouring cell
+	points := [][]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}
+	spatialRecords := make(map[spatialKey]*spatialRecord)
+	refs := make([]spatialRef, 0, 4)
+	for i := 0; i < 4; i++ {
+		id := int64(i + 1)
+		spatialRecords[spatialKey{RCNM: int(spatialTypeEdge), RCID: id}] = &spatialRecord{
+			ID:          id,
+			RecordType:  spatialTypeEdge,
+			Coordinates: [][]float64{points[i], points[i+1]},
+		}
+		refs = append(refs, spatialRef{RCNM: int(spatialTypeEdge), RCID: id, Orientation: 1, Mask: 2})
+	}
+	refs[2].Mask = maskIndicatorMask
+
+	featureRec := &featureRecord{ID: 1, GeomPrim: 3, SpatialRefs: refs}
+	geom, err := constructPolygonGeometry(featureRec, spatialRecords, defaultRingClosureTolerance, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(geom.Coordinates) != 5 {
+		t.Fatalf("Expected the closed 5-point ring to include the masked edge, got %v", geom.Coordinates)
+	}
+
+	// Right side joins the bottom; the left side is a separate run after the gap
+	expected := [][][]float64{
+		{{0, 0}, {1, 0}, {1, 1}},
+		{{0, 1}, {0, 0}},
+	}
+	if len(geom.Boundary) != len(expected) {
+		t.Fatalf("Expected %d boundary runs, got %v", len(expected), geom.Boundary)
+	}
+	for i, run := range expected {
+		if len(geom.Boundary[i]) != len(run) {
+			t.Fatalf("Run %d: expected %v, got %v", i, run, geom.Boundary[i])
+		}
+		for j, coord := range run {
+			if geom.Boundary[i][j][0] != coord[0] || geom.Boundary[i][j][1] != coord[1] {
+				t.Errorf("Run %d: expected %v, got %v", i, run, geom.Boundary[i])
+				break
+			}
+		}
+	}
+	for _, run := range geom.Boundary {
+		for j := 0; j+1 < len(run); j++ {
+			if run[j][1] == 1 && run[j+1][1] == 1 {
+				t.Errorf("Masked top edge drawn in boundary: %v", run)
+			}
+		}
+	}
+
+	// Without masks the whole ring is the boundary
+	refs[2].Mask = 2
+	geom, _ = constructPolygonGeometry(featureRec, spatialRecords, defaultRingClosureTolerance, false)
+	if geom.Boundary != nil {
+		t.Errorf("Expected nil boundary without masked edges, got %v", geom.Boundary)
+	}
+}
+
+// TestNearClosedRingSnapped tests that a ring whose ends differ by a floating-point
+// residue is snapped closed instead of getting a duplicate closing vertex
+func TestNearClosedRingSnapped(t *testing.T) {
+	ring := [][]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {1e-9, 1e-9}}
+
+	closed := ensurePolygonClosure(ring, defaultRingClosureTolerance)
+	if len(closed) != 5 {
+		t.Fatalf("Expected 5 coordinates (no spurious vertex), got %v", closed)
+	}
+	if closed[4][0] != 0 || closed[4][1] != 0 {
+		t.Errorf("Expected last coordinate snapped to the first, got %v", closed[4])
+	}
+	if ring[4][0] != 1e-9 || ring[4][1] != 1e-9 {
+		t.Errorf("Expected the caller's ring to be left unchanged, got last coordinate %v", ring[4])
+	}
+
+	// Exact closure only (negative tolerance) treats the residue as an opening
+	exact := ringClosureTolerance(ParseOptions{RingClosureTolerance: -1})
+	ring = [][]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {1e-9, 1e-9}}
+	if closed := ensurePolygonClosure(ring, exact); len(closed) != 6 {
+		t.Errorf("Expected a closing vertex with exact closure, got %v", closed)
+	}
+
+	// The same applies to rings built from edges
+	spatialRecords := map[spatialKey]*spatialRecord{
+		{RCNM: int(spatialTypeEdge), RCID: 1}: {
+			ID:          1,
+			RecordType:  spatialTypeEdge,
+			Coordinates: [][]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {1e-9, 1e-9}},
+		},
+	}
+	featureRec := &featureRecord{ID: 1, GeomPrim: 3, SpatialRefs: []spatialRef{{RCID: 1, Orientation: 1}}}
+	geom, err := constructPolygonGeometry(featureRec, spatialRecords, defaultRingClosureTolerance, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coords := geom.Coordinates
+	if len(coords) != 5 || coords[4][0] != 0 || coords[4][1] != 0 {
+		t.Errorf("Expected edge ring snapped closed with 5 coordinates, got %v", coords)
+	}
+}
+
+// TestPolygonHoleRings tests that interior edges start a new ring once the outer
+// ring has closed, with no coordinate joining the rings
+func TestPolygonHoleRings(t *testing.T) {
+	spatialRecords := map[spatialKey]*spatialRecord{
+		{RCNM: int(spatialTypeEdge), RCID: 1}: {
+			ID:          1,
+			RecordType:  spatialTypeEdge,
+			Coordinates: [][]float64{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}},
+		},
+		{RCNM: int(spatialTypeEdge), RCID: 2}: {
+			ID:          2,
+			RecordType:  spatialTypeEdge,
+			Coordinates: [][]float64{{1, 1}, {1, 3}, {3, 3}, {3, 1}, {1, 1}},
+		},
+	}
+	featureRec := &featureRecord{ID: 1, GeomPrim: 3, SpatialRefs: []spatialRef{
+		{RCID: 1, Orientation: 1, Usage: 1},
+		{RCID: 2, Orientation: 1, Usage: 2},
+	}}
+
+	geom, err := constructPolygonGeometry(featureRec, spatialRecords, defaultRingClosureTolerance, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]float64{
+		{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0},
+		{1, 1}, {1, 3}, {3, 3}, {3, 1}, {1, 1},
+	}
+	if !reflect.DeepEqual(geom.Coordinates, expected) {
+		t.Errorf("Expected outer ring then hole, got %v", geom.Coordinates)
+	}
+	if rings := PolygonRings(geom.Coordinates); len(rings) != 2 {
+		t.Errorf("Expected 2 rings, got %d", len(rings))
+	}
+	if err := ValidatePolygon(&geom); err != nil {
+		t.Errorf("Expected polygon with hole to be valid, got %v", err)
+	}
+}
+
+// TestKeep3D tests depths of 3D edges and nodes survive line and polygon construction
+func TestKeep3D(t *testing.T) {
+	spatialRecords := map[spatialKey]*spatialRecord{
+		{RCNM: int(spatialTypeConnectedNode), RCID: 1}: {
+			ID: 1, RecordType: spatialTypeConnectedNode, Coordinates: [][]float64{{0, 0, 5}},
+		},
+		{RCNM: int(spatialTypeEdge), RCID: 10}: {
+			ID:          10,
+			RecordType:  spatialTypeEdge,
+			Coordinates: [][]float64{{1, 0, 6}, {1, 1, 7}, {0, 1, 8}},
+			VectorPointers: []vectorPointer{
+				{TargetRCNM: int(spatialTypeConnectedNode), TargetRCID: 1, Topology: 1},
+				{TargetRCNM: int(spatialTypeConnectedNode), TargetRCID: 1, Topology: 2},
+			},
+		},
+	}
+	refs := []spatialRef{{RCID: 10, Orientation: 1}}
+
+	// Polygon ring: node, shape points, node
+	area := &featureRecord{ID: 1, GeomPrim: 3, SpatialRefs: refs}
+	geom, err := constructPolygonGeometry(area, spatialRecords, defaultRingClosureTolerance, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]float64{{0, 0, 5}, {1, 0, 6}, {1, 1, 7}, {0, 1, 8}, {0, 0, 5}}
+	if !reflect.DeepEqual(geom.Coordinates, expected) {
+		t.Errorf("Polygon: expected %v, got %v", expected, geom.Coordinates)
+	}
+
+	// Line through the same edge
+	line := &featureRecord{ID: 2, GeomPrim: 2, SpatialRefs: refs}
+	geom, err = constructLineStringGeometry(line, spatialRecords, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(geom.Coordinates, expected) {
+		t.Errorf("Line: expected %v, got %v", expected, geom.Coordinates)
+	}
+
+	// Default stays 2D
+	geom, _ = constructPolygonGeometry(area, spatialRecords, defaultRingClosureTolerance, false)
+	for _, coord := range geom.Coordinates {
+		if len(coord) != 2 {
+			t.Fatalf("Expected 2D coordinates without Keep3D, got %v", geom.Coordinates)
+		}
+	}
+
+	// Each vertex keeps its own depth, even where positions repeat
+	spatialRecords[spatialKey{RCNM: int(spatialTypeEdge), RCID: 11}] = &spatialRecord{
+		ID:          11,
+		RecordType:  spatialTypeEdge,
+		Coordinates: [][]float64{{2, 0, 3}, {2, 1, 4}, {2, 0, 9}},
+	}
+	line = &featureRecord{ID: 3, GeomPrim: 2, SpatialRefs: []spatialRef{{RCID: 11, Orientation: 1}}}
+	geom, err = constructLineStringGeometry(line, spatialRecords, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = [][]float64{{2, 0, 3}, {2, 1, 4}, {2, 0, 9}}
+	if !reflect.DeepEqual(geom.Coordinates, expected) {
+		t.Errorf("Repeated position: expected %v, got %v", expected, geom.Coordinates)
+	}
+}
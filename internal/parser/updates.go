@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/beetlebugorg/iso8211/pkg/iso8211"
@@ -24,6 +25,39 @@ const (
 	UpdateModify UpdateInstruction = 3
 )
 
+// UpdateSummary tallies the record update instructions applied from one update
+// file (see ParseOptions.RecordUpdateLog)
+type UpdateSummary struct {
+	File         string // Path of the update file
+	UpdateNumber string // UPDN of the update's DSID
+
+	FeaturesInserted int // Feature records with RUIN=1
+	FeaturesDeleted  int // Feature records with RUIN=2
+	FeaturesModified int // Feature records with RUIN=3
+
+	SpatialInserted int // Spatial records with RUIN=1
+	SpatialDeleted  int // Spatial records with RUIN=2
+	SpatialModified int // Spatial records with RUIN=3
+}
+
+// count tallies one applied instruction for a feature or spatial record
+func (s *UpdateSummary) count(ruin UpdateInstruction, spatial bool) {
+	switch {
+	case ruin == UpdateInsert && spatial:
+		s.SpatialInserted++
+	case ruin == UpdateDelete && spatial:
+		s.SpatialDeleted++
+	case ruin == UpdateModify && spatial:
+		s.SpatialModified++
+	case ruin == UpdateInsert:
+		s.FeaturesInserted++
+	case ruin == UpdateDelete:
+		s.FeaturesDeleted++
+	case ruin == UpdateModify:
+		s.FeaturesModified++
+	}
+}
+
 // findUpdateFiles discovers sequential update files for a base cell
 //
 // Given "GB5X01SW.000", looks for "GB5X01SW.001", "GB5X01SW.002", etc.
@@ -60,15 +94,70 @@ func findUpdateFiles(baseFilename string) ([]string, error) {
 //
 // Updates are applied at the record level before geometry construction.
 // This modifies featureRecords and spatialRecords in place.
-func applyUpdates(baseChart *chartData, updateFiles []string, params datasetParams) error {
+// Updates must match the base cell's edition (EDTN); updates for another edition
+// are skipped with an ErrStaleUpdate warning.
+func applyUpdates(baseChart *chartData, updateFiles []string, params datasetParams, opts ParseOptions) error {
 	for _, updateFile := range updateFiles {
-		if err := applyUpdate(baseChart, updateFile, params); err != nil {
+		if err := applyUpdate(baseChart, updateFile, params, opts); err != nil {
 			return fmt.Errorf("failed to apply update %s: %w", updateFile, err)
 		}
 	}
 	return nil
 }
 
+// checkUpdateSequence verifies an update's DSID follows the current dataset state
+// S-57 Part 3 §8.4: update numbers (UPDN) increase by one for each update
+func checkUpdateSequence(current, update *datasetMetadata) error {
+	if current == nil || update == nil {
+		return nil // Nothing to compare against
+	}
+
+	// DSNM is the file name (e.g., "US4MD81M.001"), so compare cell names
+	updateCell := strings.TrimSuffix(update.dsnm, filepath.Ext(update.dsnm))
+	currentCell := strings.TrimSuffix(current.dsnm, filepath.Ext(current.dsnm))
+	if updateCell != "" && currentCell != "" && updateCell != currentCell {
+		return fmt.Errorf("%w: update is for dataset %s, not %s",
+			ErrInvalidUpdateSequence, updateCell, currentCell)
+	}
+
+	currentNum, err1 := strconv.Atoi(strings.TrimSpace(current.updn))
+	updateNum, err2 := strconv.Atoi(strings.TrimSpace(update.updn))
+	if err1 != nil || err2 != nil {
+		return nil // Non-numeric update numbers cannot be checked
+	}
+	if updateNum != currentNum+1 {
+		return fmt.Errorf("%w: update %d cannot follow update %d",
+			ErrInvalidUpdateSequence, updateNum, currentNum)
+	}
+	return nil
+}
+
+// checkUpdateEdition verifies an update belongs to the base cell's edition
+// S-57 Part 3 §8.4: a new edition restarts UPDN at 0, so updates for an older
+// edition found next to a re-issued base must not be applied to it
+func checkUpdateEdition(current, update *datasetMetadata) error {
+	if current == nil || update == nil {
+		return nil // Nothing to compare against
+	}
+
+	currentEdition := strings.TrimSpace(current.edtn)
+	updateEdition := strings.TrimSpace(update.edtn)
+	if currentEdition == "" || updateEdition == "" {
+		return nil // Editions not recorded
+	}
+	currentNum, err1 := strconv.Atoi(currentEdition)
+	updateNum, err2 := strconv.Atoi(updateEdition)
+	if err1 == nil && err2 == nil {
+		if currentNum == updateNum {
+			return nil
+		}
+	} else if currentEdition == updateEdition {
+		return nil
+	}
+
+	return &ErrStaleUpdate{BaseEdition: currentEdition, UpdateEdition: updateEdition}
+}
+
 // featureID uniquely identifies a feature using the composite key from FOID
 // Per S-57 §7.6.2, the unique identifier is (AGEN, FIDN, FIDS), not just FIDN
 type featureID struct {
@@ -82,32 +171,62 @@ type chartData struct {
 	features       []*featureRecord
 	spatialRecords map[spatialKey]*spatialRecord
 	metadata       *datasetMetadata
+	structure      *StructureInfo // DSSI of the base cell (not changed by updates)
+
+	// baseCounts holds the base cell's feature record counts per DSSI category,
+	// so VerifyCounts can account for records inserted or deleted by updates
+	baseCounts map[featureCategory]int
 
 	// Index for fast lookup during updates
 	// CRITICAL: Must use composite key (AGEN, FIDN, FIDS) because FIDN alone is not unique
 	featuresByID map[featureID]*featureRecord
+
+	// retainRawFields keeps ISO 8211 fields from update records as well
+	retainRawFields bool
+
+	// warnings collects non-fatal problems (see ParseOptions.ContinueOnRecordError)
+	warnings *warningCollector
+
+	// updateLog holds one summary per applied update file (ParseOptions.RecordUpdateLog)
+	updateLog []UpdateSummary
 }
 
 // applyUpdate applies a single update file to the chart data
-func applyUpdate(chart *chartData, updateFile string, params datasetParams) error {
+func applyUpdate(chart *chartData, updateFile string, params datasetParams, opts ParseOptions) error {
 	// Parse update file
-	reader, err := iso8211.NewReader(updateFile)
+	isoFile, err := parseISO8211(updateFile, opts, chart.warnings)
 	if err != nil {
-		return fmt.Errorf("failed to open update file: %w", err)
+		return fmt.Errorf("failed to parse update file: %w", err)
 	}
-	defer reader.Close()
 
-	isoFile, err := reader.Parse()
-	if err != nil {
-		return fmt.Errorf("failed to parse update file: %w", err)
+	// Updates left over from an earlier edition are ignored, not applied
+	updatedDSID := extractDSID(isoFile)
+	if err := checkUpdateEdition(chart.metadata, updatedDSID); err != nil {
+		chart.warnings.warn(fmt.Errorf("%s: %w", updateFile, err))
+		return nil
+	}
+
+	// Updates must belong to this dataset and be applied in sequence
+	if err := checkUpdateSequence(chart.metadata, updatedDSID); err != nil {
+		return err
 	}
 
 	// Process each record in update file
+	summary := UpdateSummary{File: updateFile}
+	if updatedDSID != nil {
+		summary.UpdateNumber = updatedDSID.updn
+	}
+	attf := attributeFormatFromDDR(isoFile.DDR, "ATTF")
 	for _, record := range isoFile.Records {
 		// Feature record (FRID)
 		if fridData, ok := record.Fields["FRID"]; ok && len(fridData) >= 12 {
-			if err := applyFeatureUpdate(chart, record, fridData); err != nil {
-				return err
+			if err := applyFeatureUpdate(chart, record, fridData, attf, opts.Catalogue); err != nil {
+				if !opts.ContinueOnRecordError {
+					return err
+				}
+				chart.warnings.warn(fmt.Errorf("%s: %w", updateFile, err))
+			} else {
+				summary.count(UpdateInstruction(fridData[11]), false)
 			}
 			continue
 		}
@@ -115,14 +234,22 @@ func applyUpdate(chart *chartData, updateFile string, params datasetParams) erro
 		// Spatial record (VRID)
 		if vridData, ok := record.Fields["VRID"]; ok && len(vridData) >= 8 {
 			if err := applySpatialUpdate(chart, record, vridData, params); err != nil {
-				return err
+				if !opts.ContinueOnRecordError {
+					return err
+				}
+				chart.warnings.warn(fmt.Errorf("%s: %w", updateFile, err))
+			} else {
+				summary.count(UpdateInstruction(vridData[7]), true)
 			}
 			continue
 		}
 	}
+	if opts.RecordUpdateLog {
+		chart.updateLog = append(chart.updateLog, summary)
+	}
 
 	// Check if update contains new DSID metadata and merge it
-	if updatedDSID := extractDSID(isoFile); updatedDSID != nil {
+	if updatedDSID != nil {
 		// Merge updated metadata fields
 		// Per S-57 spec, update files can modify UPDN (update number) and UADT (update date)
 		// EDTN (edition) and DSNM (dataset name) should NOT change in updates
@@ -142,14 +269,17 @@ func applyUpdate(chart *chartData, updateFile string, params datasetParams) erro
 }
 
 // applyFeatureUpdate handles INSERT/DELETE/MODIFY for features
-func applyFeatureUpdate(chart *chartData, record *iso8211.DataRecord, fridData []byte) error {
+func applyFeatureUpdate(chart *chartData, record *iso8211.DataRecord, fridData []byte, attf attributeFormat, catalogue *Catalogue) error {
 	ruin := UpdateInstruction(fridData[11])
 
 	// Parse feature record
-	featureRec := parseFeatureRecord(record)
+	featureRec := parseFeatureRecord(record, attf, catalogue)
 	if featureRec == nil {
 		return fmt.Errorf("failed to parse feature record")
 	}
+	if chart.retainRawFields {
+		featureRec.RawFields = record.Fields
+	}
 
 	// Create composite key from FOID fields
 	key := featureID{
@@ -226,6 +356,18 @@ func applyFeatureUpdate(chart *chartData, record *iso8211.DataRecord, fridData [
 		}
 		// If FSPT not present in update, preserve existing SpatialRefs
 
+		// Overlay raw fields present in the update record
+		if chart.retainRawFields {
+			merged := make(map[string][]byte, len(existing.RawFields)+len(record.Fields))
+			for tag, data := range existing.RawFields {
+				merged[tag] = data
+			}
+			for tag, data := range record.Fields {
+				merged[tag] = data
+			}
+			existing.RawFields = merged
+		}
+
 		// Keep reference in index
 		chart.featuresByID[key] = existing
 
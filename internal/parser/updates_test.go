@@ -1,6 +1,10 @@
 package parser
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
 	"path/filepath"
 	"testing"
 )
@@ -93,3 +97,185 @@ func TestUpdateInstructionConstants(t *testing.T) {
 		t.Errorf("UpdateModify should be 3, got %d", UpdateModify)
 	}
 }
+
+func TestParseFS(t *testing.T) {
+	parser := NewParser()
+
+	fsys := os.DirFS("../../test/US4MD81M")
+	chart, err := parser.ParseFS(fsys, "US4MD81M.000", DefaultParseOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Updates must be discovered within the same filesystem
+	if chart.UpdateNumber() != "3" {
+		t.Errorf("Expected update number 3, got %s", chart.UpdateNumber())
+	}
+	if len(chart.Features) == 0 {
+		t.Error("Expected features in chart parsed from fs.FS")
+	}
+}
+
+func TestFindUpdateFilesFS(t *testing.T) {
+	fsys := os.DirFS("../../test")
+
+	updates, err := findUpdateFilesFS(fsys, "US4MD81M/US4MD81M.000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"US4MD81M/US4MD81M.001", "US4MD81M/US4MD81M.002", "US4MD81M/US4MD81M.003"}
+	if len(updates) != len(expected) {
+		t.Fatalf("Expected %d updates, got %d", len(expected), len(updates))
+	}
+	for i := range expected {
+		if updates[i] != expected[i] {
+			t.Errorf("Update %d: expected %s, got %s", i, expected[i], updates[i])
+		}
+	}
+}
+
+// wrappedNotExistFS reports missing files with a wrapped fs.ErrNotExist
+// rather than an *fs.PathError, as fs.FS implementations may
+type wrappedNotExistFS struct{ fs.FS }
+
+func (w wrappedNotExistFS) Open(name string) (fs.File, error) {
+	f, err := w.FS.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("no entry %s: %w", name, fs.ErrNotExist)
+	}
+	return f, err
+}
+
+func TestFindUpdateFilesFSWrappedNotExist(t *testing.T) {
+	fsys := wrappedNotExistFS{os.DirFS("../../test")}
+
+	updates, err := findUpdateFilesFS(fsys, "US4MD81M/US4MD81M.000")
+	if err != nil {
+		t.Fatalf("Expected the missing .004 to end discovery, got %v", err)
+	}
+	if len(updates) != 3 {
+		t.Errorf("Expected 3 updates, got %v", updates)
+	}
+}
+
+// TestUpdateSequenceErrors verifies out-of-order updates and foreign datums are rejected
+func TestUpdateSequenceErrors(t *testing.T) {
+	basePath := "../../test/US4MD81M/US4MD81M.000"
+	opts := DefaultParseOptions()
+
+	baseData, params, _, err := parseBaseFile(basePath, opts)
+	if err != nil {
+		t.Fatalf("Failed to parse base file: %v", err)
+	}
+
+	// Skipping .001 makes .002 out of sequence
+	err = applyUpdates(baseData, []string{"../../test/US4MD81M/US4MD81M.002"}, params, opts)
+	if !errors.Is(err, ErrInvalidUpdateSequence) {
+		t.Errorf("Expected ErrInvalidUpdateSequence, got %v", err)
+	}
+
+	// Update for a different cell
+	current := &datasetMetadata{dsnm: "US4MD81M.000", updn: "0"}
+	other := &datasetMetadata{dsnm: "US5VA10M.001", updn: "1"}
+	if err := checkUpdateSequence(current, other); !errors.Is(err, ErrInvalidUpdateSequence) {
+		t.Errorf("Expected ErrInvalidUpdateSequence for foreign update, got %v", err)
+	}
+	next := &datasetMetadata{dsnm: "US4MD81M.001", updn: "1"}
+	if err := checkUpdateSequence(current, next); err != nil {
+		t.Errorf("Expected next update to be accepted, got %v", err)
+	}
+
+	// Only WGS-84 is supported
+	if err := validateDatum(datasetParams{HDAT: 2}); err != nil {
+		t.Errorf("WGS-84 should be accepted: %v", err)
+	}
+	if err := validateDatum(datasetParams{HDAT: 17}); !errors.Is(err, ErrUnsupportedDatum) {
+		t.Errorf("Expected ErrUnsupportedDatum, got %v", err)
+	}
+}
+
+// TestStaleEditionUpdate verifies updates from an older edition are ignored with a warning
+func TestStaleEditionUpdate(t *testing.T) {
+	basePath := "../../test/US4MD81M/US4MD81M.000"
+	opts := DefaultParseOptions()
+
+	baseData, params, _, err := parseBaseFile(basePath, opts)
+	if err != nil {
+		t.Fatalf("Failed to parse base file: %v", err)
+	}
+
+	// Pretend the base was re-issued: the .001 on disk now belongs to an older edition
+	var warnings []error
+	baseData.warnings = newWarningCollector(func(err error) { warnings = append(warnings, err) })
+	baseData.metadata.edtn = "3"
+	featureCount := len(baseData.features)
+	updn := baseData.metadata.updn
+
+	if err := applyUpdates(baseData, []string{"../../test/US4MD81M/US4MD81M.001"}, params, opts); err != nil {
+		t.Fatalf("Stale update should be skipped, got error: %v", err)
+	}
+	if len(baseData.features) != featureCount || baseData.metadata.updn != updn {
+		t.Errorf("Stale update was applied: features %d -> %d, UPDN %s -> %s",
+			featureCount, len(baseData.features), updn, baseData.metadata.updn)
+	}
+
+	var stale *ErrStaleUpdate
+	if len(warnings) != 1 || !errors.As(warnings[0], &stale) {
+		t.Fatalf("Expected one ErrStaleUpdate warning, got %v", warnings)
+	}
+	if stale.BaseEdition != "3" {
+		t.Errorf("BaseEdition = %q, want 3", stale.BaseEdition)
+	}
+
+	// Editions compare numerically
+	if err := checkUpdateEdition(&datasetMetadata{edtn: "3"}, &datasetMetadata{edtn: "03"}); err != nil {
+		t.Errorf("Expected edition 03 to match 3, got %v", err)
+	}
+	if err := checkUpdateEdition(&datasetMetadata{edtn: "3"}, &datasetMetadata{edtn: "2"}); err == nil {
+		t.Error("Expected edition 2 update to be rejected for edition 3 base")
+	}
+}
+
+// TestRecordUpdateLog tests the per-update tally of RUIN instructions
+func TestRecordUpdateLog(t *testing.T) {
+	baseFile := "../../test/US4MD81M/US4MD81M.000"
+	updateFiles, err := findUpdateFiles(baseFile)
+	if err != nil || len(updateFiles) != 3 {
+		t.Fatalf("Expected 3 update files, got %v (%v)", updateFiles, err)
+	}
+
+	// Without the option no log is kept
+	chart, err := NewParser().Parse(baseFile)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+	if chart.UpdateLog() != nil {
+		t.Errorf("Expected no update log by default, got %v", chart.UpdateLog())
+	}
+
+	opts := DefaultParseOptions()
+	opts.RecordUpdateLog = true
+	chart, err = NewParser().ParseWithOptions(baseFile, opts)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+	log := chart.UpdateLog()
+	if len(log) != 3 {
+		t.Fatalf("Expected 3 update summaries, got %d", len(log))
+	}
+
+	// RUIN counts of the test cell's update files
+	expected := []UpdateSummary{
+		{File: updateFiles[0], UpdateNumber: "1",
+			FeaturesInserted: 6, FeaturesDeleted: 2, FeaturesModified: 14,
+			SpatialInserted: 10, SpatialDeleted: 9, SpatialModified: 33},
+		{File: updateFiles[1], UpdateNumber: "2", FeaturesInserted: 1, SpatialInserted: 1},
+		{File: updateFiles[2], UpdateNumber: "3", FeaturesModified: 1},
+	}
+	for i := range expected {
+		if log[i] != expected[i] {
+			t.Errorf("Update %d: expected %+v, got %+v", i+1, expected[i], log[i])
+		}
+	}
+}
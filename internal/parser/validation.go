@@ -2,6 +2,8 @@ package parser
 
 import (
 	"fmt"
+	"math"
+	"sort"
 )
 
 // ValidateCoordinate validates a single coordinate pair
@@ -39,7 +41,7 @@ func ValidateGeometry(geometry *Geometry) error {
 		// Multipoint features like SOUNDG can have hundreds of coordinates
 		// Allow empty points - they will be skipped during rendering
 
-	case GeometryTypeLineString:
+	case GeometryTypeLineString, GeometryTypeMultiLineString:
 		// Allow degenerate lines - they will be skipped during rendering
 
 	case GeometryTypePolygon:
@@ -52,15 +54,17 @@ func ValidateGeometry(geometry *Geometry) error {
 	for i, coord := range geometry.Coordinates {
 		if len(coord) < 2 || len(coord) > 3 {
 			return &ErrInvalidGeometry{
-				Type:   geometry.Type,
-				Reason: fmt.Sprintf("coordinate %d must have 2 or 3 values [lon, lat] or [lon, lat, depth], got %d", i, len(coord)),
+				Type:    geometry.Type,
+				HasType: true,
+				Reason:  fmt.Sprintf("coordinate %d must have 2 or 3 values [lon, lat] or [lon, lat, depth], got %d", i, len(coord)),
 			}
 		}
 		lon, lat := coord[0], coord[1]
 		if err := ValidateCoordinate(lat, lon); err != nil {
 			return &ErrInvalidGeometry{
-				Type:   geometry.Type,
-				Reason: fmt.Sprintf("coordinate %d invalid: %v", i, err),
+				Type:    geometry.Type,
+				HasType: true,
+				Reason:  fmt.Sprintf("coordinate %d invalid: %v", i, err),
 			}
 		}
 		// Note: depth (Z) coordinate is not validated - can be any value including negative
@@ -69,6 +73,191 @@ func ValidateGeometry(geometry *Geometry) error {
 	return nil
 }
 
+// ValidatePrimitive checks a geometry type against the primitives the S-57 Object
+// Catalogue permits for the object class (e.g., DEPCNT must be a line)
+// Unknown object classes and empty meta-feature geometries are not checked
+// S-57 Appendix A Chapter 1: Object Classes
+func ValidatePrimitive(objectClass string, geometry *Geometry) error {
+	if geometry == nil || len(geometry.Coordinates) == 0 {
+		return nil
+	}
+
+	def, ok := LookupObjectClass(objectClass)
+	if !ok || len(def.Primitives) == 0 {
+		return nil
+	}
+
+	if !def.Allows(geometry.Type) {
+		return &ErrPrimitiveMismatch{ObjectClass: objectClass, Type: geometry.Type}
+	}
+	return nil
+}
+
+// minPolygonArea is the smallest ring area, in square degrees, accepted as a
+// real polygon (about 1 m² at the equator); smaller rings are slivers
+const minPolygonArea = 1e-10
+
+// ValidatePolygon checks that a polygon can be filled and tested for
+// containment. The outer ring and each hole (see PolygonRings) must be closed,
+// have at least three distinct vertices, enclose more than minPolygonArea and
+// not cross itself. Self-intersecting rings are typically produced when topology
+// is resolved from edges with wrong ORNT.
+// Geometries that are not polygons, and empty meta-feature geometries, are valid.
+func ValidatePolygon(geometry *Geometry) error {
+	if geometry == nil || geometry.Type != GeometryTypePolygon || len(geometry.Coordinates) == 0 {
+		return nil
+	}
+
+	for i, ring := range PolygonRings(geometry.Coordinates) {
+		name := "ring"
+		if i > 0 {
+			name = fmt.Sprintf("hole %d", i)
+		}
+		if reason := validateRing(ring, name); reason != "" {
+			return &ErrInvalidGeometry{Type: GeometryTypePolygon, HasType: true, Reason: reason}
+		}
+	}
+	return nil
+}
+
+// validateRing returns why a single polygon ring is invalid, or "" when it is valid
+func validateRing(ring [][]float64, name string) string {
+	if len(ring) < 4 {
+		return fmt.Sprintf("%s has %d coordinates, need at least 4", name, len(ring))
+	}
+	first, last := ring[0], ring[len(ring)-1]
+	if first[0] != last[0] || first[1] != last[1] {
+		return fmt.Sprintf("%s is not closed", name)
+	}
+
+	if i, j, ok := findSelfIntersection(ring); ok {
+		return fmt.Sprintf("%s self-intersects between segments %d and %d", name, i, j)
+	}
+
+	area := 0.0
+	for i := 0; i+1 < len(ring); i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	if area = math.Abs(area / 2); area <= minPolygonArea {
+		return fmt.Sprintf("%s area %g is below the minimum %g", name, area, minPolygonArea)
+	}
+	return ""
+}
+
+// PolygonRings splits polygon coordinates into rings.
+//
+// Polygon coordinates hold the outer ring followed by any holes, back to back,
+// each closed on its own first vertex. A ring ends at a later vertex equal to
+// its start that leaves at least three vertices in between; the first such
+// vertex is taken unless the coordinates after it would not split into closed
+// rings, so a ring that touches its own start vertex is not cut there. If no
+// split closes every ring, each ring ends at the first such vertex and
+// coordinates left over after the last closed ring are returned as a final,
+// unclosed ring.
+func PolygonRings(coords [][]float64) [][][]float64 {
+	if rings, ok := closedRings(coords, 0, make(map[int]bool)); ok {
+		return rings
+	}
+
+	var rings [][][]float64
+	start := 0
+	for i := start + 3; i < len(coords); i++ {
+		if coords[i][0] == coords[start][0] && coords[i][1] == coords[start][1] {
+			rings = append(rings, coords[start:i+1])
+			start = i + 1
+			i = start + 2 // Loop increment makes this start+3
+		}
+	}
+	if start < len(coords) {
+		rings = append(rings, coords[start:])
+	}
+	return rings
+}
+
+// closedRings splits coords[start:] into rings that each close on their first
+// vertex, preferring the earliest closing vertex of every ring. Start indices
+// whose coordinates cannot be split are recorded in failed so each is tried
+// once.
+func closedRings(coords [][]float64, start int, failed map[int]bool) ([][][]float64, bool) {
+	if start == len(coords) {
+		return nil, true
+	}
+	if failed[start] {
+		return nil, false
+	}
+	for i := start + 3; i < len(coords); i++ {
+		if coords[i][0] != coords[start][0] || coords[i][1] != coords[start][1] {
+			continue
+		}
+		if rest, ok := closedRings(coords, i+1, failed); ok {
+			return append([][][]float64{coords[start : i+1]}, rest...), true
+		}
+	}
+	failed[start] = true
+	return nil, false
+}
+
+// findSelfIntersection returns the indices of two non-adjacent ring segments that
+// cross. Segments are swept in longitude order so only segments whose extents
+// overlap are compared. Touching at a shared vertex is not a crossing.
+func findSelfIntersection(ring [][]float64) (int, int, bool) {
+	n := len(ring) - 1 // Number of segments in the closed ring
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	minLon := func(i int) float64 { return math.Min(ring[i][0], ring[i+1][0]) }
+	maxLon := func(i int) float64 { return math.Max(ring[i][0], ring[i+1][0]) }
+	sort.Slice(order, func(a, b int) bool { return minLon(order[a]) < minLon(order[b]) })
+
+	active := make([]int, 0)
+	for _, i := range order {
+		// Drop segments that end before this one starts
+		kept := active[:0]
+		for _, j := range active {
+			if maxLon(j) >= minLon(i) {
+				kept = append(kept, j)
+			}
+		}
+		active = kept
+
+		for _, j := range active {
+			if adjacentSegments(i, j, n) {
+				continue
+			}
+			if segmentsCross(ring[i], ring[i+1], ring[j], ring[j+1]) {
+				if i > j {
+					i, j = j, i
+				}
+				return i, j, true
+			}
+		}
+		active = append(active, i)
+	}
+	return 0, 0, false
+}
+
+// adjacentSegments reports whether segments i and j share a vertex in a ring of n segments
+func adjacentSegments(i, j, n int) bool {
+	d := i - j
+	if d < 0 {
+		d = -d
+	}
+	return d <= 1 || d == n-1
+}
+
+// segmentsCross reports whether segments a-b and c-d properly cross
+// (intersect at a single point interior to both)
+func segmentsCross(a, b, c, d []float64) bool {
+	orient := func(p, q, r []float64) float64 {
+		return (q[0]-p[0])*(r[1]-p[1]) - (q[1]-p[1])*(r[0]-p[0])
+	}
+	d1, d2 := orient(c, d, a), orient(c, d, b)
+	d3, d4 := orient(a, b, c), orient(a, b, d)
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) &&
+		((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
+
 // ValidateFeature validates a feature per S-57 rules
 func ValidateFeature(feature *Feature) error {
 	if feature == nil {
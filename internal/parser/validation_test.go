@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -246,3 +247,178 @@ func TestValidationBreakdown(t *testing.T) {
 		fmt.Printf("  %-30s: %d\n", reason, count)
 	}
 }
+
+// TestValidatePrimitiveWarning verifies a polygon class resolving to a line is reported
+func TestValidatePrimitiveWarning(t *testing.T) {
+	data := &chartData{
+		features: []*featureRecord{
+			{
+				ID:          1,
+				ObjectClass: 112, // RESARE - area only
+				GeomPrim:    2,   // Encoded as a line
+				SpatialRefs: []spatialRef{{RCID: 1, Orientation: 1}},
+			},
+		},
+		spatialRecords: map[spatialKey]*spatialRecord{
+			{RCNM: int(spatialTypeEdge), RCID: 1}: {
+				ID:          1,
+				RecordType:  spatialTypeEdge,
+				Coordinates: [][]float64{{0.0, 0.0}, {1.0, 1.0}},
+			},
+		},
+	}
+
+	var reported []error
+	opts := DefaultParseOptions()
+	opts.ValidatePrimitive = true
+	opts.OnWarning = func(err error) { reported = append(reported, err) }
+	data.warnings = newWarningCollector(opts.OnWarning)
+
+	chart, err := buildChart(data, nil, defaultDatasetParams(), opts)
+	if err != nil {
+		t.Fatalf("Primitive mismatch should not fail the chart: %v", err)
+	}
+	if len(chart.Features) != 1 {
+		t.Fatalf("Expected feature to be kept, got %d features", len(chart.Features))
+	}
+	if len(reported) != 1 {
+		t.Fatalf("Expected 1 warning, got %d", len(reported))
+	}
+
+	var mismatch *ErrPrimitiveMismatch
+	if !errors.As(reported[0], &mismatch) {
+		t.Fatalf("Expected ErrPrimitiveMismatch, got %v", reported[0])
+	}
+	if mismatch.ObjectClass != "RESARE" || mismatch.Type != GeometryTypeLineString {
+		t.Errorf("Unexpected mismatch: %v", mismatch)
+	}
+
+	// Disabled by default
+	data.warnings = newWarningCollector(nil)
+	chart, err = buildChart(data, nil, defaultDatasetParams(), DefaultParseOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chart.Warnings) != 0 {
+		t.Errorf("Expected no warnings by default, got %v", chart.Warnings)
+	}
+}
+
+// TestValidatePolygon tests polygon ring validity checks
+func TestValidatePolygon(t *testing.T) {
+	tests := []struct {
+		name   string
+		ring   [][]float64
+		reason string // Expected substring of the failure, empty if valid
+	}{
+		{"square", [][]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}, ""},
+		{"bowtie", [][]float64{{0, 0}, {1, 1}, {1, 0}, {0, 1}, {0, 0}}, "self-intersects"},
+		{"square with hole", [][]float64{
+			{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0},
+			{1, 1}, {1, 3}, {3, 3}, {3, 1}, {1, 1},
+		}, ""},
+		{"bowtie hole", [][]float64{
+			{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0},
+			{1, 1}, {3, 3}, {3, 1}, {1, 3}, {1, 1},
+		}, "hole 1 self-intersects"},
+		{"touches its start", [][]float64{
+			{0, 0}, {2, -1}, {2, 1}, {0, 0}, {-2, 1}, {-2, -1}, {0, 0},
+		}, ""},
+		{"unclosed", [][]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}}, "not closed"},
+		{"sliver", [][]float64{{0, 0}, {1, 0}, {0.5, 1e-12}, {0, 0}}, "area"},
+		{"too short", [][]float64{{0, 0}, {1, 0}, {0, 0}}, "at least 4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePolygon(&Geometry{Type: GeometryTypePolygon, Coordinates: tt.ring})
+			if tt.reason == "" {
+				if err != nil {
+					t.Errorf("Expected valid polygon, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.reason) {
+				t.Errorf("Expected error containing %q, got %v", tt.reason, err)
+			}
+		})
+	}
+
+	// A bowtie built from topology is reported, and dropped on request
+	data := &chartData{
+		features: []*featureRecord{
+			{
+				ID:          1,
+				ObjectClass: 42, // DEPARE
+				GeomPrim:    3,
+				SpatialRefs: []spatialRef{{RCID: 1, Orientation: 1}},
+			},
+		},
+		spatialRecords: map[spatialKey]*spatialRecord{
+			{RCNM: int(spatialTypeEdge), RCID: 1}: {
+				ID:          1,
+				RecordType:  spatialTypeEdge,
+				Coordinates: [][]float64{{0, 0}, {1, 1}, {1, 0}, {0, 1}, {0, 0}},
+			},
+		},
+	}
+
+	opts := DefaultParseOptions()
+	opts.ValidatePolygons = true
+	data.warnings = newWarningCollector(nil)
+	chart, err := buildChart(data, nil, defaultDatasetParams(), opts)
+	if err != nil {
+		t.Fatalf("Invalid polygon should not fail the chart: %v", err)
+	}
+	if len(chart.Features) != 1 || len(chart.Warnings) != 1 {
+		t.Fatalf("Expected feature kept with 1 warning, got %d features, warnings %v", len(chart.Features), chart.Warnings)
+	}
+
+	opts.DropInvalidPolygons = true
+	data.warnings = newWarningCollector(nil)
+	chart, err = buildChart(data, nil, defaultDatasetParams(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chart.Features) != 0 || len(chart.Warnings) != 1 {
+		t.Errorf("Expected feature dropped with 1 warning, got %d features, warnings %v", len(chart.Features), chart.Warnings)
+	}
+}
+
+// TestPolygonRings tests splitting polygon coordinates into rings
+func TestPolygonRings(t *testing.T) {
+	tests := []struct {
+		name    string
+		coords  [][]float64
+		lengths []int // Expected coordinate count of each ring
+	}{
+		{"square with hole", [][]float64{
+			{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0},
+			{1, 1}, {1, 3}, {3, 3}, {3, 1}, {1, 1},
+		}, []int{5, 5}},
+		{"ring touching its start with hole", [][]float64{
+			{0, 0}, {2, -1}, {2, 1}, {0, 0}, {-2, 1}, {-2, -1}, {0, 0},
+			{1.5, -0.2}, {1.5, 0.2}, {1.8, 0}, {1.5, -0.2},
+		}, []int{7, 4}},
+		{"hole touching its start", [][]float64{
+			{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0},
+			{5, 5}, {6, 4}, {6, 6}, {5, 5}, {4, 6}, {4, 4}, {5, 5},
+		}, []int{5, 7}},
+		{"unclosed remainder", [][]float64{
+			{0, 0}, {1, 0}, {1, 1}, {0, 0}, {5, 5}, {6, 5},
+		}, []int{4, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rings := PolygonRings(tt.coords)
+			lengths := make([]int, len(rings))
+			for i, ring := range rings {
+				lengths[i] = len(ring)
+			}
+			if fmt.Sprint(lengths) != fmt.Sprint(tt.lengths) {
+				t.Errorf("Expected ring lengths %v, got %v", tt.lengths, lengths)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package s57
+
+// agencyNames maps producing agency (AGEN) codes to display names.
+//
+// Codes are assigned by the IHO in publication S-62 (ENC Producer Codes).
+// Only producers whose codes are confirmed here are listed; extend the table
+// as new producers are needed rather than guessing codes. Cells of other
+// producers are still named through producerNames (see ProducerName).
+var agencyNames = map[int]string{
+	540: "UKHO (GB)", // United Kingdom Hydrographic Office
+	550: "NOAA (US)", // National Oceanic and Atmospheric Administration
+}
+
+// producerNames maps S-62 two-character producer codes to display names.
+//
+// The producer code is the first two characters of every ENC cell name (S-57
+// Appendix B.1, e.g. "FR" in FR501130); national hydrographic offices use
+// their country's ISO 3166 code.
+var producerNames = map[string]string{
+	"AR": "SHN (AR)",      // Servicio de Hidrografía Naval
+	"AU": "AHO (AU)",      // Australian Hydrographic Office
+	"BR": "DHN (BR)",      // Diretoria de Hidrografia e Navegação
+	"CA": "CHS (CA)",      // Canadian Hydrographic Service
+	"CL": "SHOA (CL)",     // Servicio Hidrográfico y Oceanográfico de la Armada
+	"DE": "BSH (DE)",      // Bundesamt für Seeschifffahrt und Hydrographie
+	"DK": "DGA (DK)",      // Danish Geodata Agency
+	"ES": "IHM (ES)",      // Instituto Hidrográfico de la Marina
+	"FI": "Traficom (FI)", // Finnish Transport and Communications Agency
+	"FR": "SHOM (FR)",     // Service Hydrographique et Océanographique de la Marine
+	"GB": "UKHO (GB)",     // United Kingdom Hydrographic Office
+	"IT": "IIM (IT)",      // Istituto Idrografico della Marina
+	"JP": "JHOD (JP)",     // Japan Hydrographic and Oceanographic Department
+	"KR": "KHOA (KR)",     // Korea Hydrographic and Oceanographic Agency
+	"NL": "NLHS (NL)",     // Netherlands Hydrographic Service
+	"NO": "NHS (NO)",      // Norwegian Hydrographic Service
+	"NZ": "LINZ (NZ)",     // Land Information New Zealand
+	"PT": "IH (PT)",       // Instituto Hidrográfico
+	"SE": "SMA (SE)",      // Swedish Maritime Administration
+	"US": "NOAA (US)",     // National Oceanic and Atmospheric Administration
+}
+
+// AgencyName returns the display name of a producing agency code.
+//
+// Returns false for codes not in the table.
+//
+// Example:
+//
+//	if name, ok := s57.AgencyName(550); ok {
+//	    fmt.Println(name) // NOAA (US)
+//	}
+func AgencyName(code int) (string, bool) {
+	name, ok := agencyNames[code]
+	return name, ok
+}
+
+// ProducerName returns the display name of an S-62 two-character producer
+// code, the first two characters of a cell name.
+//
+// Returns false for codes not in the table.
+//
+// Example:
+//
+//	if name, ok := s57.ProducerName(chart.DatasetName()[:2]); ok {
+//	    fmt.Println(name) // e.g. SHOM (FR) for FR501130
+//	}
+func ProducerName(code string) (string, bool) {
+	name, ok := producerNames[code]
+	return name, ok
+}
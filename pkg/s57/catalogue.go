@@ -0,0 +1,85 @@
+package s57
+
+import (
+	"fmt"
+
+	"github.com/beetlebugorg/s57/internal/parser"
+)
+
+// Catalogue supplements the S-57 Object and Attribute Catalogues with the
+// object classes and attributes of product specifications built on S-57, such
+// as Inland ENC (IENC) river and lock objects.
+//
+// Register entries, then pass the catalogue to NewParserWithCatalogue. Codes in
+// the standard S-57 catalogue keep their standard meaning; other codes resolve
+// through the catalogue before falling back to "OBJL_<code>" (object classes)
+// or "ATTR_<code>" (attributes). Do not modify a catalogue once a parser is
+// using it.
+//
+// Example:
+//
+//	cat := s57.NewCatalogue()
+//	cat.AddObjectClass(s57.ObjectClassMetadata{
+//	    Code: 17050, Acronym: "wtwaxs", Name: "Waterway axis",
+//	    Type: s57.ObjectTypeGeo, Primitives: []s57.GeometryType{s57.GeometryTypeLineString},
+//	})
+//	cat.AddAttribute(18001, "catbrt")
+//	chart, err := s57.NewParserWithCatalogue(cat).Parse("1R7EMS01.000")
+type Catalogue struct {
+	internal *parser.Catalogue
+}
+
+// NewCatalogue creates an empty supplementary catalogue.
+func NewCatalogue() *Catalogue {
+	return &Catalogue{internal: parser.NewCatalogue()}
+}
+
+// AddObjectClass registers an object class. Code and Acronym are required.
+func (c *Catalogue) AddObjectClass(info ObjectClassMetadata) error {
+	if info.Code <= 0 || info.Acronym == "" {
+		return fmt.Errorf("object class needs a positive code and an acronym (code %d, acronym %q)", info.Code, info.Acronym)
+	}
+
+	def := parser.ObjectClassDef{
+		Code:       info.Code,
+		Acronym:    info.Acronym,
+		Name:       info.Name,
+		Primitives: make([]parser.GeometryType, len(info.Primitives)),
+	}
+	switch info.Type {
+	case ObjectTypeGeo:
+		def.Class = "G"
+	case ObjectTypeMeta:
+		def.Class = "M"
+	case ObjectTypeCollection:
+		def.Class = "C"
+	case ObjectTypeCartographic:
+		def.Class = "$"
+	}
+	for i, prim := range info.Primitives {
+		def.Primitives[i] = parser.GeometryType(prim)
+	}
+	c.internal.AddObjectClass(def)
+	return nil
+}
+
+// AddAttribute registers an attribute acronym for a numeric attribute code.
+func (c *Catalogue) AddAttribute(code int, acronym string) error {
+	if code <= 0 || acronym == "" {
+		return fmt.Errorf("attribute needs a positive code and an acronym (code %d, acronym %q)", code, acronym)
+	}
+	c.internal.AddAttribute(code, acronym)
+	return nil
+}
+
+// NewParserWithCatalogue creates a parser that resolves object class and
+// attribute codes missing from the S-57 catalogue through cat.
+//
+// A nil catalogue gives the same parser as NewParser.
+func NewParserWithCatalogue(cat *Catalogue) Parser {
+	wrapper := &parserWrapper{internal: parser.NewParser()}
+	if cat != nil {
+		wrapper.catalogue = cat.internal
+	}
+	return wrapper
+}
@@ -1,6 +1,11 @@
 package s57
 
 import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
 	"github.com/beetlebugorg/s57/internal/parser"
 	"github.com/dhconnelly/rtreego"
 )
@@ -15,27 +20,61 @@ import (
 //
 // All fields are private to maintain encapsulation.
 type Chart struct {
-	features      []Feature // All features
-	spatialIndex  *spatialIndex // Fast spatial queries
-	bounds        Bounds    // Chart coverage area
-
-	datasetName       string
-	edition           string
-	updateNumber      string
-	updateDate        string
-	issueDate         string
-	s57Edition        string
-	producingAgency   int
-	comment           string
-	exchangePurpose   string
-	productSpec       string
+	features     []Feature     // All features
+	metaFeatures []Feature     // Meta and collection features removed by ExcludeMetaFeatures
+	spatialIndex *spatialIndex // Fast spatial queries
+	bounds       Bounds        // Chart coverage area
+
+	datasetName        string
+	edition            string
+	updateNumber       string
+	updateDate         string
+	issueDate          string
+	s57Edition         string
+	producingAgency    int
+	comment            string
+	exchangePurpose    string
+	productSpec        string
 	applicationProfile string
 	usageBand          UsageBand
 
 	// Coordinate system metadata (S-57 §7.3.2)
-	coordinateUnits CoordinateUnits // COUN field from DSPM record
-	horizontalDatum int             // HDAT field from DSPM record
-	compilationScale int32          // CSCL field from DSPM record
+	coordinateUnits       CoordinateUnits // COUN field from DSPM record
+	horizontalDatum       int             // HDAT field from DSPM record
+	compilationScale      int32           // CSCL field from DSPM record
+	coordinateFactor      int32           // COMF used to scale coordinates
+	soundingFactor        int32           // SOMF used to scale sounding depths
+	usedDefaultParameters bool            // No usable DSPM record; default COMF/SOMF applied
+
+	structure StructureInfo   // DSSI record of the base cell
+	updateLog []UpdateSummary // Applied updates (ParseOptions.RecordUpdateLog)
+
+	// Original ISO 8211 fields per feature (only with ParseOptions.RetainRawFields)
+	rawRecords map[FeatureID]map[string][]byte
+
+	// Edge/node graph (only with ParseOptions.RetainTopology)
+	topology *Topology
+
+	// R-tree node fan-out (ParseOptions.SpatialIndexMinChildren/MaxChildren)
+	rtreeMinChildren int
+	rtreeMaxChildren int
+
+	// Post-filter R-tree candidates by true bounds (ParseOptions.ExactBoundsQueries)
+	exactBoundsQueries bool
+
+	warnings []error // Non-fatal problems found while parsing
+
+	source resourceSource // Where companion files are read from (see Resource)
+
+	// Sorted unique object classes, computed on first use
+	objectClassesOnce sync.Once
+	objectClasses     []string
+	objectClassSet    map[string]bool
+
+	// Per-class R-trees, built on first use (see FeaturesInBoundsOfClass)
+	classIndexes classIndexes
+
+	released bool // Release has been called
 }
 
 // CoordinateUnits indicates how coordinates are encoded in the chart.
@@ -152,6 +191,12 @@ func (ub UsageBand) ScaleRange() (min, max int) {
 	}
 }
 
+// Default R-tree node fan-out, suitable for most chart sizes.
+const (
+	defaultRTreeMinChildren = 25
+	defaultRTreeMaxChildren = 50
+)
+
 // spatialIndex provides O(log n) spatial queries using R-tree.
 // Dramatically faster than linear O(n) scan for large charts.
 type spatialIndex struct {
@@ -173,13 +218,13 @@ func (f *indexedFeature) Bounds() rtreego.Rect {
 	lonLength := f.bounds.MaxLon - f.bounds.MinLon
 	latLength := f.bounds.MaxLat - f.bounds.MinLat
 
-	// For point features (zero-area), use small epsilon (~11 meters at equator)
-	const epsilon = 0.0001
-	if lonLength < epsilon {
-		lonLength = epsilon
+	// For point features (zero-area), use small epsilon (~11 meters)
+	lonEpsilon, latEpsilon := pointEpsilon(f.bounds.MinLat)
+	if lonLength < lonEpsilon {
+		lonLength = lonEpsilon
 	}
-	if latLength < epsilon {
-		latLength = epsilon
+	if latLength < latEpsilon {
+		latLength = latEpsilon
 	}
 
 	lengths := []float64{lonLength, latLength}
@@ -187,6 +232,24 @@ func (f *indexedFeature) Bounds() rtreego.Rect {
 	return rect
 }
 
+// pointEpsilonDegrees is the padding applied to zero-area bounds in the R-tree,
+// in degrees of latitude (~11 meters).
+const pointEpsilonDegrees = 0.0001
+
+// pointEpsilon returns the longitude and latitude padding for a zero-area
+// feature at the given latitude.
+//
+// A degree of longitude shrinks by cos(lat) toward the poles, so the longitude
+// padding is scaled by 1/cos(lat) to keep the padded box roughly square in
+// meters. Near the poles the scale is capped to avoid unbounded boxes.
+func pointEpsilon(lat float64) (lonEpsilon, latEpsilon float64) {
+	cosLat := math.Cos(lat * math.Pi / 180)
+	if cosLat < 0.01 {
+		cosLat = 0.01 // ~89.4°, beyond any charted waters
+	}
+	return pointEpsilonDegrees / cosLat, pointEpsilonDegrees
+}
+
 // Features returns all features in the chart.
 //
 // Features include depth contours, buoys, lights, hazards, restricted areas,
@@ -198,11 +261,88 @@ func (c *Chart) Features() []Feature {
 	return c.features
 }
 
+// FeaturesOfType returns all features with the given geometry type.
+//
+// Use this to split a chart into fill (Polygon), line (LineString) and symbol
+// (Point) layers. Like ParseOptions.GeometryTypeFilter, which drops other
+// types at parse time instead, GeometryTypeLineString also matches
+// MultiLineString features, so the line layer is complete.
+func (c *Chart) FeaturesOfType(t GeometryType) []Feature {
+	result := make([]Feature, 0)
+	for _, f := range c.features {
+		if f.geometry.Type == t || (t == GeometryTypeLineString && f.geometry.Type == GeometryTypeMultiLineString) {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// MetaFeatures returns the chart's meta objects (M_COVR, M_QUAL, M_CSCL, ...)
+// and collection objects (C_AGGR, C_ASSO, ...).
+//
+// Use it to draw coverage and data-quality overlays separately from the
+// geographic content. Classes are identified by their Object Catalogue type,
+// not their acronym prefix. Meta features are returned whether or not
+// ParseOptions.ExcludeMetaFeatures removed them from Features.
+//
+// Example:
+//
+//	for _, f := range chart.MetaFeatures() {
+//	    if f.ObjectClass() == "M_QUAL" {
+//	        drawQualityOverlay(f)
+//	    }
+//	}
+func (c *Chart) MetaFeatures() []Feature {
+	if c.metaFeatures != nil {
+		return c.metaFeatures
+	}
+	result := make([]Feature, 0)
+	for _, f := range c.features {
+		if isMetaClass(f.objectClass) {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
 // FeatureCount returns the number of features in the chart.
 func (c *Chart) FeatureCount() int {
 	return len(c.features)
 }
 
+// ObjectClasses returns the sorted object class codes present in the chart
+// (e.g., "BOYLAT", "DEPARE", "LIGHTS").
+//
+// Use it to build layer toggles before rendering. The set is computed once on
+// first use; the returned slice is a copy the caller may modify.
+func (c *Chart) ObjectClasses() []string {
+	c.collectObjectClasses()
+	classes := make([]string, len(c.objectClasses))
+	copy(classes, c.objectClasses)
+	return classes
+}
+
+// HasObjectClass reports whether the chart has at least one feature of the
+// given object class.
+func (c *Chart) HasObjectClass(code string) bool {
+	c.collectObjectClasses()
+	return c.objectClassSet[code]
+}
+
+// collectObjectClasses builds the object class set on first use
+func (c *Chart) collectObjectClasses() {
+	c.objectClassesOnce.Do(func() {
+		c.objectClassSet = make(map[string]bool)
+		for _, f := range c.features {
+			if !c.objectClassSet[f.objectClass] {
+				c.objectClassSet[f.objectClass] = true
+				c.objectClasses = append(c.objectClasses, f.objectClass)
+			}
+		}
+		sort.Strings(c.objectClasses)
+	})
+}
+
 // Bounds returns the geographic coverage area of the chart.
 //
 // This represents the minimum bounding box containing all features.
@@ -226,35 +366,183 @@ func (c *Chart) Bounds() Bounds {
 //	    render(feature)
 //	}
 func (c *Chart) FeaturesInBounds(bounds Bounds) []Feature {
+	result, _ := c.FeaturesInBoundsWithStats(bounds)
+	return result
+}
+
+// QueryStats reports the work done by a spatial query, for tuning render
+// performance.
+type QueryStats struct {
+	// Candidates is the number of features the R-tree returned for the query
+	// (or the number scanned when the chart has no spatial index).
+	Candidates int
+
+	// Returned is the number of features in the result.
+	Returned int
+
+	// ExactFilter is true if candidates were checked against their true
+	// bounds (see ParseOptions.ExactBoundsQueries). The R-tree pads point
+	// features, so without it a few features just outside the query bounds
+	// can be returned.
+	ExactFilter bool
+}
+
+// FeaturesInBoundsWithStats is FeaturesInBounds, also reporting how many
+// R-tree candidates the query examined versus returned.
+//
+// Example:
+//
+//	features, stats := chart.FeaturesInBoundsWithStats(viewport)
+//	log.Printf("%d of %d candidates", stats.Returned, stats.Candidates)
+func (c *Chart) FeaturesInBoundsWithStats(bounds Bounds) ([]Feature, QueryStats) {
+	return c.queryBounds(bounds, c.exactBoundsQueries)
+}
+
+// FeaturesInBoundsExact returns the features whose true bounds intersect the
+// given bounding box.
+//
+// FeaturesInBounds stays the fast default for rendering, where the few padded
+// point features it can return just outside the viewport do no harm. Use
+// this variant for correctness-sensitive work such as counting or clipping:
+// R-tree candidates are checked against each feature's bounds, so the result
+// never includes a feature that does not intersect the box.
+func (c *Chart) FeaturesInBoundsExact(bounds Bounds) []Feature {
+	result, _ := c.queryBounds(bounds, true)
+	return result
+}
+
+// queryBounds runs an R-tree query, optionally post-filtering candidates by
+// their true bounds.
+func (c *Chart) queryBounds(bounds Bounds, exact bool) ([]Feature, QueryStats) {
 	if c.spatialIndex == nil || c.spatialIndex.rtree == nil {
 		// No spatial index, fallback to linear search
-		return c.featuresInBoundsLinear(bounds)
+		result := c.featuresInBoundsLinear(bounds)
+		return result, QueryStats{Candidates: len(c.features), Returned: len(result), ExactFilter: true}
 	}
 
 	// Query R-tree: O(log n) instead of O(n)
-	point := rtreego.Point{bounds.MinLon, bounds.MinLat}
-	lengths := []float64{
-		bounds.MaxLon - bounds.MinLon,
-		bounds.MaxLat - bounds.MinLat,
-	}
-	queryRect, _ := rtreego.NewRect(point, lengths)
-
-	// Search R-tree for intersecting features
-	spatials := c.spatialIndex.rtree.SearchIntersect(queryRect)
+	spatials := c.spatialIndex.rtree.SearchIntersect(boundsRect(bounds))
+	stats := QueryStats{Candidates: len(spatials), ExactFilter: exact}
 
 	// Extract features from indexed wrappers
 	result := make([]Feature, 0, len(spatials))
 	for _, spatial := range spatials {
 		indexed := spatial.(*indexedFeature)
+		if exact && !bounds.Intersects(indexed.bounds) {
+			continue // Only matched the padded R-tree rectangle
+		}
 		result = append(result, indexed.feature)
 	}
 
-	return result
+	stats.Returned = len(result)
+	return result, stats
+}
+
+// EachFeature calls fn for every feature in the chart, in chart order.
+//
+// Iteration stops early when fn returns false. Unlike Features, no result
+// slice is involved, which keeps tight render loops free of allocations.
+func (c *Chart) EachFeature(fn func(Feature) bool) {
+	for _, feature := range c.features {
+		if !fn(feature) {
+			return
+		}
+	}
+}
+
+// EachFeatureInBounds calls fn for every feature that intersects bounds.
+//
+// Iteration stops early when fn returns false. This is the allocation-free
+// counterpart of FeaturesInBounds: features are handed to fn directly from
+// the R-tree search instead of being collected into a slice.
+func (c *Chart) EachFeatureInBounds(bounds Bounds, fn func(Feature) bool) {
+	if c.spatialIndex == nil || c.spatialIndex.rtree == nil {
+		// No spatial index, fallback to linear search
+		for _, feature := range c.features {
+			if bounds.Intersects(featureBounds(feature)) && !fn(feature) {
+				return
+			}
+		}
+		return
+	}
+
+	// Visit matches from a search filter, refusing every entry so the
+	// R-tree never builds a result set. rtreego only aborts the current
+	// leaf, so remember that fn asked to stop and skip remaining leaves.
+	stopped := false
+	c.spatialIndex.rtree.SearchIntersect(boundsRect(bounds),
+		func(_ []rtreego.Spatial, object rtreego.Spatial) (refuse, abort bool) {
+			indexed := object.(*indexedFeature)
+			if c.exactBoundsQueries && !bounds.Intersects(indexed.bounds) {
+				return true, stopped
+			}
+			if !stopped && !fn(indexed.feature) {
+				stopped = true
+			}
+			return true, stopped
+		})
+}
+
+// FeaturesInBoundsChunked delivers the features that intersect bounds to fn
+// in batches of at most chunkSize, as the R-tree search yields them.
+//
+// Use it to pipeline work such as GPU uploads without building one large
+// result slice. Returning false from fn stops the query. A chunkSize below 1
+// is treated as 1. The final batch may be smaller than chunkSize; fn is not
+// called when nothing intersects bounds.
+//
+// The slice passed to fn is a buffer reused for every batch: it is only valid
+// until fn returns. Copy the features (or the slice) to retain them.
+//
+// Example:
+//
+//	chart.FeaturesInBoundsChunked(viewport, 512, func(batch []s57.Feature) bool {
+//	    upload(batch) // Must not keep batch after returning
+//	    return true
+//	})
+func (c *Chart) FeaturesInBoundsChunked(bounds Bounds, chunkSize int, fn func([]Feature) bool) {
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+
+	buf := make([]Feature, 0, chunkSize)
+	stopped := false
+	c.EachFeatureInBounds(bounds, func(feature Feature) bool {
+		buf = append(buf, feature)
+		if len(buf) < chunkSize {
+			return true
+		}
+		stopped = !fn(buf)
+		buf = buf[:0]
+		return !stopped
+	})
+
+	if !stopped && len(buf) > 0 {
+		fn(buf)
+	}
+}
+
+// boundsRect converts Bounds to an R-tree query rectangle.
+func boundsRect(bounds Bounds) rtreego.Rect {
+	point := rtreego.Point{bounds.MinLon, bounds.MinLat}
+	lengths := []float64{
+		bounds.MaxLon - bounds.MinLon,
+		bounds.MaxLat - bounds.MinLat,
+	}
+	rect, _ := rtreego.NewRect(point, lengths)
+	return rect
 }
 
 // featuresInBoundsLinear performs linear search when no spatial index exists.
 func (c *Chart) featuresInBoundsLinear(bounds Bounds) []Feature {
-	result := make([]Feature, 0, len(c.features)/10)
+	// Assume ~10% of features are visible, unless the query covers the whole
+	// chart ("fit chart" views), where nearly all of them are
+	capacity := len(c.features) / 10
+	if c.bounds != (Bounds{}) && bounds.ContainsBounds(c.bounds) {
+		capacity = len(c.features)
+	}
+
+	result := make([]Feature, 0, capacity)
 	for _, feature := range c.features {
 		fb := featureBounds(feature)
 		if bounds.Intersects(fb) {
@@ -287,6 +575,62 @@ func (c *Chart) UpdateDate() string { return c.updateDate }
 // This is when the dataset was released by the producing agency.
 func (c *Chart) IssueDate() string { return c.issueDate }
 
+// s57DateLayout is the fixed YYYYMMDD format of S-57 date fields.
+const s57DateLayout = "20060102"
+
+// UpdateDateTime returns the update application date as a UTC time.
+//
+// The UADT field is used, falling back to the issue date (ISDT) when the
+// chart carries no update application date. Returns false if the date is
+// missing or malformed.
+func (c *Chart) UpdateDateTime() (time.Time, bool) {
+	date := c.updateDate
+	if date == "" {
+		date = c.issueDate
+	}
+	return parseS57Date(date)
+}
+
+// IssueDateTime returns the chart issue date (ISDT) as a UTC time.
+//
+// Returns false if the date is missing or malformed.
+func (c *Chart) IssueDateTime() (time.Time, bool) {
+	return parseS57Date(c.issueDate)
+}
+
+// IsCurrentAsOf reports whether the chart's updates are dated on or after t.
+//
+// Only the calendar date of t is compared, since S-57 dates have no time of
+// day. Charts with a missing or malformed date are never current.
+//
+// Example:
+//
+//	cutoff := time.Now().AddDate(0, -6, 0)
+//	if !chart.IsCurrentAsOf(cutoff) {
+//	    log.Printf("%s has not been updated in six months", chart.DatasetName())
+//	}
+func (c *Chart) IsCurrentAsOf(t time.Time) bool {
+	updated, ok := c.UpdateDateTime()
+	if !ok {
+		return false
+	}
+	t = t.UTC()
+	cutoff := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return !updated.Before(cutoff)
+}
+
+// parseS57Date parses an 8-character YYYYMMDD date as UTC midnight.
+func parseS57Date(date string) (time.Time, bool) {
+	if len(date) != len(s57DateLayout) {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(s57DateLayout, date, time.UTC)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
 // S57Edition returns the S-57 standard edition used.
 //
 // Example: "03.1" for S-57 Edition 3.1
@@ -297,8 +641,24 @@ func (c *Chart) S57Edition() string { return c.s57Edition }
 // Example: 550 = NOAA (United States)
 //
 // Full agency list available in IHO S-57 Appendix A.
+// Use ProducingAgencyName for a display name.
 func (c *Chart) ProducingAgency() int { return c.producingAgency }
 
+// ProducingAgencyName returns the display name of the producing agency.
+//
+// Example: "NOAA (US)". The AGEN code is looked up with AgencyName, falling
+// back to the producer code that begins the dataset name (see ProducerName).
+// Returns false if neither is known.
+func (c *Chart) ProducingAgencyName() (string, bool) {
+	if name, ok := AgencyName(c.producingAgency); ok {
+		return name, true
+	}
+	if len(c.datasetName) < 2 {
+		return "", false
+	}
+	return ProducerName(c.datasetName[:2])
+}
+
 // Comment returns the metadata comment field.
 func (c *Chart) Comment() string { return c.comment }
 
@@ -307,6 +667,14 @@ func (c *Chart) Comment() string { return c.comment }
 // Returns "New" for new datasets or "Revision" for updates.
 func (c *Chart) ExchangePurpose() string { return c.exchangePurpose }
 
+// IsBaseCell reports whether the chart's dataset is a base cell (EXPP=1)
+// rather than an update (EXPP=2).
+//
+// Parse rejects update files with ErrNotABaseCell, so this is only false for a
+// parsed chart whose EXPP is missing or not a known value; use ExtractMetadata
+// to classify files before parsing them.
+func (c *Chart) IsBaseCell() bool { return c.exchangePurpose == "New" }
+
 // ProductSpecification returns human-readable product specification.
 //
 // Typically "ENC" for Electronic Navigational Charts.
@@ -338,6 +706,42 @@ func (c *Chart) UsageBand() UsageBand { return c.usageBand }
 // S-57 §7.3.2.1: COUN field in DSPM record.
 func (c *Chart) CoordinateUnits() CoordinateUnits { return c.coordinateUnits }
 
+// UsedDefaultParameters reports whether the chart had no usable DSPM record.
+//
+// When DSPM is absent or truncated, coordinates are scaled with the default
+// coordinate multiplication factor (10^7) and soundings with the default
+// sounding factor (10), and CoordinateUnits reports CoordinateUnitsUnknown.
+// Cautious callers can check this flag and warn that positions may be wrong.
+//
+// The flag describes the cell, not the factors applied: it stays true when
+// ParseOptions.CoordinateFactorOverride or SoundingFactorOverride supplied a
+// factor in place of the default. CoordinateFactor and SoundingFactor return
+// the factors actually used.
+//
+// S-57 §7.3.2.1: COMF and SOMF fields in DSPM record.
+func (c *Chart) UsedDefaultParameters() bool { return c.usedDefaultParameters }
+
+// CoordinateFactor returns the coordinate multiplication factor the parser
+// divided integer coordinates by.
+//
+// This is ParseOptions.CoordinateFactorOverride when set; otherwise the COMF
+// read from the DSPM record (10^7 for virtually every ENC), or the default
+// when UsedDefaultParameters is true. Positions that are off by a power of ten
+// usually trace back to this value.
+//
+// S-57 §7.3.2.1: COMF field in DSPM record.
+func (c *Chart) CoordinateFactor() int32 { return c.coordinateFactor }
+
+// SoundingFactor returns the sounding multiplication factor the parser divided
+// integer sounding depths by.
+//
+// This is ParseOptions.SoundingFactorOverride when set; otherwise the SOMF
+// read from the DSPM record (10 for virtually every ENC), or the default when
+// UsedDefaultParameters is true.
+//
+// S-57 §7.3.2.1: SOMF field in DSPM record.
+func (c *Chart) SoundingFactor() int32 { return c.soundingFactor }
+
 // HorizontalDatum returns the horizontal geodetic datum code.
 //
 // Common values:
@@ -356,6 +760,65 @@ func (c *Chart) HorizontalDatum() int { return c.horizontalDatum }
 // Returns 0 if not specified.
 func (c *Chart) CompilationScale() int32 { return c.compilationScale }
 
+// Warnings returns non-fatal problems found while parsing.
+//
+// Warnings are only recorded for recoverable conditions, such as records
+// skipped because of ParseOptions.ContinueOnRecordError. A clean chart
+// returns an empty slice.
+func (c *Chart) Warnings() []error { return c.warnings }
+
+// RawRecord returns the original ISO 8211 fields of a feature record, keyed by
+// field tag (e.g., "FRID", "FOID", "ATTF", "FFPT", "FSPT").
+//
+// Fields are only retained when parsing with ParseOptions.RetainRawFields.
+// Returns false if raw fields were not retained or the feature is not found.
+//
+// The returned bytes are shared with the chart and must not be modified.
+func (c *Chart) RawRecord(foid FeatureID) (map[string][]byte, bool) {
+	fields, ok := c.rawRecords[foid]
+	return fields, ok
+}
+
+// Release frees the chart's features, spatial indexes and retained records
+// (raw ISO 8211 fields and topology) so their memory can be reclaimed without
+// waiting for every reference to the chart to go away.
+//
+// Release makes the chart unusable: afterwards feature queries return empty
+// results and Resource returns ErrChartReleased. Dataset metadata (name,
+// edition, dates, bounds) remains available. Release must not be called while
+// other goroutines are still querying the chart; calling it more than once is
+// harmless.
+//
+// Features obtained before Release stay valid, since they do not depend on
+// the chart.
+func (c *Chart) Release() {
+	c.features = nil
+	c.metaFeatures = nil
+	c.spatialIndex = nil
+	c.classIndexes.mu.Lock()
+	c.classIndexes.trees = nil
+	c.classIndexes.mu.Unlock()
+	c.rawRecords = nil
+	c.topology = nil
+	c.collectObjectClasses() // Settle the once so it cannot repopulate
+	c.objectClasses = nil
+	c.objectClassSet = nil
+	c.source = resourceSource{}
+	c.released = true
+}
+
+// Released reports whether Release has been called on the chart.
+func (c *Chart) Released() bool { return c.released }
+
+// FeatureID uniquely identifies a feature object.
+//
+// S-57 §7.6.2: The FOID field is the composite key (AGEN, FIDN, FIDS).
+type FeatureID struct {
+	Agency      uint16 // AGEN - producing agency code
+	Number      uint32 // FIDN - feature identification number
+	Subdivision uint16 // FIDS - feature identification subdivision
+}
+
 // Feature represents a navigational object from an S-57 chart.
 //
 // Features include depth contours, buoys, lights, hazards, restricted areas,
@@ -369,9 +832,13 @@ func (c *Chart) CompilationScale() int32 { return c.compilationScale }
 //   - Attribute(name) returns a specific attribute value
 type Feature struct {
 	id          int64
+	agency      uint16
+	subdivision uint16
 	objectClass string
+	group       int
 	geometry    Geometry
 	attributes  map[string]interface{}
+	noDepths    bool // ParseOptions.InjectSoundingDepths was false; no derived DEPTHS
 }
 
 // ID returns the unique feature identifier.
@@ -379,6 +846,17 @@ func (f *Feature) ID() int64 {
 	return f.id
 }
 
+// FeatureID returns the full feature object identifier (FOID).
+//
+// Unlike ID, which is the FIDN alone, this is unique across producing agencies.
+func (f *Feature) FeatureID() FeatureID {
+	return FeatureID{
+		Agency:      f.agency,
+		Number:      uint32(f.id),
+		Subdivision: f.subdivision,
+	}
+}
+
 // ObjectClass returns the S-57 object class code.
 //
 // Common examples:
@@ -392,6 +870,16 @@ func (f *Feature) ObjectClass() string {
 	return f.objectClass
 }
 
+// DisplayGroup returns the feature's group (GRUP) from its feature record.
+//
+// S-52 draws group 1, the skin of the earth (DEPARE, DRGARE, LNDARE, UNSARE
+// and a few other area classes that together cover the cell without gaps or
+// overlaps), beneath group 2, every other object. Producers set 255 when a
+// feature has no group.
+func (f *Feature) DisplayGroup() int {
+	return f.group
+}
+
 // Geometry returns the spatial representation of the feature.
 func (f *Feature) Geometry() Geometry {
 	return f.geometry
@@ -406,8 +894,20 @@ func (f *Feature) Geometry() Geometry {
 //   - "OBJNAM": Object name
 //
 // Attribute meanings are defined in the S-57 Object Catalogue.
+//
+// The returned map is a copy, so callers may modify it without affecting the
+// chart. For SOUNDG features it also contains the derived "DEPTHS" entry (see
+// Depths), unless ParseOptions.InjectSoundingDepths was false. Use Attribute
+// or EachAttribute for read-only access without copying the map.
 func (f *Feature) Attributes() map[string]interface{} {
-	return f.attributes
+	attrs := make(map[string]interface{}, len(f.attributes)+1)
+	for k, v := range f.attributes {
+		attrs[k] = v
+	}
+	if depths, ok := f.soundingDepths(); ok {
+		attrs[soundingDepthsAttribute] = depths
+	}
+	return attrs
 }
 
 // Attribute returns a specific attribute value by name.
@@ -420,26 +920,181 @@ func (f *Feature) Attributes() map[string]interface{} {
 //	    fmt.Printf("Depth: %v meters\n", depth)
 //	}
 func (f *Feature) Attribute(name string) (interface{}, bool) {
+	if name == soundingDepthsAttribute {
+		if depths, ok := f.soundingDepths(); ok {
+			return depths, true
+		}
+	}
 	val, ok := f.attributes[name]
 	return val, ok
 }
 
+// EachAttribute calls fn for every attribute without copying the attribute map.
+//
+// Iteration order is unspecified and stops early when fn returns false.
+// Values must be treated as read-only.
+func (f *Feature) EachAttribute(fn func(name string, value interface{}) bool) {
+	for name, value := range f.attributes {
+		if !fn(name, value) {
+			return
+		}
+	}
+	if depths, ok := f.soundingDepths(); ok {
+		fn(soundingDepthsAttribute, depths)
+	}
+}
+
+// Depths returns the depth (Z) value of each coordinate that carries one.
+//
+// SOUNDG features are multipoint geometries with [lon, lat, depth]
+// coordinates; Depths returns the soundings in coordinate order. Returns nil
+// for features without 3D coordinates. Depths are computed on each call from
+// the geometry, so nothing is allocated for charts that never ask for them.
+func (f *Feature) Depths() []float64 {
+	var depths []float64
+	for _, coord := range f.geometry.Coordinates {
+		// Coordinates are [lon, lat, depth] for 3D points
+		if len(coord) >= 3 {
+			if depths == nil {
+				depths = make([]float64, 0, len(f.geometry.Coordinates))
+			}
+			depths = append(depths, coord[2])
+		}
+	}
+	return depths
+}
+
+// soundingDepthsAttribute is the derived attribute exposing SOUNDG depths.
+// It is not an S-57 attribute; it is computed from the SG3D coordinates.
+const soundingDepthsAttribute = "DEPTHS"
+
+// soundingDepths returns the derived DEPTHS attribute for SOUNDG features.
+func (f *Feature) soundingDepths() ([]float64, bool) {
+	if f.objectClass != "SOUNDG" || f.noDepths {
+		return nil, false
+	}
+	depths := f.Depths()
+	return depths, len(depths) > 0
+}
+
+// SoundingStyle classifies a single sounding against the safety depth.
+type SoundingStyle struct {
+	Lon     float64 // Longitude of the sounding
+	Lat     float64 // Latitude of the sounding
+	Depth   float64 // Sounding depth in meters
+	Shallow bool    // True if the depth is at or above the safety depth
+}
+
+// SoundingStyles classifies each sounding of a SOUNDG feature against the
+// mariner's safety depth.
+//
+// Per S-52 (conditional procedure SNDFRM) soundings less than or equal to the
+// safety depth are drawn in the emphasized shoal style, deeper soundings in the
+// faint style. Results are in coordinate order; coordinates without a depth are
+// skipped. Returns nil for features other than SOUNDG.
+//
+// Example:
+//
+//	for _, s := range feature.SoundingStyles(6.0) {
+//	    if s.Shallow {
+//	        drawBold(s.Lon, s.Lat, s.Depth)
+//	    }
+//	}
+func (f *Feature) SoundingStyles(safetyDepth float64) []SoundingStyle {
+	if f.objectClass != "SOUNDG" {
+		return nil
+	}
+
+	styles := make([]SoundingStyle, 0, len(f.geometry.Coordinates))
+	for _, coord := range f.geometry.Coordinates {
+		if len(coord) < 3 {
+			continue
+		}
+		styles = append(styles, SoundingStyle{
+			Lon:     coord[0],
+			Lat:     coord[1],
+			Depth:   coord[2],
+			Shallow: coord[2] <= safetyDepth,
+		})
+	}
+	return styles
+}
+
+// Bearing returns the direction of a line feature in degrees true (0-360).
+//
+// Directional features such as TSSLPT carry an ORIENT attribute, which is
+// returned when present. Otherwise the bearing is the initial great-circle
+// bearing from the first to the last coordinate, so it follows vertex order.
+// Renderers use it to place directional arrows. Returns ok=false for points,
+// polygons, lines split into parts (MultiLineString) and lines whose ends
+// coincide.
+//
+// Example:
+//
+//	if bearing, ok := feature.Bearing(); ok {
+//	    lon, lat := feature.Geometry().RepresentativePoint()
+//	    drawArrow(lon, lat, bearing)
+//	}
+func (f *Feature) Bearing() (float64, bool) {
+	if f.geometry.Type != GeometryTypeLineString {
+		return 0, false
+	}
+	if orient, ok := attributeFloat(*f, "ORIENT"); ok {
+		return math.Mod(orient, 360), true
+	}
+
+	coords := f.geometry.Coordinates
+	if len(coords) < 2 {
+		return 0, false
+	}
+	first, last := coords[0], coords[len(coords)-1]
+	if first[0] == last[0] && first[1] == last[1] {
+		return 0, false
+	}
+
+	toRad := math.Pi / 180
+	lat1, lat2 := first[1]*toRad, last[1]*toRad
+	dLon := (last[0] - first[0]) * toRad
+	y := math.Sin(dLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+	bearing := math.Atan2(y, x) / toRad
+	return math.Mod(bearing+360, 360), true
+}
+
 // Geometry represents the spatial representation of a feature.
 //
 // Coordinates follow GeoJSON convention: [longitude, latitude] pairs.
 // All coordinates are in WGS-84 decimal degrees.
 type Geometry struct {
-	// Type indicates the geometry type (Point, LineString, or Polygon).
+	// Type indicates the geometry type (Point, LineString, Polygon or
+	// MultiLineString).
 	Type GeometryType
 
-	// Coordinates contains [longitude, latitude] pairs.
+	// Coordinates contains [longitude, latitude] pairs, or [longitude,
+	// latitude, depth] triples for 3D geometry such as SOUNDG (see Dimensions).
 	//
 	// For Point: Single coordinate pair
 	// For LineString: Array of coordinate pairs forming a line
-	// For Polygon: Array of coordinate pairs forming a closed ring
+	// For Polygon: The outer ring followed by any holes, each a closed ring
+	// (its first coordinate repeated at its end); fill with the even-odd rule
+	// For MultiLineString: The coordinates of every part, in order (draw
+	// Parts; consecutive parts are not connected)
 	//
 	// Note: Coordinates follow GeoJSON convention [lon, lat], not [lat, lon].
 	Coordinates [][]float64
+
+	// Boundary holds the visible parts of a polygon's outline when some of
+	// its edges are masked, for example where a coastline is masked against
+	// a neighbouring cell. Each entry is a line of [lon, lat] pairs.
+	//
+	// Masked edges still close the ring in Coordinates, so fill with
+	// Coordinates and stroke Boundary. Nil means no edge is masked and the
+	// whole ring is the boundary.
+	Boundary [][][]float64
+
+	// Parts holds the separate lines of a MultiLineString, each a line of
+	// [lon, lat] pairs. Nil for other geometry types.
+	Parts [][][]float64
 }
 
 // GeometryType represents the type of geometry.
@@ -454,6 +1109,12 @@ const (
 
 	// GeometryTypePolygon represents a closed polygon area.
 	GeometryTypePolygon
+
+	// GeometryTypeMultiLineString represents a line feature made of several
+	// separate lines (see Geometry.Parts), such as a coastline whose edges
+	// do not join end to end, or a feature referencing both exterior and
+	// interior boundary edges.
+	GeometryTypeMultiLineString
 )
 
 // String returns the string representation of the geometry type.
@@ -465,68 +1126,94 @@ func (g GeometryType) String() string {
 		return "LineString"
 	case GeometryTypePolygon:
 		return "Polygon"
+	case GeometryTypeMultiLineString:
+		return "MultiLineString"
 	default:
 		return "Unknown"
 	}
 }
 
 // convertChart converts internal chart to public API chart
-func convertChart(internal *parser.Chart) *Chart {
+func convertChart(internal *parser.Chart, opts ParseOptions) *Chart {
 	features := make([]Feature, len(internal.Features))
+	var rawRecords map[FeatureID]map[string][]byte
 	for i, f := range internal.Features {
-		attributes := f.Attributes
-
-		// Special handling for SOUNDG (Sounding) features:
-		// Extract Z coordinates (depths) from geometry and add as DEPTHS attribute
-		// SOUNDG features are multipoint with Z values containing depth soundings
-		if f.ObjectClass == "SOUNDG" && len(f.Geometry.Coordinates) > 0 {
-			depths := make([]float64, 0, len(f.Geometry.Coordinates))
-			for _, coord := range f.Geometry.Coordinates {
-				// Coordinates are [lon, lat, depth] for 3D points
-				if len(coord) >= 3 {
-					depths = append(depths, coord[2])
-				}
-			}
-			if len(depths) > 0 {
-				// Make a copy of attributes map and add DEPTHS
-				attrs := make(map[string]interface{}, len(attributes)+1)
-				for k, v := range attributes {
-					attrs[k] = v
-				}
-				attrs["DEPTHS"] = depths
-				attributes = attrs
-			}
-		}
-
 		features[i] = Feature{
 			id:          f.ID,
+			agency:      f.Agency,
+			subdivision: f.Subdivision,
 			objectClass: f.ObjectClass,
+			group:       f.Group,
 			geometry: Geometry{
 				Type:        GeometryType(f.Geometry.Type),
 				Coordinates: f.Geometry.Coordinates,
+				Boundary:    f.Geometry.Boundary,
+				Parts:       f.Geometry.Parts,
 			},
-			attributes: attributes,
+			// SOUNDG depths are derived lazily from the geometry (see Depths),
+			// so the attribute map is shared rather than cloned per feature
+			attributes: f.Attributes,
+			noDepths:   !opts.InjectSoundingDepths,
+		}
+
+		if f.RawFields != nil {
+			if rawRecords == nil {
+				rawRecords = make(map[FeatureID]map[string][]byte)
+			}
+			rawRecords[features[i].FeatureID()] = f.RawFields
 		}
 	}
 
 	chart := &Chart{
-		features:          features,
-		datasetName:       internal.DatasetName(),
-		edition:           internal.Edition(),
-		updateNumber:      internal.UpdateNumber(),
-		updateDate:        internal.UpdateDate(),
-		issueDate:         internal.IssueDate(),
-		s57Edition:        internal.S57Edition(),
-		producingAgency:   internal.ProducingAgency(),
-		comment:           internal.Comment(),
-		exchangePurpose:   internal.ExchangePurpose(),
-		productSpec:       internal.ProductSpecification(),
+		features:           features,
+		datasetName:        internal.DatasetName(),
+		edition:            internal.Edition(),
+		updateNumber:       internal.UpdateNumber(),
+		updateDate:         internal.UpdateDate(),
+		issueDate:          internal.IssueDate(),
+		s57Edition:         internal.S57Edition(),
+		producingAgency:    internal.ProducingAgency(),
+		comment:            internal.Comment(),
+		exchangePurpose:    internal.ExchangePurpose(),
+		productSpec:        internal.ProductSpecification(),
 		applicationProfile: internal.ApplicationProfile(),
-		usageBand:         UsageBand(internal.IntendedUsage()),
+		usageBand:          UsageBand(internal.IntendedUsage()),
 		// Coordinate system metadata from DSPM record
-		coordinateUnits:  CoordinateUnits(internal.CoordinateUnits()),
-		horizontalDatum:  internal.HorizontalDatum(),
-		compilationScale: internal.CompilationScale(),
+		coordinateUnits:       CoordinateUnits(internal.CoordinateUnits()),
+		horizontalDatum:       internal.HorizontalDatum(),
+		compilationScale:      internal.CompilationScale(),
+		coordinateFactor:      internal.CoordinateFactor(),
+		soundingFactor:        internal.SoundingFactor(),
+		usedDefaultParameters: internal.UsedDefaultParameters(),
+		rawRecords:            rawRecords,
+		rtreeMinChildren:      opts.SpatialIndexMinChildren,
+		rtreeMaxChildren:      opts.SpatialIndexMaxChildren,
+		exactBoundsQueries:    opts.ExactBoundsQueries,
+		warnings:              publicErrors(internal.Warnings),
+	}
+
+	if dssi := internal.StructureInfo(); dssi != nil {
+		chart.structure = StructureInfo(*dssi)
+	}
+
+	if opts.ExcludeMetaFeatures {
+		geo := make([]Feature, 0, len(chart.features))
+		for _, feature := range chart.features {
+			if isMetaClass(feature.objectClass) {
+				chart.metaFeatures = append(chart.metaFeatures, feature)
+			} else {
+				geo = append(geo, feature)
+			}
+		}
+		chart.features = geo
+	}
+
+	for _, summary := range internal.UpdateLog() {
+		chart.updateLog = append(chart.updateLog, UpdateSummary(summary))
+	}
+
+	if opts.RetainTopology {
+		chart.topology = convertTopology(internal.Topology())
 	}
 
 	// Build spatial index for fast viewport queries
@@ -542,35 +1229,39 @@ func (c *Chart) buildSpatialIndex() {
 		return
 	}
 
-	// Create R-tree (2D, min=25 children, max=50 children)
-	// These parameters are optimal for most use cases
-	rtree := rtreego.NewTree(2, 25, 50)
+	// Create R-tree (2D, default min=25 children, max=50 children)
+	// The defaults are optimal for most use cases
+	minChildren, maxChildren := c.rtreeNodeSize()
+	rtree := rtreego.NewTree(2, minChildren, maxChildren)
 
 	// Calculate bounds - prefer M_COVR (Meta Coverage) feature if available
 	// M_COVR defines the official coverage area of the chart
 	var chartBounds *Bounds
 
-	// First pass: look for M_COVR features
+	// First pass: look for M_COVR features, which ExcludeMetaFeatures
+	// keeps out of the feature list
 	var foundMCOVR bool
-	for _, feature := range c.features {
-		if feature.ObjectClass() == "M_COVR" {
-			foundMCOVR = true
-			fb := featureBounds(feature)
-			if chartBounds == nil {
-				chartBounds = &fb
-			} else {
-				// Expand with M_COVR bounds
-				if fb.MinLon < chartBounds.MinLon {
-					chartBounds.MinLon = fb.MinLon
-				}
-				if fb.MaxLon > chartBounds.MaxLon {
-					chartBounds.MaxLon = fb.MaxLon
-				}
-				if fb.MinLat < chartBounds.MinLat {
-					chartBounds.MinLat = fb.MinLat
-				}
-				if fb.MaxLat > chartBounds.MaxLat {
-					chartBounds.MaxLat = fb.MaxLat
+	for _, features := range [][]Feature{c.metaFeatures, c.features} {
+		for _, feature := range features {
+			if feature.ObjectClass() == "M_COVR" {
+				foundMCOVR = true
+				fb := featureBounds(feature)
+				if chartBounds == nil {
+					chartBounds = &fb
+				} else {
+					// Expand with M_COVR bounds
+					if fb.MinLon < chartBounds.MinLon {
+						chartBounds.MinLon = fb.MinLon
+					}
+					if fb.MaxLon > chartBounds.MaxLon {
+						chartBounds.MaxLon = fb.MaxLon
+					}
+					if fb.MinLat < chartBounds.MinLat {
+						chartBounds.MinLat = fb.MinLat
+					}
+					if fb.MaxLat > chartBounds.MaxLat {
+						chartBounds.MaxLat = fb.MaxLat
+					}
 				}
 			}
 		}
@@ -619,3 +1310,23 @@ func (c *Chart) buildSpatialIndex() {
 		c.bounds = *chartBounds
 	}
 }
+
+// rtreeNodeSize returns the R-tree fan-out, falling back to defaults for
+// unset or inconsistent values.
+func (c *Chart) rtreeNodeSize() (minChildren, maxChildren int) {
+	minChildren, maxChildren = c.rtreeMinChildren, c.rtreeMaxChildren
+	if maxChildren <= 0 {
+		maxChildren = defaultRTreeMaxChildren
+	}
+	if minChildren <= 0 {
+		minChildren = maxChildren / 2
+	}
+	// A node split must leave both halves with at least minChildren entries
+	if minChildren > maxChildren/2 {
+		minChildren = maxChildren / 2
+	}
+	if minChildren < 1 {
+		minChildren = 1
+	}
+	return minChildren, maxChildren
+}
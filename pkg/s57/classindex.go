@@ -0,0 +1,97 @@
+package s57
+
+import (
+	"sync"
+
+	"github.com/dhconnelly/rtreego"
+)
+
+// classIndexes holds per-object-class R-trees, built lazily on first use so
+// charts that never run class-filtered queries pay no extra memory.
+type classIndexes struct {
+	mu    sync.Mutex
+	trees map[string]*rtreego.Rtree // nil entry: class has no features
+}
+
+// FeaturesInBoundsOfClass returns the features of the given object classes
+// that intersect the bounding box.
+//
+// It is equivalent to filtering FeaturesInBounds by ObjectClass, but each
+// class is searched in its own R-tree, so rendering one layer at a time
+// (e.g. S-52 display priorities) does not traverse unrelated features. The
+// tree for a class is built the first time that class is queried and reused
+// afterwards; classes never queried cost nothing.
+//
+// Results are grouped by class in argument order. Duplicate classes are
+// ignored. ParseOptions.ExactBoundsQueries applies as for FeaturesInBounds.
+//
+// Example:
+//
+//	contours := chart.FeaturesInBoundsOfClass(viewport, "DEPCNT")
+//	aids := chart.FeaturesInBoundsOfClass(viewport, "BOYLAT", "BCNLAT")
+func (c *Chart) FeaturesInBoundsOfClass(bounds Bounds, classes ...string) []Feature {
+	result := make([]Feature, 0)
+	if c.spatialIndex == nil || c.spatialIndex.rtree == nil {
+		// No spatial index, fallback to linear search
+		wanted := make(map[string]bool, len(classes))
+		for _, class := range classes {
+			wanted[class] = true
+		}
+		for _, feature := range c.features {
+			if wanted[feature.objectClass] && bounds.Intersects(featureBounds(feature)) {
+				result = append(result, feature)
+			}
+		}
+		return result
+	}
+
+	seen := make(map[string]bool, len(classes))
+	for _, class := range classes {
+		if seen[class] {
+			continue
+		}
+		seen[class] = true
+
+		rtree := c.classIndex(class)
+		if rtree == nil {
+			continue
+		}
+		for _, spatial := range rtree.SearchIntersect(boundsRect(bounds)) {
+			indexed := spatial.(*indexedFeature)
+			if c.exactBoundsQueries && !bounds.Intersects(indexed.bounds) {
+				continue // Only matched the padded R-tree rectangle
+			}
+			result = append(result, indexed.feature)
+		}
+	}
+	return result
+}
+
+// classIndex returns the R-tree for one object class, building it on first
+// use. Returns nil if the chart has no features of the class.
+func (c *Chart) classIndex(class string) *rtreego.Rtree {
+	c.classIndexes.mu.Lock()
+	defer c.classIndexes.mu.Unlock()
+
+	if rtree, ok := c.classIndexes.trees[class]; ok {
+		return rtree
+	}
+	if c.classIndexes.trees == nil {
+		c.classIndexes.trees = make(map[string]*rtreego.Rtree)
+	}
+
+	var rtree *rtreego.Rtree
+	for _, feature := range c.features {
+		if feature.objectClass != class {
+			continue
+		}
+		if rtree == nil {
+			minChildren, maxChildren := c.rtreeNodeSize()
+			rtree = rtreego.NewTree(2, minChildren, maxChildren)
+		}
+		rtree.Insert(&indexedFeature{feature: feature, bounds: featureBounds(feature)})
+	}
+
+	c.classIndexes.trees[class] = rtree
+	return rtree
+}
@@ -0,0 +1,266 @@
+package s57
+
+// Outcodes for Cohen–Sutherland line clipping.
+const (
+	outcodeInside = 0
+	outcodeLeft   = 1 << iota
+	outcodeRight
+	outcodeBottom
+	outcodeTop
+)
+
+// ClippedFeaturesInBounds returns copies of the features intersecting bounds
+// with their geometries clipped to the bounding box.
+//
+// This is intended for bandwidth-sensitive or GPU-submission paths where a
+// coastline spanning the whole chart should not be sent in full for every
+// viewport. Polygons are clipped with Sutherland–Hodgman and lines with
+// Cohen–Sutherland, the outer ring and each hole separately. Point features
+// pass through unchanged.
+//
+// A line that leaves and re-enters the bounds is split into several pieces,
+// each returned as its own LineString Feature sharing the original ID, object
+// class and attributes; each part of a MultiLineString is clipped the same
+// way. Polygons clipped against a rectangle always yield a single piece;
+// clipping against a concave region could split them, but Bounds is always a
+// rectangle.
+//
+// The original features are never mutated.
+func (c *Chart) ClippedFeaturesInBounds(bounds Bounds) []Feature {
+	candidates := c.FeaturesInBounds(bounds)
+
+	result := make([]Feature, 0, len(candidates))
+	for _, feature := range candidates {
+		geom := feature.geometry
+		switch geom.Type {
+		case GeometryTypeLineString, GeometryTypeMultiLineString:
+			for _, line := range geom.lines() {
+				for _, piece := range clipLineString(line, bounds) {
+					clipped := feature
+					clipped.geometry = Geometry{Type: GeometryTypeLineString, Coordinates: piece}
+					result = append(result, clipped)
+				}
+			}
+		case GeometryTypePolygon:
+			var coords [][]float64
+			for i, ring := range polygonRings(geom.Coordinates) {
+				ring = clipPolygon(ring, bounds)
+				if len(ring) < 4 {
+					if i == 0 {
+						break // Polygon lies entirely outside the bounds
+					}
+					continue // Hole lies outside the bounds
+				}
+				coords = append(coords, ring...)
+			}
+			if len(coords) == 0 {
+				continue
+			}
+			clipped := feature
+			clipped.geometry = Geometry{Type: geom.Type, Coordinates: coords}
+			result = append(result, clipped)
+		default:
+			// Points (including multipoint SOUNDG) pass through unchanged
+			result = append(result, feature)
+		}
+	}
+	return result
+}
+
+// computeOutcode classifies a point relative to the bounds for Cohen–Sutherland.
+func computeOutcode(lon, lat float64, b Bounds) int {
+	code := outcodeInside
+	if lon < b.MinLon {
+		code |= outcodeLeft
+	} else if lon > b.MaxLon {
+		code |= outcodeRight
+	}
+	if lat < b.MinLat {
+		code |= outcodeBottom
+	} else if lat > b.MaxLat {
+		code |= outcodeTop
+	}
+	return code
+}
+
+// clipSegment clips the segment p0-p1 to the bounds using Cohen–Sutherland.
+// Returns the clipped endpoints and false if the segment is entirely outside.
+func clipSegment(p0, p1 []float64, b Bounds) ([]float64, []float64, bool) {
+	a, z := p0, p1
+	codeA := computeOutcode(a[0], a[1], b)
+	codeZ := computeOutcode(z[0], z[1], b)
+
+	for {
+		if codeA|codeZ == 0 {
+			return a, z, true // Both inside
+		}
+		if codeA&codeZ != 0 {
+			return nil, nil, false // Both on the same outside side
+		}
+
+		// Pick an endpoint outside the bounds and move it to the boundary
+		out := codeA
+		if out == 0 {
+			out = codeZ
+		}
+
+		var t float64
+		switch {
+		case out&outcodeTop != 0:
+			t = (b.MaxLat - a[1]) / (z[1] - a[1])
+		case out&outcodeBottom != 0:
+			t = (b.MinLat - a[1]) / (z[1] - a[1])
+		case out&outcodeRight != 0:
+			t = (b.MaxLon - a[0]) / (z[0] - a[0])
+		default: // outcodeLeft
+			t = (b.MinLon - a[0]) / (z[0] - a[0])
+		}
+		p := interpolateCoord(a, z, t)
+
+		// Snap the clipped coordinate exactly onto the boundary it was moved to
+		switch {
+		case out&outcodeTop != 0:
+			p[1] = b.MaxLat
+		case out&outcodeBottom != 0:
+			p[1] = b.MinLat
+		case out&outcodeRight != 0:
+			p[0] = b.MaxLon
+		default:
+			p[0] = b.MinLon
+		}
+
+		if out == codeA {
+			a = p
+			codeA = computeOutcode(a[0], a[1], b)
+		} else {
+			z = p
+			codeZ = computeOutcode(z[0], z[1], b)
+		}
+	}
+}
+
+// clipLineString clips a line to the bounds, returning one coordinate list
+// per contiguous piece that lies inside.
+func clipLineString(coords [][]float64, b Bounds) [][][]float64 {
+	var pieces [][][]float64
+	var current [][]float64
+
+	for i := 0; i+1 < len(coords); i++ {
+		a, z, ok := clipSegment(coords[i], coords[i+1], b)
+		if !ok {
+			if len(current) >= 2 {
+				pieces = append(pieces, current)
+			}
+			current = nil
+			continue
+		}
+
+		if len(current) == 0 {
+			current = append(current, a)
+		} else if last := current[len(current)-1]; last[0] != a[0] || last[1] != a[1] {
+			// Segment start was clipped: the line left and re-entered the bounds
+			if len(current) >= 2 {
+				pieces = append(pieces, current)
+			}
+			current = [][]float64{a}
+		}
+		current = append(current, z)
+
+		// Segment end was clipped: the line leaves the bounds here
+		if z[0] != coords[i+1][0] || z[1] != coords[i+1][1] {
+			pieces = append(pieces, current)
+			current = nil
+		}
+	}
+
+	if len(current) >= 2 {
+		pieces = append(pieces, current)
+	}
+	return pieces
+}
+
+// clipPolygon clips a closed ring to the bounds using Sutherland–Hodgman.
+// The returned ring is closed (first coordinate repeated at the end) or empty.
+func clipPolygon(coords [][]float64, b Bounds) [][]float64 {
+	ring := coords
+	if n := len(ring); n > 1 && ring[0][0] == ring[n-1][0] && ring[0][1] == ring[n-1][1] {
+		ring = ring[:n-1] // Work on the open ring
+	}
+
+	edges := []struct {
+		inside    func(p []float64) bool
+		intersect func(p, q []float64) []float64
+	}{
+		{ // Left
+			func(p []float64) bool { return p[0] >= b.MinLon },
+			func(p, q []float64) []float64 {
+				r := interpolateCoord(p, q, (b.MinLon-p[0])/(q[0]-p[0]))
+				r[0] = b.MinLon
+				return r
+			},
+		},
+		{ // Right
+			func(p []float64) bool { return p[0] <= b.MaxLon },
+			func(p, q []float64) []float64 {
+				r := interpolateCoord(p, q, (b.MaxLon-p[0])/(q[0]-p[0]))
+				r[0] = b.MaxLon
+				return r
+			},
+		},
+		{ // Bottom
+			func(p []float64) bool { return p[1] >= b.MinLat },
+			func(p, q []float64) []float64 {
+				r := interpolateCoord(p, q, (b.MinLat-p[1])/(q[1]-p[1]))
+				r[1] = b.MinLat
+				return r
+			},
+		},
+		{ // Top
+			func(p []float64) bool { return p[1] <= b.MaxLat },
+			func(p, q []float64) []float64 {
+				r := interpolateCoord(p, q, (b.MaxLat-p[1])/(q[1]-p[1]))
+				r[1] = b.MaxLat
+				return r
+			},
+		},
+	}
+
+	for _, edge := range edges {
+		if len(ring) == 0 {
+			break
+		}
+		input := ring
+		ring = make([][]float64, 0, len(input))
+		prev := input[len(input)-1]
+		for _, curr := range input {
+			if edge.inside(curr) {
+				if !edge.inside(prev) {
+					ring = append(ring, edge.intersect(prev, curr))
+				}
+				ring = append(ring, curr)
+			} else if edge.inside(prev) {
+				ring = append(ring, edge.intersect(prev, curr))
+			}
+			prev = curr
+		}
+	}
+
+	if len(ring) < 3 {
+		return [][]float64{}
+	}
+	return append(ring, append([]float64(nil), ring[0]...))
+}
+
+// interpolateCoord returns a new coordinate at parameter t along p-q.
+// Any dimensions shared by both coordinates (e.g. depth) are interpolated too.
+func interpolateCoord(p, q []float64, t float64) []float64 {
+	n := len(p)
+	if len(q) < n {
+		n = len(q)
+	}
+	r := make([]float64, n)
+	for i := 0; i < n; i++ {
+		r[i] = p[i] + t*(q[i]-p[i])
+	}
+	return r
+}
@@ -0,0 +1,98 @@
+package s57
+
+import (
+	"testing"
+)
+
+// TestClipLineString tests Cohen–Sutherland clipping of lines to a viewport
+func TestClipLineString(t *testing.T) {
+	viewport := Bounds{MinLon: 0, MaxLon: 10, MinLat: 0, MaxLat: 10}
+
+	// Line crossing the viewport horizontally
+	line := [][]float64{{-5, 5}, {15, 5}}
+	pieces := clipLineString(line, viewport)
+	if len(pieces) != 1 {
+		t.Fatalf("Expected 1 piece, got %d", len(pieces))
+	}
+	if got := pieces[0]; got[0][0] != 0 || got[1][0] != 10 {
+		t.Errorf("Expected line clipped to [0,10], got %v", got)
+	}
+
+	// Line that leaves and re-enters the viewport is split in two
+	zigzag := [][]float64{{2, 5}, {5, 15}, {8, 5}}
+	pieces = clipLineString(zigzag, viewport)
+	if len(pieces) != 2 {
+		t.Fatalf("Expected 2 pieces, got %d: %v", len(pieces), pieces)
+	}
+
+	// Line entirely outside
+	outside := [][]float64{{20, 20}, {30, 30}}
+	if pieces := clipLineString(outside, viewport); len(pieces) != 0 {
+		t.Errorf("Expected no pieces, got %v", pieces)
+	}
+}
+
+// TestClipPolygon tests Sutherland–Hodgman clipping of polygons to a viewport
+func TestClipPolygon(t *testing.T) {
+	viewport := Bounds{MinLon: 0, MaxLon: 10, MinLat: 0, MaxLat: 10}
+
+	// Square overlapping the top-right corner of the viewport
+	square := [][]float64{{5, 5}, {15, 5}, {15, 15}, {5, 15}, {5, 5}}
+	ring := clipPolygon(square, viewport)
+	if len(ring) != 5 {
+		t.Fatalf("Expected closed 4-vertex ring, got %v", ring)
+	}
+	for _, coord := range ring {
+		if !viewport.Contains(coord[0], coord[1]) {
+			t.Errorf("Clipped coordinate %v outside viewport", coord)
+		}
+	}
+
+	// Original must not be mutated
+	if square[1][0] != 15 {
+		t.Error("Clipping mutated the input coordinates")
+	}
+
+	// Depth is kept on every vertex, the closing one included
+	deep := [][]float64{{5, 5, 2}, {15, 5, 4}, {15, 15, 6}, {5, 15, 8}, {5, 5, 2}}
+	ring = clipPolygon(deep, viewport)
+	for i, coord := range ring {
+		if len(coord) != 3 {
+			t.Errorf("Clipped coordinate %d lost its depth: %v", i, coord)
+		}
+	}
+	if first, last := ring[0], ring[len(ring)-1]; len(last) == 3 && last[2] != first[2] {
+		t.Errorf("Closing coordinate %v does not repeat the first %v", last, first)
+	}
+
+	// Polygon entirely outside
+	far := [][]float64{{20, 20}, {30, 20}, {30, 30}, {20, 20}}
+	if ring := clipPolygon(far, viewport); len(ring) != 0 {
+		t.Errorf("Expected empty ring, got %v", ring)
+	}
+}
+
+// TestClippedFeaturesInBounds tests clipping against a real chart
+func TestClippedFeaturesInBounds(t *testing.T) {
+	parser := NewParser()
+	chart, err := parser.Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	viewport := viewportFraction(chart, 0.25, 0.75)
+
+	const tolerance = 1e-9
+	for _, f := range chart.ClippedFeaturesInBounds(viewport) {
+		geom := f.Geometry()
+		if geom.Type == GeometryTypePoint {
+			continue
+		}
+		for _, coord := range geom.Coordinates {
+			if !viewport.Expand(tolerance).Contains(coord[0], coord[1]) {
+				t.Fatalf("Feature %d (%s) has coordinate %v outside viewport",
+					f.ID(), f.ObjectClass(), coord)
+			}
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package s57
+
+import (
+	"fmt"
+	"sort"
+)
+
+// depthAreaClasses are the area classes that carry a DRVAL1/DRVAL2 depth range.
+var depthAreaClasses = map[string]bool{
+	"DEPARE": true, // Depth area
+	"DRGARE": true, // Dredged area
+}
+
+// MergeDepthAreas dissolves adjacent depth areas with the same depth range.
+//
+// DEPARE and DRGARE polygons are grouped by object class and DRVAL1/DRVAL2,
+// and polygons in a group that share at least one boundary segment are merged
+// into a single area. Areas without a neighbour are returned unchanged. This is
+// intended for generalized small-scale rendering, where it reduces the number
+// of fills drawn per tile.
+//
+// Shared boundaries are found by exact coordinate match, which holds for
+// polygons built from the same S-57 edges. The chart is not modified; merged
+// areas are new features carrying the ID and attributes of the lowest-ID area
+// they were built from.
+//
+// Areas are merged by their outer rings, so a merged area is returned as its
+// outer boundary only: holes (for example around an enclosed shoal of a
+// different depth range) are dropped and the enclosed feature must be drawn
+// above the merged area to remain visible.
+func (c *Chart) MergeDepthAreas() []Feature {
+	groups := make(map[string][]Feature)
+	var keys []string
+	for _, f := range c.features {
+		if !depthAreaClasses[f.objectClass] || f.geometry.Type != GeometryTypePolygon ||
+			len(f.geometry.Coordinates) < 4 {
+			continue
+		}
+		key := depthRangeKey(f)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], f)
+	}
+	sort.Strings(keys)
+
+	result := make([]Feature, 0)
+	for _, key := range keys {
+		result = append(result, mergeAdjacentPolygons(groups[key])...)
+	}
+	return result
+}
+
+// depthRangeKey groups depth areas by object class and depth range
+func depthRangeKey(f Feature) string {
+	drval1, _ := attributeFloat(f, "DRVAL1")
+	drval2, _ := attributeFloat(f, "DRVAL2")
+	return fmt.Sprintf("%s|%g|%g", f.objectClass, drval1, drval2)
+}
+
+// segmentKey identifies an undirected boundary segment
+type segmentKey struct {
+	x1, y1, x2, y2 float64
+}
+
+// newSegmentKey orders the endpoints so both directions share a key
+func newSegmentKey(a, b []float64) segmentKey {
+	if a[0] < b[0] || (a[0] == b[0] && a[1] < b[1]) {
+		return segmentKey{a[0], a[1], b[0], b[1]}
+	}
+	return segmentKey{b[0], b[1], a[0], a[1]}
+}
+
+// mergeAdjacentPolygons unions polygons that share boundary segments
+func mergeAdjacentPolygons(features []Feature) []Feature {
+	sort.Slice(features, func(i, j int) bool { return features[i].id < features[j].id })
+
+	// Union-find over polygons sharing a segment
+	parent := make([]int, len(features))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+
+	owner := make(map[segmentKey]int)
+	for i, f := range features {
+		coords := outerRing(f.geometry)
+		for j := 0; j+1 < len(coords); j++ {
+			if coords[j][0] == coords[j+1][0] && coords[j][1] == coords[j+1][1] {
+				continue
+			}
+			key := newSegmentKey(coords[j], coords[j+1])
+			if other, ok := owner[key]; ok {
+				if a, b := find(i), find(other); a != b {
+					parent[b] = a
+				}
+			} else {
+				owner[key] = i
+			}
+		}
+	}
+
+	components := make(map[int][]int)
+	var roots []int
+	for i := range features {
+		root := find(i)
+		if _, ok := components[root]; !ok {
+			roots = append(roots, root)
+		}
+		components[root] = append(components[root], i)
+	}
+
+	result := make([]Feature, 0, len(roots))
+	for _, root := range roots {
+		members := components[root]
+		first := features[members[0]]
+		if len(members) == 1 {
+			result = append(result, first)
+			continue
+		}
+
+		rings := make([][][]float64, len(members))
+		for i, idx := range members {
+			rings[i] = outerRing(features[idx].geometry)
+		}
+		for _, ring := range dissolveRings(rings) {
+			result = append(result, Feature{
+				id:          first.id,
+				agency:      first.agency,
+				subdivision: first.subdivision,
+				objectClass: first.objectClass,
+				geometry:    Geometry{Type: GeometryTypePolygon, Coordinates: ring},
+				attributes:  first.attributes,
+			})
+		}
+	}
+	return result
+}
+
+// outerRing returns the outer ring of a polygon, without its holes
+func outerRing(g Geometry) [][]float64 {
+	if rings := polygonRings(g.Coordinates); len(rings) > 0 {
+		return rings[0]
+	}
+	return g.Coordinates
+}
+
+// dissolveRings merges rings by removing their shared segments.
+//
+// Each ring is oriented counter-clockwise, so a boundary shared by two rings
+// appears once in each direction. Those pairs cancel; the remaining directed
+// segments are chained back into rings. Only counter-clockwise (outer) rings
+// are returned; holes of the merged area are dropped.
+func dissolveRings(rings [][][]float64) [][][]float64 {
+	type segment struct {
+		from, to []float64
+	}
+
+	counts := make(map[segmentKey]int)
+	var segments []segment
+	for _, ring := range rings {
+		ring = counterClockwise(ring)
+		for j := 0; j+1 < len(ring); j++ {
+			a, b := ring[j], ring[j+1]
+			if a[0] == b[0] && a[1] == b[1] {
+				continue
+			}
+			counts[newSegmentKey(a, b)]++
+			segments = append(segments, segment{a, b})
+		}
+	}
+
+	// Outgoing boundary segments per start point
+	type point struct{ x, y float64 }
+	outgoing := make(map[point][]int)
+	var boundary []int
+	for i, s := range segments {
+		if counts[newSegmentKey(s.from, s.to)] > 1 {
+			continue // Shared between rings
+		}
+		p := point{s.from[0], s.from[1]}
+		outgoing[p] = append(outgoing[p], i)
+		boundary = append(boundary, i)
+	}
+
+	used := make(map[int]bool)
+	var result [][][]float64
+	for _, start := range boundary {
+		if used[start] {
+			continue
+		}
+		ring := [][]float64{{segments[start].from[0], segments[start].from[1]}}
+		current := start
+		for current >= 0 && !used[current] {
+			used[current] = true
+			to := segments[current].to
+			ring = append(ring, []float64{to[0], to[1]})
+			if to[0] == ring[0][0] && to[1] == ring[0][1] {
+				break
+			}
+			current = -1
+			for _, next := range outgoing[point{to[0], to[1]}] {
+				if !used[next] {
+					current = next
+					break
+				}
+			}
+		}
+
+		closed := len(ring) >= 4 && ring[0][0] == ring[len(ring)-1][0] && ring[0][1] == ring[len(ring)-1][1]
+		if closed && ringArea(ring) > 0 {
+			result = append(result, ring)
+		}
+	}
+	return result
+}
+
+// ringArea returns the signed planar area of a closed ring in square degrees.
+// Positive for counter-clockwise rings, negative for clockwise.
+func ringArea(ring [][]float64) float64 {
+	area := 0.0
+	for i := 0; i+1 < len(ring); i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return area / 2
+}
+
+// counterClockwise returns the ring oriented counter-clockwise
+func counterClockwise(ring [][]float64) [][]float64 {
+	if ringArea(ring) >= 0 {
+		return ring
+	}
+	reversed := make([][]float64, len(ring))
+	for i, coord := range ring {
+		reversed[len(ring)-1-i] = coord
+	}
+	return reversed
+}
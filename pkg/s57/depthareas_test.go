@@ -0,0 +1,80 @@
+package s57
+
+import (
+	"math"
+	"testing"
+)
+
+// square returns a closed clockwise unit-square ring with its lower-left corner at (x, y)
+func square(x, y float64) Geometry {
+	return Geometry{
+		Type: GeometryTypePolygon,
+		Coordinates: [][]float64{
+			{x, y}, {x, y + 1}, {x + 1, y + 1}, {x + 1, y}, {x, y},
+		},
+	}
+}
+
+// TestMergeDepthAreas tests dissolving adjacent depth areas with equal ranges
+func TestMergeDepthAreas(t *testing.T) {
+	shallow := map[string]interface{}{"DRVAL1": "0", "DRVAL2": "5"}
+	deep := map[string]interface{}{"DRVAL1": "5", "DRVAL2": "10"}
+
+	chart := newTestChart([]Feature{
+		{id: 1, objectClass: "DEPARE", geometry: square(0, 0), attributes: shallow},
+		{id: 2, objectClass: "DEPARE", geometry: square(1, 0), attributes: shallow},
+		{id: 3, objectClass: "DEPARE", geometry: square(5, 0), attributes: shallow},
+		{id: 4, objectClass: "DEPARE", geometry: square(0, 1), attributes: deep},
+		{id: 5, objectClass: "LNDARE", geometry: square(2, 0), attributes: map[string]interface{}{}},
+	})
+
+	merged := chart.MergeDepthAreas()
+	if len(merged) != 3 {
+		t.Fatalf("Expected 3 depth areas after merging, got %d", len(merged))
+	}
+
+	for _, f := range merged {
+		area := math.Abs(ringArea(f.Geometry().Coordinates))
+		switch f.ID() {
+		case 1:
+			if area != 2 {
+				t.Errorf("Expected merged area of 2, got %v (%v)", area, f.Geometry().Coordinates)
+			}
+			if drval2, _ := f.Attribute("DRVAL2"); drval2 != "5" {
+				t.Errorf("Merged area lost its depth range: %v", drval2)
+			}
+		case 3, 4:
+			if area != 1 {
+				t.Errorf("Feature %d should be unchanged, got area %v", f.ID(), area)
+			}
+		default:
+			t.Errorf("Unexpected feature %d (%s) in result", f.ID(), f.ObjectClass())
+		}
+	}
+
+	// The chart itself is not modified
+	if chart.FeatureCount() != 5 || len(chart.features[0].geometry.Coordinates) != 5 {
+		t.Error("MergeDepthAreas modified the chart")
+	}
+}
+
+// TestMergeDepthAreasRealChart tests merging never increases the number of areas
+func TestMergeDepthAreasRealChart(t *testing.T) {
+	chart, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	areas := 0
+	for _, f := range chart.Features() {
+		if depthAreaClasses[f.ObjectClass()] && f.Geometry().Type == GeometryTypePolygon {
+			areas++
+		}
+	}
+
+	merged := chart.MergeDepthAreas()
+	if len(merged) == 0 || len(merged) > areas {
+		t.Errorf("Expected between 1 and %d merged areas, got %d", areas, len(merged))
+	}
+	t.Logf("Merged %d depth areas into %d", areas, len(merged))
+}
@@ -39,7 +39,11 @@
 //	// Query visible features
 //	visible := chart.FeaturesInBounds(viewport)
 //
-//	// Features are returned as a slice - no allocation overhead for iteration
+//	// Or visit them without allocating a result slice
+//	chart.EachFeatureInBounds(viewport, func(f s57.Feature) bool {
+//	    render(f)
+//	    return true // false stops iteration
+//	})
 //
 // # Feature Access
 //
@@ -94,6 +98,6 @@
 //
 // - Spatial index built automatically during parsing
 // - Viewport queries are O(n) with low constant factor (simple bounding box checks)
-// - No allocations during iteration
+// - No allocations during iteration with EachFeature and EachFeatureInBounds
 // - Features parsed eagerly (charts fit in memory)
 package s57
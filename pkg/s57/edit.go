@@ -0,0 +1,70 @@
+package s57
+
+import "sync"
+
+// RemoveFeatures removes every feature for which remove returns true and
+// returns the number of features removed.
+//
+// The spatial index is rebuilt afterwards, so bounding box queries never
+// return removed features. The chart's bounds are recomputed the same way as
+// at parse time (from M_COVR coverage if present, otherwise from the remaining
+// features). Retained raw records of removed features are dropped.
+//
+// The chart gets a new feature slice: slices previously returned by Features
+// and views taken with Snapshot keep the old features. RemoveFeatures must not
+// be called while other goroutines are querying the chart.
+//
+// Example:
+//
+//	// Drop lights before rendering a daytime chart
+//	removed := chart.RemoveFeatures(func(f s57.Feature) bool {
+//	    return f.ObjectClass() == "LIGHTS"
+//	})
+func (c *Chart) RemoveFeatures(remove func(Feature) bool) int {
+	if c.released {
+		return 0
+	}
+
+	kept := make([]Feature, 0, len(c.features))
+	for _, feature := range c.features {
+		if !remove(feature) {
+			kept = append(kept, feature)
+			continue
+		}
+		if c.rawRecords != nil {
+			delete(c.rawRecords, feature.FeatureID())
+		}
+	}
+
+	removed := len(c.features) - len(kept)
+	if removed > 0 {
+		c.features = kept
+		c.RebuildSpatialIndex()
+	}
+	return removed
+}
+
+// RebuildSpatialIndex rebuilds the chart's spatial indexes from its current
+// features, and recomputes the chart bounds and object class list.
+//
+// Chart methods that change the feature set (such as RemoveFeatures) call it
+// themselves; it is only needed after modifying features in place, e.g.
+// changing geometry through the slice returned by Features. It must not be
+// called while other goroutines are querying the chart. Does nothing on a
+// released chart.
+func (c *Chart) RebuildSpatialIndex() {
+	if c.released {
+		return
+	}
+
+	c.spatialIndex = nil
+	c.buildSpatialIndex()
+
+	c.classIndexes.mu.Lock()
+	c.classIndexes.trees = nil
+	c.classIndexes.mu.Unlock()
+
+	c.objectClassesOnce = sync.Once{}
+	c.objectClasses = nil
+	c.objectClassSet = nil
+}
@@ -0,0 +1,51 @@
+package s57
+
+import "testing"
+
+// TestRemoveFeatures tests that removed features disappear from every query
+func TestRemoveFeatures(t *testing.T) {
+	chart, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+	before := chart.FeatureCount()
+	bounds := chart.Bounds()
+	if len(chart.FeaturesInBoundsOfClass(bounds, "LIGHTS")) == 0 {
+		t.Fatal("Expected LIGHTS in test chart")
+	}
+	view := chart.Snapshot()
+
+	removed := chart.RemoveFeatures(func(f Feature) bool { return f.ObjectClass() == "LIGHTS" })
+	if removed == 0 || chart.FeatureCount() != before-removed {
+		t.Fatalf("Removed %d features, count %d -> %d", removed, before, chart.FeatureCount())
+	}
+
+	for _, f := range chart.FeaturesInBounds(bounds) {
+		if f.ObjectClass() == "LIGHTS" {
+			t.Fatalf("FeaturesInBounds returned removed feature %d", f.ID())
+		}
+	}
+	if lights := chart.FeaturesInBoundsOfClass(bounds, "LIGHTS"); len(lights) != 0 {
+		t.Errorf("FeaturesInBoundsOfClass returned %d removed features", len(lights))
+	}
+	for _, class := range chart.ObjectClasses() {
+		if class == "LIGHTS" {
+			t.Error("ObjectClasses still lists LIGHTS")
+		}
+	}
+
+	// Views taken before the removal are unchanged
+	if view.FeatureCount() != before {
+		t.Errorf("Snapshot has %d features, expected %d", view.FeatureCount(), before)
+	}
+
+	if n := chart.RemoveFeatures(func(Feature) bool { return false }); n != 0 {
+		t.Errorf("Expected nothing removed, got %d", n)
+	}
+
+	// Removing everything leaves queries empty
+	chart.RemoveFeatures(func(Feature) bool { return true })
+	if got := chart.FeaturesInBounds(bounds); len(got) != 0 {
+		t.Errorf("Expected no features, got %d", len(got))
+	}
+}
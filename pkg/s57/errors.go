@@ -0,0 +1,166 @@
+package s57
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/beetlebugorg/s57/internal/parser"
+)
+
+// ErrNotABaseCell is returned when the file passed to Parse is an update
+// dataset (a .001, .002, ... file) rather than a base cell.
+//
+// Update datasets only contain changes to a base cell. Parse the .000 file
+// instead; its updates are discovered and applied automatically.
+//
+// Example:
+//
+//	var notBase *s57.ErrNotABaseCell
+//	if errors.As(err, &notBase) {
+//	    fmt.Printf("%s is update %s\n", notBase.Filename, notBase.UpdateNumber)
+//	}
+type ErrNotABaseCell = parser.ErrNotABaseCell
+
+// ErrFeatureCountMismatch reports that the number of meta or geo features
+// parsed differs from the count declared in the cell's DSSI record. It is
+// produced by ParseOptions.VerifyCounts, as a warning or, with StrictCounts,
+// as the parse error.
+type ErrFeatureCountMismatch = parser.ErrFeatureCountMismatch
+
+// ErrStaleUpdate is reported as a warning (see Chart.Warnings) for an update
+// file whose edition differs from the base cell's. Updates must match the base
+// edition: when a producer re-issues a cell as a new edition, UPDN restarts at
+// 0 and update files of the old edition left on disk are ignored.
+type ErrStaleUpdate = parser.ErrStaleUpdate
+
+// ErrDuplicateFeature is reported as a warning (see Chart.Warnings) when a
+// base cell contains several feature records with the same feature object
+// identifier. Only the record with the highest version (RVER) is kept, so
+// updates and geometry apply to a single feature.
+type ErrDuplicateFeature = parser.ErrDuplicateFeature
+
+// ErrMissingPoints is reported as a warning (see Chart.Warnings) when some of
+// the nodes a point feature references are missing from the dataset, for
+// example part of a SOUNDG multipoint. The feature keeps the points that did
+// resolve; Dropped tells how many references were lost.
+type ErrMissingPoints = parser.ErrMissingPoints
+
+// ErrMissingSpatialRecord reports a feature whose spatial references point to
+// a spatial record (node, edge or face) absent from the dataset. It is the
+// parse error, or a warning under ContinueOnRecordError, for features whose
+// geometry cannot be built. FeatureID and SpatialID name the records.
+//
+// Geometry errors are returned wrapped; recover them with errors.As to tell a
+// broken feature from an I/O failure:
+//
+//	var missing *s57.ErrMissingSpatialRecord
+//	if errors.As(err, &missing) {
+//	    log.Printf("feature %d: spatial record %d missing", missing.FeatureID, missing.SpatialID)
+//	}
+type ErrMissingSpatialRecord = parser.ErrMissingSpatialRecord
+
+// ErrInvalidSpatialRecord reports a spatial record of the wrong type for the
+// way a feature uses it, e.g. a node referenced where an edge is required.
+// FeatureID is the referencing feature and SpatialID the record.
+type ErrInvalidSpatialRecord = parser.ErrInvalidSpatialRecord
+
+// ErrInvalidGeometry reports geometry that violates S-57 rules, such as a
+// polygon whose edges yield no ring, or one failing ValidateGeometry or
+// ValidatePolygons. It is returned wrapped, like ErrMissingSpatialRecord:
+//
+//	var invalid *s57.ErrInvalidGeometry
+//	if errors.As(err, &invalid) && invalid.HasType && invalid.Type == s57.GeometryTypePolygon {
+//	    log.Printf("feature %d: %s", invalid.FeatureID, invalid.Reason)
+//	}
+type ErrInvalidGeometry struct {
+	FeatureID int64        // Feature being built; 0 if not known
+	Type      GeometryType // Geometry type, when HasType is set
+	HasType   bool         // Type is known (the zero Type is GeometryTypePoint)
+	Reason    string       // What is wrong with the geometry
+}
+
+func (e *ErrInvalidGeometry) Error() string {
+	if e.HasType {
+		return fmt.Sprintf("invalid geometry (%v): %s", e.Type, e.Reason)
+	}
+	return fmt.Sprintf("invalid geometry: %s", e.Reason)
+}
+
+// publicError converts an internal error type in err's chain to its public
+// counterpart, so callers can match it with errors.As. The message is kept.
+// Errors without one are returned unchanged.
+func publicError(err error) error {
+	var invalid *parser.ErrInvalidGeometry
+	if err == nil || !errors.As(err, &invalid) {
+		return err
+	}
+	public := &ErrInvalidGeometry{
+		FeatureID: invalid.FeatureID,
+		Type:      GeometryType(invalid.Type),
+		HasType:   invalid.HasType,
+		Reason:    invalid.Reason,
+	}
+	if err == error(invalid) {
+		return public
+	}
+	return &convertedError{err: err, public: public}
+}
+
+// convertedError is a wrapped internal error whose chain also yields its
+// public counterpart
+type convertedError struct {
+	err    error // Original error, for its message and the rest of its chain
+	public error // Public counterpart of the internal error in err's chain
+}
+
+func (e *convertedError) Error() string   { return e.err.Error() }
+func (e *convertedError) Unwrap() []error { return []error{e.public, e.err} }
+
+// publicErrors converts each error with publicError
+func publicErrors(errs []error) []error {
+	if errs == nil {
+		return nil
+	}
+	converted := make([]error, len(errs))
+	for i, err := range errs {
+		converted[i] = publicError(err)
+	}
+	return converted
+}
+
+// Sentinel errors for common parse failures. Test for them with errors.Is:
+//
+//	chart, err := parser.Parse(path)
+//	switch {
+//	case os.IsNotExist(err):
+//	    // File not found (the *os.PathError is returned unwrapped)
+//	case errors.Is(err, s57.ErrNotS57):
+//	    // Not an ISO 8211 / S-57 file
+//	}
+var (
+	// ErrNotS57 indicates the file is not a readable ISO 8211 / S-57 dataset.
+	ErrNotS57 = parser.ErrNotS57
+
+	// ErrMissingDSID indicates the dataset has no DSID (Data Set
+	// Identification) record.
+	ErrMissingDSID = parser.ErrMissingDSID
+
+	// ErrUnsupportedDatum indicates a horizontal datum other than WGS-84.
+	ErrUnsupportedDatum = parser.ErrUnsupportedDatum
+
+	// ErrInvalidUpdateSequence indicates an update file is out of order or
+	// belongs to a different dataset.
+	ErrInvalidUpdateSequence = parser.ErrInvalidUpdateSequence
+
+	// ErrMultipleDatasets indicates the file bundles several datasets (more
+	// than one DSID record). Use Parser.ParseAll to read one chart per dataset.
+	ErrMultipleDatasets = parser.ErrMultipleDatasets
+)
+
+// ErrResourceNotFound is returned by Chart.Resource when a companion text or
+// picture file is not part of the exchange set.
+var ErrResourceNotFound = errors.New("resource not found in exchange set")
+
+// ErrChartReleased is returned by Chart methods that can fail, such as
+// Resource, after Chart.Release has been called.
+var ErrChartReleased = errors.New("chart has been released")
@@ -0,0 +1,73 @@
+package s57
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// ExportPointsCSV writes the chart's point features as CSV, one row per
+// coordinate.
+//
+// Columns are class, id, objnam, lon, lat and depth. Multipoint SOUNDG
+// features expand to one row per sounding with its depth; depth is empty for
+// points without a Z value. Line and area features are skipped, as are point
+// features without coordinates (e.g. meta-features with no geometry).
+//
+// Only features of the given object classes are written; with no classes,
+// every point feature is. Rows follow chart order.
+//
+// Example:
+//
+//	// Soundings as lon,lat,depth
+//	f, _ := os.Create("soundings.csv")
+//	defer f.Close()
+//	err := chart.ExportPointsCSV(f, "SOUNDG")
+func (c *Chart) ExportPointsCSV(w io.Writer, classes ...string) error {
+	wanted := make(map[string]bool, len(classes))
+	for _, class := range classes {
+		wanted[class] = true
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"class", "id", "objnam", "lon", "lat", "depth"}); err != nil {
+		return err
+	}
+
+	for _, feature := range c.features {
+		if feature.geometry.Type != GeometryTypePoint {
+			continue
+		}
+		if len(wanted) > 0 && !wanted[feature.objectClass] {
+			continue
+		}
+
+		id := strconv.FormatInt(feature.id, 10)
+		name := ""
+		if value, ok := feature.attributes["OBJNAM"]; ok {
+			name, _ = value.(string)
+		}
+
+		for _, coord := range feature.geometry.Coordinates {
+			if len(coord) < 2 {
+				continue
+			}
+			depth := ""
+			if len(coord) >= 3 {
+				depth = formatCoordinate(coord[2])
+			}
+			row := []string{feature.objectClass, id, name, formatCoordinate(coord[0]), formatCoordinate(coord[1]), depth}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatCoordinate formats an ordinate with the fewest digits that round-trip.
+func formatCoordinate(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,82 @@
+package s57
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+)
+
+// TestExportPointsCSV tests point export and SOUNDG expansion
+func TestExportPointsCSV(t *testing.T) {
+	chart := &Chart{features: []Feature{
+		{id: 1, objectClass: "SOUNDG", geometry: Geometry{Type: GeometryTypePoint,
+			Coordinates: [][]float64{{-76.1, 38.1, 4.5}, {-76.2, 38.2, 7}, {-76.3, 38.3, 12.1}}}},
+		{id: 2, objectClass: "BOYLAT", geometry: Geometry{Type: GeometryTypePoint,
+			Coordinates: [][]float64{{-76.4, 38.4}}},
+			attributes: map[string]interface{}{"OBJNAM": "Buoy \"2\""}},
+		{id: 3, objectClass: "DEPCNT", geometry: Geometry{Type: GeometryTypeLineString,
+			Coordinates: [][]float64{{-76, 38}, {-75, 39}}}},
+		{id: 4, objectClass: "M_COVR", geometry: Geometry{Type: GeometryTypePoint}},
+	}}
+
+	var buf bytes.Buffer
+	if err := chart.ExportPointsCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Output is not valid CSV: %v", err)
+	}
+
+	expected := [][]string{
+		{"class", "id", "objnam", "lon", "lat", "depth"},
+		{"SOUNDG", "1", "", "-76.1", "38.1", "4.5"},
+		{"SOUNDG", "1", "", "-76.2", "38.2", "7"},
+		{"SOUNDG", "1", "", "-76.3", "38.3", "12.1"},
+		{"BOYLAT", "2", "Buoy \"2\"", "-76.4", "38.4", ""},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Expected rows %v, got %v", expected, rows)
+	}
+
+	// Class filter
+	buf.Reset()
+	if err := chart.ExportPointsCSV(&buf, "BOYLAT"); err != nil {
+		t.Fatal(err)
+	}
+	rows, _ = csv.NewReader(&buf).ReadAll()
+	if len(rows) != 2 || rows[1][0] != "BOYLAT" {
+		t.Errorf("Expected header and one BOYLAT row, got %v", rows)
+	}
+}
+
+// TestExportPointsCSVRealChart tests each SOUNDG sounding becomes a row
+func TestExportPointsCSVRealChart(t *testing.T) {
+	chart, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	soundings := 0
+	for _, f := range chart.Features() {
+		if f.ObjectClass() == "SOUNDG" {
+			soundings += len(f.Geometry().Coordinates)
+		}
+	}
+	if soundings == 0 {
+		t.Skip("Test chart has no soundings")
+	}
+
+	var buf bytes.Buffer
+	if err := chart.ExportPointsCSV(&buf, "SOUNDG"); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows)-1 != soundings {
+		t.Errorf("Expected %d sounding rows, got %d", soundings, len(rows)-1)
+	}
+}
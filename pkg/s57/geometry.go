@@ -0,0 +1,341 @@
+package s57
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/beetlebugorg/s57/internal/parser"
+)
+
+// Dimensions returns the number of ordinates per coordinate: 3 when the
+// coordinates carry depth as [lon, lat, depth] (e.g. SOUNDG), otherwise 2.
+//
+// A geometry is uniformly 2D or 3D; when only some coordinates of a geometry
+// have a depth, the parser fills in the others from their neighbours. Empty
+// geometry reports 2.
+func (g Geometry) Dimensions() int {
+	if len(g.Coordinates) > 0 && len(g.Coordinates[0]) >= 3 {
+		return 3
+	}
+	return 2
+}
+
+// lines returns the separate lines of a geometry: the parts of a
+// MultiLineString, the rings of a polygon, or the coordinates of any other
+// geometry as one line
+func (g Geometry) lines() [][][]float64 {
+	if g.Type == GeometryTypeMultiLineString && len(g.Parts) > 0 {
+		return g.Parts
+	}
+	if g.Type == GeometryTypePolygon {
+		if rings := polygonRings(g.Coordinates); len(rings) > 0 {
+			return rings
+		}
+	}
+	return [][][]float64{g.Coordinates}
+}
+
+// Centroid returns the geometric center of the geometry.
+//
+// For polygons this is the area centroid, for lines the length-weighted
+// center of the segments (of every part of a MultiLineString), and for
+// (multi)points the mean position. The centroid of a concave polygon can fall
+// outside it; use RepresentativePoint to anchor labels and symbols. Returns
+// (0, 0) for empty geometry.
+func (g Geometry) Centroid() (lon, lat float64) {
+	coords := g.Coordinates
+	if len(coords) == 0 {
+		return 0, 0
+	}
+
+	switch g.Type {
+	case GeometryTypePolygon:
+		// Area-weighted centroid of the rings (shoelace formula); holes wind
+		// opposite to the outer ring and subtract from it
+		var area, cx, cy float64
+		for _, ring := range polygonRings(coords) {
+			for i := 0; i+1 < len(ring); i++ {
+				cross := ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+				area += cross
+				cx += (ring[i][0] + ring[i+1][0]) * cross
+				cy += (ring[i][1] + ring[i+1][1]) * cross
+			}
+		}
+		if area != 0 {
+			return cx / (3 * area), cy / (3 * area)
+		}
+		// Degenerate ring - fall back to the boundary
+		return Geometry{Type: GeometryTypeLineString, Coordinates: coords}.Centroid()
+
+	case GeometryTypeLineString, GeometryTypeMultiLineString:
+		var length, cx, cy float64
+		for _, line := range g.lines() {
+			for i := 0; i+1 < len(line); i++ {
+				l := segmentLength(line[i], line[i+1])
+				length += l
+				cx += (line[i][0] + line[i+1][0]) / 2 * l
+				cy += (line[i][1] + line[i+1][1]) / 2 * l
+			}
+		}
+		if length > 0 {
+			return cx / length, cy / length
+		}
+	}
+
+	// Points, or lines of zero length
+	for _, coord := range coords {
+		lon += coord[0]
+		lat += coord[1]
+	}
+	n := float64(len(coords))
+	return lon / n, lat / n
+}
+
+// RepresentativePoint returns a point guaranteed to lie on the geometry.
+//
+// For polygons a point-on-surface algorithm is used: a horizontal line is cast
+// through the middle of the bounding box and the midpoint of the widest span
+// inside the polygon is returned, so the point lands inside even for C-shaped
+// coastlines, and never in a hole. For lines it is the midpoint along the arc
+// length (not the middle vertex) of the longest part, and for points the first
+// point. Returns (0, 0) for empty geometry.
+//
+// Example:
+//
+//	lon, lat := feature.Geometry().RepresentativePoint()
+//	drawLabel(lon, lat, name)
+func (g Geometry) RepresentativePoint() (lon, lat float64) {
+	coords := g.Coordinates
+	if len(coords) == 0 {
+		return 0, 0
+	}
+
+	switch g.Type {
+	case GeometryTypePolygon:
+		if lon, lat, ok := pointOnSurface(coords); ok {
+			return lon, lat
+		}
+		return coords[0][0], coords[0][1]
+
+	case GeometryTypeLineString, GeometryTypeMultiLineString:
+		longest, longestLength := coords, -1.0
+		for _, line := range g.lines() {
+			length := 0.0
+			for i := 0; i+1 < len(line); i++ {
+				length += segmentLength(line[i], line[i+1])
+			}
+			if length > longestLength {
+				longest, longestLength = line, length
+			}
+		}
+		return lineMidpoint(longest)
+	}
+
+	return coords[0][0], coords[0][1]
+}
+
+// pointOnSurface finds an interior point of a polygon by scanline.
+//
+// The scanline is placed at the bounding box's middle latitude, nudged off any
+// vertex so crossings are counted unambiguously. Crossings with the outer ring
+// and its holes are paired inside/outside (even-odd), so spans inside a hole
+// are never chosen, and the midpoint of the widest inside span is returned.
+func pointOnSurface(coords [][]float64) (lon, lat float64, ok bool) {
+	rings := polygonRings(coords)
+	if len(rings) == 0 {
+		return 0, 0, false
+	}
+	minLat, maxLat := rings[0][0][1], rings[0][0][1]
+	for _, ring := range rings {
+		for _, coord := range ring {
+			minLat = math.Min(minLat, coord[1])
+			maxLat = math.Max(maxLat, coord[1])
+		}
+	}
+	if minLat == maxLat {
+		return 0, 0, false
+	}
+
+	// Avoid passing exactly through a vertex
+	y := (minLat + maxLat) / 2
+	for _, ring := range rings {
+		for _, coord := range ring {
+			if coord[1] == y {
+				y += (maxLat - minLat) * 1e-6
+			}
+		}
+	}
+
+	var crossings []float64
+	for _, ring := range rings {
+		for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+			a, b := ring[j], ring[i]
+			if (a[1] > y) != (b[1] > y) {
+				t := (y - a[1]) / (b[1] - a[1])
+				crossings = append(crossings, a[0]+t*(b[0]-a[0]))
+			}
+		}
+	}
+	sort.Float64s(crossings)
+
+	best := -1.0
+	for i := 0; i+1 < len(crossings); i += 2 {
+		if width := crossings[i+1] - crossings[i]; width > best {
+			best = width
+			lon = (crossings[i] + crossings[i+1]) / 2
+		}
+	}
+	if best < 0 {
+		return 0, 0, false
+	}
+	return lon, y, true
+}
+
+// polygonRings splits a polygon's coordinates into its rings: the outer ring
+// followed by any holes (see Geometry). Trailing coordinates too few to form a
+// ring are dropped.
+func polygonRings(coords [][]float64) [][][]float64 {
+	rings := parser.PolygonRings(coords)
+	if n := len(rings); n > 0 && len(rings[n-1]) < 3 {
+		rings = rings[:n-1]
+	}
+	return rings
+}
+
+// lineMidpoint returns the point halfway along a line's length
+func lineMidpoint(coords [][]float64) (lon, lat float64) {
+	total := 0.0
+	for i := 0; i+1 < len(coords); i++ {
+		total += segmentLength(coords[i], coords[i+1])
+	}
+
+	remaining := total / 2
+	for i := 0; i+1 < len(coords); i++ {
+		l := segmentLength(coords[i], coords[i+1])
+		if l > 0 && remaining <= l {
+			t := remaining / l
+			a, b := coords[i], coords[i+1]
+			return a[0] + t*(b[0]-a[0]), a[1] + t*(b[1]-a[1])
+		}
+		remaining -= l
+	}
+	return coords[0][0], coords[0][1]
+}
+
+// segmentLength returns the planar length of a segment in degrees
+func segmentLength(a, b []float64) float64 {
+	return math.Hypot(b[0]-a[0], b[1]-a[1])
+}
+
+// pointInPolygon reports whether a point lies inside a polygon: inside its outer
+// ring and outside every hole.
+func pointInPolygon(lon, lat float64, coords [][]float64) bool {
+	inside := false
+	for _, ring := range polygonRings(coords) {
+		if pointInRing(lon, lat, ring) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// pointInRing reports whether a point lies inside a ring (even-odd rule).
+// Coordinates are [lon, lat] like all Geometry coordinates.
+func pointInRing(lon, lat float64, ring [][]float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a[1] > lat) != (b[1] > lat) &&
+			lon < (b[0]-a[0])*(lat-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// IsValid reports whether the geometry can be rendered and queried reliably.
+//
+// Every coordinate must be a [lon, lat] or [lon, lat, depth] position within
+// geographic bounds. A polygon's outer ring and each of its holes must
+// additionally be closed, have at least three distinct vertices, not cross
+// itself and not be a sliver.
+// Self-intersecting rings break fill rendering and point-in-polygon tests;
+// they are usually produced by edges with wrong orientation in producer data.
+// When the geometry is invalid, reason describes the first problem found.
+//
+// Example:
+//
+//	if ok, reason := feature.Geometry().IsValid(); !ok {
+//	    log.Printf("skipping feature %d: %s", feature.ID(), reason)
+//	}
+func (g Geometry) IsValid() (bool, string) {
+	internal := parser.Geometry{Type: parser.GeometryType(g.Type), Coordinates: g.Coordinates}
+	err := parser.ValidateGeometry(&internal)
+	if err == nil {
+		err = parser.ValidatePolygon(&internal)
+	}
+	if err == nil {
+		return true, ""
+	}
+
+	var invalid *parser.ErrInvalidGeometry
+	if errors.As(err, &invalid) {
+		return false, invalid.Reason
+	}
+	return false, err.Error()
+}
+
+// Web Mercator (EPSG:3857) constants
+const (
+	webMercatorRadius = 6378137.0    // WGS-84 semi-major axis in meters
+	webMercatorMaxLat = 85.051128779 // Latitude where the projection becomes square
+)
+
+// ToWebMercator returns the geometry projected to Web Mercator (EPSG:3857).
+//
+// Coordinates become [x, y] in meters (depth, if present, is kept as the third
+// value). Latitudes beyond ±85.0511° are clamped to the projection limit. The
+// source geometry is not modified.
+//
+// Example:
+//
+//	projected := feature.Geometry().ToWebMercator()
+//	x, y := projected.Coordinates[0][0], projected.Coordinates[0][1]
+func (g Geometry) ToWebMercator() Geometry {
+	project := func(coords [][]float64) [][]float64 {
+		out := make([][]float64, len(coords))
+		for i, coord := range coords {
+			out[i] = make([]float64, len(coord))
+			copy(out[i], coord)
+			out[i][0], out[i][1] = webMercator(coord[0], coord[1])
+		}
+		return out
+	}
+
+	projected := Geometry{
+		Type:        g.Type,
+		Coordinates: project(g.Coordinates),
+	}
+	if g.Boundary != nil {
+		projected.Boundary = make([][][]float64, len(g.Boundary))
+		for i, line := range g.Boundary {
+			projected.Boundary[i] = project(line)
+		}
+	}
+	if g.Parts != nil {
+		projected.Parts = make([][][]float64, len(g.Parts))
+		for i, line := range g.Parts {
+			projected.Parts[i] = project(line)
+		}
+	}
+	return projected
+}
+
+// webMercator projects a WGS-84 position to EPSG:3857 meters
+func webMercator(lon, lat float64) (x, y float64) {
+	lat = math.Max(-webMercatorMaxLat, math.Min(webMercatorMaxLat, lat))
+	x = webMercatorRadius * lon * math.Pi / 180
+	y = webMercatorRadius * math.Log(math.Tan(math.Pi/4+lat*math.Pi/360))
+	return x, y
+}
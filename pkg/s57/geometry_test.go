@@ -0,0 +1,285 @@
+package s57
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/beetlebugorg/s57/internal/parser"
+)
+
+// TestRepresentativePoint tests label anchors for concave polygons and lines
+func TestRepresentativePoint(t *testing.T) {
+	// C-shaped polygon opening to the east; its centroid lies in the gap
+	cShape := Geometry{
+		Type: GeometryTypePolygon,
+		Coordinates: [][]float64{
+			{0, 0}, {10, 0}, {10, 2}, {2, 2}, {2, 8}, {10, 8}, {10, 10}, {0, 10}, {0, 0},
+		},
+	}
+
+	cLon, cLat := cShape.Centroid()
+	if pointInRing(cLon, cLat, cShape.Coordinates) {
+		t.Fatalf("Test polygon centroid (%v, %v) should fall outside the C", cLon, cLat)
+	}
+
+	lon, lat := cShape.RepresentativePoint()
+	if !pointInRing(lon, lat, cShape.Coordinates) {
+		t.Errorf("Representative point (%v, %v) is outside the polygon", lon, lat)
+	}
+
+	// Donut: outer ring and a centered hole, stored back to back as the
+	// parser writes them; the scanline's middle span lies in the hole
+	donut := Geometry{
+		Type: GeometryTypePolygon,
+		Coordinates: [][]float64{
+			{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0},
+			{2, 2}, {2, 8}, {8, 8}, {8, 2}, {2, 2},
+		},
+	}
+	hole := [][]float64{{2, 2}, {2, 8}, {8, 8}, {8, 2}, {2, 2}}
+	lon, lat = donut.RepresentativePoint()
+	if !pointInRing(lon, lat, donut.Coordinates[:5]) || pointInRing(lon, lat, hole) {
+		t.Errorf("Representative point (%v, %v) should be inside the donut, outside its hole", lon, lat)
+	}
+	if rings := polygonRings(donut.Coordinates); len(rings) != 2 {
+		t.Errorf("Expected 2 rings in donut, got %d", len(rings))
+	}
+
+	// Square centroid is its center
+	if lon, lat := square(0, 0).Centroid(); math.Abs(lon-0.5) > 1e-12 || math.Abs(lat-0.5) > 1e-12 {
+		t.Errorf("Expected square centroid (0.5, 0.5), got (%v, %v)", lon, lat)
+	}
+
+	// Line midpoint is measured along its length, not by vertex count
+	line := Geometry{
+		Type:        GeometryTypeLineString,
+		Coordinates: [][]float64{{0, 0}, {1, 0}, {2, 0}, {10, 0}},
+	}
+	if lon, lat := line.RepresentativePoint(); lon != 5 || lat != 0 {
+		t.Errorf("Expected line midpoint (5, 0), got (%v, %v)", lon, lat)
+	}
+
+	point := Geometry{Type: GeometryTypePoint, Coordinates: [][]float64{{-76.5, 38.9}}}
+	if lon, lat := point.RepresentativePoint(); lon != -76.5 || lat != 38.9 {
+		t.Errorf("Expected point itself, got (%v, %v)", lon, lat)
+	}
+
+	if lon, lat := (Geometry{}).RepresentativePoint(); lon != 0 || lat != 0 {
+		t.Errorf("Expected (0, 0) for empty geometry, got (%v, %v)", lon, lat)
+	}
+}
+
+// TestToWebMercator tests projection to EPSG:3857
+func TestToWebMercator(t *testing.T) {
+	g := Geometry{
+		Type: GeometryTypePoint,
+		Coordinates: [][]float64{
+			{10, 50, 12.5}, // Reference values from PROJ
+			{180, 89},      // Clamped at the projection limit
+			{0, 0},
+		},
+	}
+
+	projected := g.ToWebMercator()
+
+	want := [][]float64{
+		{1113194.9079327357, 6446275.841017158, 12.5},
+		{20037508.342789244, 20037508.342789244},
+		{0, 0},
+	}
+	for i, coord := range projected.Coordinates {
+		for j := range want[i] {
+			if math.Abs(coord[j]-want[i][j]) > 0.01 {
+				t.Errorf("Coordinate %d: expected %v, got %v", i, want[i], coord)
+				break
+			}
+		}
+	}
+
+	if g.Coordinates[0][0] != 10 || g.Coordinates[0][1] != 50 {
+		t.Error("ToWebMercator modified the source geometry")
+	}
+}
+
+// TestGeometryIsValid tests on-demand polygon validity checks
+func TestGeometryIsValid(t *testing.T) {
+	if ok, reason := square(0, 0).IsValid(); !ok {
+		t.Errorf("Expected unit square to be valid, got %q", reason)
+	}
+
+	bowtie := Geometry{
+		Type:        GeometryTypePolygon,
+		Coordinates: [][]float64{{0, 0}, {1, 1}, {1, 0}, {0, 1}, {0, 0}},
+	}
+	if ok, reason := bowtie.IsValid(); ok || !strings.Contains(reason, "self-intersects") {
+		t.Errorf("Expected bowtie to be invalid for self-intersection, got ok=%v reason=%q", ok, reason)
+	}
+
+	// Holes follow the outer ring and are validated as rings of their own
+	holed := Geometry{
+		Type: GeometryTypePolygon,
+		Coordinates: [][]float64{
+			{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0},
+			{1, 1}, {1, 3}, {3, 3}, {3, 1}, {1, 1},
+		},
+	}
+	if ok, reason := holed.IsValid(); !ok {
+		t.Errorf("Expected polygon with hole to be valid, got %q", reason)
+	}
+
+	// The same coordinates as a line are fine
+	line := Geometry{Type: GeometryTypeLineString, Coordinates: bowtie.Coordinates}
+	if ok, reason := line.IsValid(); !ok {
+		t.Errorf("Expected crossing line to be valid, got %q", reason)
+	}
+
+	outOfRange := Geometry{Type: GeometryTypePoint, Coordinates: [][]float64{{-761.2, 38.9}}}
+	if ok, _ := outOfRange.IsValid(); ok {
+		t.Error("Expected out-of-range point to be invalid")
+	}
+}
+
+// TestInvalidGeometryErrors tests that invalid geometry is reported with the
+// public error type, in warnings and in wrapped errors
+func TestInvalidGeometryErrors(t *testing.T) {
+	var reported []error
+	opts := DefaultParseOptions()
+	opts.ValidatePolygons = true
+	opts.OnWarning = func(err error) { reported = append(reported, err) }
+	chart, err := NewParser().ParseWithOptions(testChartPath, opts)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	for name, warnings := range map[string][]error{"OnWarning": reported, "Warnings": chart.Warnings()} {
+		found := false
+		for _, w := range warnings {
+			var invalid *ErrInvalidGeometry
+			if errors.As(w, &invalid) {
+				found = true
+				if !invalid.HasType || invalid.Type != GeometryTypePolygon || invalid.FeatureID == 0 || invalid.Reason == "" {
+					t.Errorf("%s: unexpected invalid geometry %+v", name, invalid)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected an *ErrInvalidGeometry for the test chart's invalid polygons", name)
+		}
+	}
+
+	// Wrapping is kept, and so is the message
+	internal := fmt.Errorf("feature 7: %w", &parser.ErrInvalidGeometry{
+		FeatureID: 7, Type: parser.GeometryTypeLineString, HasType: true, Reason: "too short",
+	})
+	converted := publicError(internal)
+	var invalid *ErrInvalidGeometry
+	if !errors.As(converted, &invalid) || !invalid.HasType || invalid.Type != GeometryTypeLineString || invalid.FeatureID != 7 {
+		t.Errorf("Expected public ErrInvalidGeometry, got %#v", converted)
+	}
+
+	// A Point error names its type; an error of unknown type names none
+	point := publicError(&parser.ErrInvalidGeometry{Type: parser.GeometryTypePoint, HasType: true, Reason: "bad"})
+	if !errors.As(point, &invalid) || !invalid.HasType || invalid.Type != GeometryTypePoint {
+		t.Errorf("Expected known Point type, got %#v", point)
+	}
+	if point.Error() != "invalid geometry (Point): bad" {
+		t.Errorf("Unexpected Point error message %q", point.Error())
+	}
+	unknown := publicError(&parser.ErrInvalidGeometry{Reason: "bad"})
+	if !errors.As(unknown, &invalid) || invalid.HasType {
+		t.Errorf("Expected unknown type, got %#v", unknown)
+	}
+	if unknown.Error() != "invalid geometry: bad" {
+		t.Errorf("Unexpected unknown-type error message %q", unknown.Error())
+	}
+	if converted.Error() != internal.Error() {
+		t.Errorf("Expected message %q, got %q", internal.Error(), converted.Error())
+	}
+	if other := errors.New("unrelated"); publicError(other) != other {
+		t.Error("Expected unrelated errors to be returned unchanged")
+	}
+}
+
+// TestGeometryDimensions tests SOUNDG reports 3 dimensions and DEPCNT 2
+func TestGeometryDimensions(t *testing.T) {
+	chart, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	found := map[string]bool{}
+	for _, f := range chart.Features() {
+		geom := f.Geometry()
+		var want int
+		switch f.ObjectClass() {
+		case "SOUNDG":
+			want = 3
+		case "DEPCNT":
+			want = 2
+		default:
+			continue
+		}
+		if len(geom.Coordinates) == 0 {
+			continue // Spatial records removed by an update
+		}
+		found[f.ObjectClass()] = true
+		if got := geom.Dimensions(); got != want {
+			t.Errorf("%s %d: Dimensions() = %d, want %d", f.ObjectClass(), f.ID(), got, want)
+		}
+		for _, coord := range geom.Coordinates {
+			if len(coord) != want {
+				t.Errorf("%s %d: coordinate %v is not %dD", f.ObjectClass(), f.ID(), coord, want)
+				break
+			}
+		}
+	}
+	if !found["SOUNDG"] || !found["DEPCNT"] {
+		t.Fatalf("Test chart lacks SOUNDG or DEPCNT features: %v", found)
+	}
+
+	if got := (Geometry{Type: GeometryTypePoint}).Dimensions(); got != 2 {
+		t.Errorf("Empty geometry Dimensions() = %d, want 2", got)
+	}
+}
+
+// TestMultiLineStringHelpers tests that geometry helpers treat the parts of a
+// MultiLineString as separate lines
+func TestMultiLineStringHelpers(t *testing.T) {
+	parts := [][][]float64{
+		{{0, 0}, {4, 0}},
+		{{10, 10}, {10, 11}},
+	}
+	multi := Geometry{
+		Type:        GeometryTypeMultiLineString,
+		Coordinates: append(append([][]float64{}, parts[0]...), parts[1]...),
+		Parts:       parts,
+	}
+
+	// Midpoint of the longest part, not of the gap between parts
+	if lon, lat := multi.RepresentativePoint(); lon != 2 || lat != 0 {
+		t.Errorf("RepresentativePoint = (%v, %v), expected (2, 0)", lon, lat)
+	}
+
+	// Length-weighted over the parts only
+	lon, lat := multi.Centroid()
+	if math.Abs(lon-3.6) > 1e-9 || math.Abs(lat-2.1) > 1e-9 {
+		t.Errorf("Centroid = (%v, %v), expected (3.6, 2.1)", lon, lat)
+	}
+
+	// A point on the segment joining the parts is far from the geometry
+	if d := geometryDistance(multi, 7, 5, 1); d < 3 {
+		t.Errorf("Distance across the gap = %v, expected at least 3", d)
+	}
+
+	projected := multi.ToWebMercator()
+	if len(projected.Parts) != 2 || projected.Parts[1][0][0] == 10 {
+		t.Errorf("Expected projected parts, got %v", projected.Parts)
+	}
+
+	if !(ObjectClassMetadata{Primitives: []GeometryType{GeometryTypeLineString}}).Allows(GeometryTypeMultiLineString) {
+		t.Error("Expected a line class to allow MultiLineString")
+	}
+}
@@ -0,0 +1,170 @@
+package s57
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// hazardClasses are object classes that are dangers to navigation in their own
+// right, regardless of whether a depth is charted for them.
+var hazardClasses = map[string]bool{
+	"OBSTRN": true, // Obstruction
+	"WRECKS": true, // Wreck
+	"UWTROC": true, // Underwater/awash rock
+}
+
+// WaterLevel is the water level effect (WATLEV) of an object: how it stands
+// relative to the water surface as the tide rises and falls. S-52 symbolizes
+// rocks, obstructions and wrecks (UWTROC, OBSTRN, WRECKS) by it.
+//
+// Reference: S-57 Appendix A Chapter 2, attribute WATLEV.
+type WaterLevel int
+
+const (
+	// WaterLevelPartlySubmerged - partly submerged at high water.
+	WaterLevelPartlySubmerged WaterLevel = 1
+
+	// WaterLevelAlwaysDry - always above the water surface.
+	WaterLevelAlwaysDry WaterLevel = 2
+
+	// WaterLevelAlwaysUnderwater - always under water (submerged).
+	WaterLevelAlwaysUnderwater WaterLevel = 3
+
+	// WaterLevelCoversAndUncovers - submerged at high water and dry at low
+	// water (intertidal).
+	WaterLevelCoversAndUncovers WaterLevel = 4
+
+	// WaterLevelAwash - at about the level of the water surface at low water.
+	WaterLevelAwash WaterLevel = 5
+
+	// WaterLevelSubjectToFlooding - subject to inundation or flooding.
+	WaterLevelSubjectToFlooding WaterLevel = 6
+
+	// WaterLevelFloating - floating on the water surface.
+	WaterLevelFloating WaterLevel = 7
+)
+
+// String returns the human-readable name of the water level effect.
+func (w WaterLevel) String() string {
+	switch w {
+	case WaterLevelPartlySubmerged:
+		return "PartlySubmerged"
+	case WaterLevelAlwaysDry:
+		return "AlwaysDry"
+	case WaterLevelAlwaysUnderwater:
+		return "AlwaysUnderwater"
+	case WaterLevelCoversAndUncovers:
+		return "CoversAndUncovers"
+	case WaterLevelAwash:
+		return "Awash"
+	case WaterLevelSubjectToFlooding:
+		return "SubjectToFlooding"
+	case WaterLevelFloating:
+		return "Floating"
+	default:
+		return "Unknown"
+	}
+}
+
+// WaterLevel returns the feature's water level effect (WATLEV).
+//
+// Returns false if the feature has no WATLEV attribute or its value is not
+// one of the codes defined by S-57.
+//
+// Example:
+//
+//	if level, ok := feature.WaterLevel(); ok && level == s57.WaterLevelCoversAndUncovers {
+//	    drawIntertidal(feature)
+//	}
+func (f *Feature) WaterLevel() (WaterLevel, bool) {
+	value, ok := attributeFloat(*f, "WATLEV")
+	if !ok || value != math.Trunc(value) || value < 1 || value > 7 {
+		return 0, false
+	}
+	return WaterLevel(value), true
+}
+
+// Hazards returns charted dangers within bounds for a vessel with the given draft.
+//
+// The result contains:
+//   - OBSTRN, WRECKS and UWTROC features whose VALSOU is less than or equal to
+//     the draft, or whose depth is unknown (no VALSOU). Per S-52 safety rules an
+//     obstruction of unknown depth is always treated as a danger.
+//   - OBSTRN, WRECKS and UWTROC features that are dry, awash or cover and
+//     uncover (WATLEV), which are obstructions even though they are not a
+//     depth hazard.
+//   - Any other feature whose VALSOU or DRVAL1 is less than or equal to the
+//     draft (e.g. shallow DEPARE areas).
+//
+// Example:
+//
+//	dangers := chart.Hazards(viewport, 2.5)
+//	for _, hazard := range dangers {
+//	    warn(hazard)
+//	}
+func (c *Chart) Hazards(bounds Bounds, draftMeters float64) []Feature {
+	candidates := c.FeaturesInBounds(bounds)
+
+	result := make([]Feature, 0)
+	for _, feature := range candidates {
+		if isHazard(feature, draftMeters) {
+			result = append(result, feature)
+		}
+	}
+	return result
+}
+
+// isHazard reports whether a feature is a danger for the given draft.
+func isHazard(f Feature, draftMeters float64) bool {
+	if hazardClasses[f.objectClass] {
+		// Dry, awash or intertidal obstructions are always dangers
+		if level, ok := f.WaterLevel(); ok {
+			switch level {
+			case WaterLevelPartlySubmerged, WaterLevelAlwaysDry, WaterLevelCoversAndUncovers, WaterLevelAwash:
+				return true
+			}
+		}
+
+		// Unknown depth is treated as dangerous (S-52)
+		depth, ok := attributeFloat(f, "VALSOU")
+		if !ok {
+			return true
+		}
+		return depth <= draftMeters
+	}
+
+	if depth, ok := attributeFloat(f, "VALSOU"); ok {
+		return depth <= draftMeters
+	}
+	if depth, ok := attributeFloat(f, "DRVAL1"); ok {
+		return depth <= draftMeters
+	}
+	return false
+}
+
+// attributeFloat returns a numeric attribute value.
+//
+// Attribute values are stored as the raw ATVL strings from the ATTF field, so
+// they are parsed here. Returns false if the attribute is missing or not numeric.
+func attributeFloat(f Feature, name string) (float64, bool) {
+	val, ok := f.attributes[name]
+	if !ok {
+		return 0, false
+	}
+
+	switch v := val.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, false
+		}
+		return parsed, true
+	default:
+		return 0, false
+	}
+}
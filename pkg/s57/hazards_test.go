@@ -0,0 +1,107 @@
+package s57
+
+import (
+	"testing"
+)
+
+// newTestChart builds an indexed chart from hand-made features
+func newTestChart(features []Feature) *Chart {
+	chart := &Chart{features: features}
+	chart.buildSpatialIndex()
+	return chart
+}
+
+// TestHazards tests the safe-water hazard query
+func TestHazards(t *testing.T) {
+	point := func(lon, lat float64) Geometry {
+		return Geometry{Type: GeometryTypePoint, Coordinates: [][]float64{{lon, lat}}}
+	}
+
+	chart := newTestChart([]Feature{
+		{id: 1, objectClass: "OBSTRN", geometry: point(-76.1, 38.1),
+			attributes: map[string]interface{}{}}, // Unknown depth
+		{id: 2, objectClass: "WRECKS", geometry: point(-76.2, 38.2),
+			attributes: map[string]interface{}{"VALSOU": "12.0"}}, // Deep wreck
+		{id: 3, objectClass: "UWTROC", geometry: point(-76.3, 38.3),
+			attributes: map[string]interface{}{"VALSOU": "1.5"}}, // Shallow rock
+		{id: 4, objectClass: "OBSTRN", geometry: point(-76.4, 38.4),
+			attributes: map[string]interface{}{"VALSOU": "20", "WATLEV": "2"}}, // Always dry
+		{id: 5, objectClass: "DEPARE", geometry: point(-76.5, 38.5),
+			attributes: map[string]interface{}{"DRVAL1": "0", "DRVAL2": "2"}}, // Shallow area
+		{id: 6, objectClass: "DEPARE", geometry: point(-76.6, 38.6),
+			attributes: map[string]interface{}{"DRVAL1": "10", "DRVAL2": "20"}}, // Deep area
+		{id: 7, objectClass: "LIGHTS", geometry: point(-76.7, 38.7),
+			attributes: map[string]interface{}{}},
+	})
+
+	viewport := Bounds{MinLon: -77, MaxLon: -76, MinLat: 38, MaxLat: 39}
+	hazards := chart.Hazards(viewport, 3.0)
+
+	got := make(map[int64]bool)
+	for _, f := range hazards {
+		got[f.ID()] = true
+	}
+
+	expected := map[int64]bool{1: true, 3: true, 4: true, 5: true}
+	for id := range expected {
+		if !got[id] {
+			t.Errorf("Expected feature %d to be reported as a hazard", id)
+		}
+	}
+	for id := range got {
+		if !expected[id] {
+			t.Errorf("Feature %d should not be reported as a hazard", id)
+		}
+	}
+
+	// An obstruction with unknown depth is a hazard for any draft
+	if hazards := chart.Hazards(viewport, 0); len(hazards) == 0 || !containsID(hazards, 1) {
+		t.Error("Obstruction with unknown depth should always be returned")
+	}
+}
+
+// containsID reports whether a feature with the given ID is in the slice
+func containsID(features []Feature, id int64) bool {
+	for _, f := range features {
+		if f.ID() == id {
+			return true
+		}
+	}
+	return false
+}
+
+// TestWaterLevel tests decoding of the WATLEV attribute
+func TestWaterLevel(t *testing.T) {
+	tests := []struct {
+		watlev   interface{}
+		expected WaterLevel
+		ok       bool
+	}{
+		{"1", WaterLevelPartlySubmerged, true},
+		{"2", WaterLevelAlwaysDry, true},
+		{"3", WaterLevelAlwaysUnderwater, true},
+		{"4", WaterLevelCoversAndUncovers, true},
+		{"5", WaterLevelAwash, true},
+		{"7", WaterLevelFloating, true},
+		{4, WaterLevelCoversAndUncovers, true},
+		{"9", 0, false},
+		{"", 0, false},
+		{nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		attributes := map[string]interface{}{}
+		if tt.watlev != nil {
+			attributes["WATLEV"] = tt.watlev
+		}
+		f := Feature{objectClass: "UWTROC", attributes: attributes}
+		level, ok := f.WaterLevel()
+		if level != tt.expected || ok != tt.ok {
+			t.Errorf("WATLEV %v: got %v, %v; expected %v, %v", tt.watlev, level, ok, tt.expected, tt.ok)
+		}
+	}
+
+	if WaterLevelCoversAndUncovers.String() != "CoversAndUncovers" {
+		t.Errorf("Unexpected name %q", WaterLevelCoversAndUncovers.String())
+	}
+}
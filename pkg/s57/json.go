@@ -0,0 +1,171 @@
+package s57
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// featureJSON is the serialized form of a Feature
+type featureJSON struct {
+	FOID         featureIDJSON          `json:"foid"`
+	ObjectClass  string                 `json:"objectClass"`
+	Group        int                    `json:"group"`
+	GeometryType string                 `json:"geometryType"`
+	Coordinates  [][]float64            `json:"coordinates"`
+	Boundary     [][][]float64          `json:"boundary,omitempty"`
+	Parts        [][][]float64          `json:"parts,omitempty"`
+	Attributes   map[string]interface{} `json:"attributes"`
+}
+
+// featureIDJSON is the serialized form of a FeatureID
+type featureIDJSON struct {
+	Agency      uint16 `json:"agency"`
+	Number      uint32 `json:"number"`
+	Subdivision uint16 `json:"subdivision"`
+}
+
+// MarshalJSON encodes the feature as a JSON object with a stable schema:
+//
+//	{
+//	  "foid": {"agency": 550, "number": 1234, "subdivision": 1},
+//	  "objectClass": "DEPARE",
+//	  "group": 1,
+//	  "geometryType": "Polygon",
+//	  "coordinates": [[-76.5, 38.9], ...],
+//	  "attributes": {"DRVAL1": "5", "DRVAL2": "10"}
+//	}
+//
+// "boundary" and "parts" are added when the geometry has them (see Geometry).
+// Attribute values keep their types: strings as decoded from the cell,
+// numbers, and lists of numbers. Text that is not valid UTF-8 is read as
+// ISO 8859-1, the encoding of S-57 lexical level 1, so a degree sign in an
+// INFORM value is written as "°". The derived DEPTHS attribute of SOUNDG
+// features is not written; it is derived again from the coordinates.
+//
+// Example:
+//
+//	// Cache the parsed features of a chart
+//	data, err := json.Marshal(chart.Features())
+func (f Feature) MarshalJSON() ([]byte, error) {
+	attributes := make(map[string]interface{}, len(f.attributes))
+	for name, value := range f.attributes {
+		if text, ok := value.(string); ok && !utf8.ValidString(text) {
+			value = latin1ToUTF8(text)
+		}
+		attributes[name] = value
+	}
+	coordinates := f.geometry.Coordinates
+	if coordinates == nil {
+		coordinates = [][]float64{}
+	}
+	return json.Marshal(featureJSON{
+		FOID:         featureIDJSON{Agency: f.agency, Number: uint32(f.id), Subdivision: f.subdivision},
+		ObjectClass:  f.objectClass,
+		Group:        f.group,
+		GeometryType: f.geometry.Type.String(),
+		Coordinates:  coordinates,
+		Boundary:     f.geometry.Boundary,
+		Parts:        f.geometry.Parts,
+		Attributes:   attributes,
+	})
+}
+
+// UnmarshalJSON decodes a feature written by MarshalJSON.
+//
+// Numeric attribute values are restored as int when whole and float64
+// otherwise; lists of whole numbers become []int and other numeric lists
+// []float64. Returns an error for an unknown geometryType.
+func (f *Feature) UnmarshalJSON(data []byte) error {
+	var decoded featureJSON
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return err
+	}
+
+	geometryType, ok := parseGeometryType(decoded.GeometryType)
+	if !ok {
+		return fmt.Errorf("unknown geometry type %q", decoded.GeometryType)
+	}
+
+	attributes := make(map[string]interface{}, len(decoded.Attributes))
+	for name, value := range decoded.Attributes {
+		attributes[name] = decodeAttributeValue(value)
+	}
+
+	*f = Feature{
+		id:          int64(decoded.FOID.Number),
+		agency:      decoded.FOID.Agency,
+		subdivision: decoded.FOID.Subdivision,
+		objectClass: decoded.ObjectClass,
+		group:       decoded.Group,
+		geometry: Geometry{
+			Type:        geometryType,
+			Coordinates: decoded.Coordinates,
+			Boundary:    decoded.Boundary,
+			Parts:       decoded.Parts,
+		},
+		attributes: attributes,
+	}
+	return nil
+}
+
+// parseGeometryType returns the geometry type named by GeometryType.String
+func parseGeometryType(name string) (GeometryType, bool) {
+	for _, t := range []GeometryType{GeometryTypePoint, GeometryTypeLineString, GeometryTypePolygon, GeometryTypeMultiLineString} {
+		if t.String() == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// decodeAttributeValue converts a JSON-decoded attribute value (decoded with
+// UseNumber) to int, float64, []int or []float64 where it is numeric
+func decodeAttributeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return int(n)
+		}
+		if n, err := v.Float64(); err == nil {
+			return n
+		}
+	case []interface{}:
+		ints := make([]int, 0, len(v))
+		floats := make([]float64, 0, len(v))
+		for _, item := range v {
+			number, ok := item.(json.Number)
+			if !ok {
+				return v
+			}
+			n, err := number.Float64()
+			if err != nil {
+				return v
+			}
+			floats = append(floats, n)
+			if i, err := number.Int64(); err == nil && ints != nil {
+				ints = append(ints, int(i))
+			} else {
+				ints = nil
+			}
+		}
+		if ints != nil {
+			return ints
+		}
+		return floats
+	}
+	return value
+}
+
+// latin1ToUTF8 converts ISO 8859-1 text to UTF-8. Every byte is one
+// character, and Latin-1 code points equal their Unicode code points.
+func latin1ToUTF8(text string) string {
+	runes := make([]rune, len(text))
+	for i := 0; i < len(text); i++ {
+		runes[i] = rune(text[i])
+	}
+	return string(runes)
+}
@@ -0,0 +1,72 @@
+package s57
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+// TestFeatureJSONRoundTrip tests that features survive a JSON round trip
+func TestFeatureJSONRoundTrip(t *testing.T) {
+	chart, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	features := chart.Features()
+	data, err := json.Marshal(features)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded []Feature
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if len(decoded) != len(features) {
+		t.Fatalf("Expected %d features, got %d", len(features), len(decoded))
+	}
+	for i := range features {
+		if text, ok := features[i].attributes["INFORM"].(string); ok && !utf8.ValidString(text) {
+			// Latin-1 text comes back as UTF-8
+			if inform := decoded[i].attributes["INFORM"].(string); !utf8.ValidString(inform) || len(inform) <= len(text) {
+				t.Errorf("Feature %d: INFORM not converted from Latin-1: %q", i, inform)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(decoded[i], features[i]) {
+			t.Fatalf("Feature %d differs after round trip:\n%+v\n%+v", i, features[i], decoded[i])
+		}
+	}
+
+	// Typed attribute values keep their types
+	typed := Feature{
+		id:          7,
+		agency:      550,
+		subdivision: 2,
+		objectClass: "LIGHTS",
+		geometry:    Geometry{Type: GeometryTypePoint, Coordinates: [][]float64{{-76.5, 38.9}}},
+		attributes: map[string]interface{}{
+			"OBJNAM": "Light",
+			"HEIGHT": 12.5,
+			"SIGPER": 4,
+			"COLOUR": []int{1, 3},
+			"VALNMR": []float64{2.5, 7},
+		},
+	}
+	data, err = json.Marshal(typed)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var got Feature
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, typed) {
+		t.Errorf("Typed feature differs after round trip:\n%+v\n%+v", typed, got)
+	}
+
+	if err := json.Unmarshal([]byte(`{"geometryType":"Circle"}`), &got); err == nil {
+		t.Error("Expected an error for an unknown geometry type")
+	}
+}
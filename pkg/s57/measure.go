@@ -0,0 +1,414 @@
+package s57
+
+import (
+	"math"
+
+	"github.com/beetlebugorg/s57/internal/parser"
+)
+
+// earthRadius is the mean radius of the WGS-84 ellipsoid in meters, used for
+// great-circle distances
+const earthRadius = 6371008.8
+
+// Perimeter returns the length of a polygon's boundary in meters.
+//
+// Segment lengths are great-circle (haversine) distances, accurate to about
+// 0.5% at chart scales. The perimeter is the sum of the outer ring and every
+// hole, each including its closing segment. Returns 0 for points and lines.
+//
+// Use it with BoundingCircle to scale S-52 area fill patterns and centered
+// symbols.
+func (g Geometry) Perimeter() float64 {
+	if g.Type != GeometryTypePolygon || len(g.Coordinates) < 2 {
+		return 0
+	}
+
+	perimeter := 0.0
+	for _, ring := range polygonRings(g.Coordinates) {
+		for i := 0; i+1 < len(ring); i++ {
+			perimeter += haversineDistance(ring[i][0], ring[i][1], ring[i+1][0], ring[i+1][1])
+		}
+
+		// Rings are normally closed; measure the closing segment if one is not
+		first, last := ring[0], ring[len(ring)-1]
+		if first[0] != last[0] || first[1] != last[1] {
+			perimeter += haversineDistance(last[0], last[1], first[0], first[1])
+		}
+	}
+	return perimeter
+}
+
+// BoundingCircle returns the smallest circle enclosing a polygon's ring: its
+// center and its radius in meters.
+//
+// The circle is found in a local equirectangular projection around the ring,
+// then its radius is widened to the largest great-circle distance from the
+// center to a vertex, so every vertex lies within radiusMeters. Returns zeros
+// for points and lines.
+//
+// Example:
+//
+//	lon, lat, radius := feature.Geometry().BoundingCircle()
+//	if radius > symbolSizeMeters {
+//	    drawSymbol(lon, lat)
+//	}
+func (g Geometry) BoundingCircle() (lon, lat, radiusMeters float64) {
+	if g.Type != GeometryTypePolygon || len(g.Coordinates) == 0 {
+		return 0, 0, 0
+	}
+
+	// Project to a plane where a degree of longitude and latitude are equal
+	// lengths at the ring's middle latitude
+	minLat, maxLat := g.Coordinates[0][1], g.Coordinates[0][1]
+	for _, coord := range g.Coordinates {
+		minLat = math.Min(minLat, coord[1])
+		maxLat = math.Max(maxLat, coord[1])
+	}
+	lonScale := math.Max(math.Cos((minLat+maxLat)/2*math.Pi/180), 0.01)
+
+	points := make([][2]float64, len(g.Coordinates))
+	for i, coord := range g.Coordinates {
+		points[i] = [2]float64{coord[0] * lonScale, coord[1]}
+	}
+	center, _ := smallestEnclosingCircle(points)
+	lon, lat = center[0]/lonScale, center[1]
+
+	for _, coord := range g.Coordinates {
+		radiusMeters = math.Max(radiusMeters, haversineDistance(lon, lat, coord[0], coord[1]))
+	}
+	return lon, lat, radiusMeters
+}
+
+// Densify returns the geometry with intermediate points inserted along the
+// great circle of every segment longer than maxSegmentMeters, so no segment
+// exceeds that length.
+//
+// Segments are stored as straight lines in longitude/latitude, which bow away
+// from the shortest path over long distances; densified lines follow the great
+// circle when drawn on a globe or in any projection. Depth (the third value of
+// 3D coordinates) is interpolated linearly along the segment. Points are
+// returned unchanged, as is every geometry when maxSegmentMeters is not
+// positive. Polygon boundaries and MultiLineString parts are densified too.
+// The source geometry is not modified.
+//
+// Example:
+//
+//	leg := feature.Geometry().Densify(10000) // At most 10 km between vertices
+func (g Geometry) Densify(maxSegmentMeters float64) Geometry {
+	if g.Type == GeometryTypePoint || maxSegmentMeters <= 0 {
+		return g
+	}
+
+	densified := Geometry{
+		Type:        g.Type,
+		Coordinates: densifyLine(g.Coordinates, maxSegmentMeters),
+	}
+	if g.Boundary != nil {
+		densified.Boundary = make([][][]float64, len(g.Boundary))
+		for i, line := range g.Boundary {
+			densified.Boundary[i] = densifyLine(line, maxSegmentMeters)
+		}
+	}
+	if g.Parts != nil {
+		// Coordinates holds the parts back to back; densify them separately
+		// so no points are inserted across the gaps between parts
+		densified.Parts = make([][][]float64, len(g.Parts))
+		densified.Coordinates = nil
+		for i, line := range g.Parts {
+			densified.Parts[i] = densifyLine(line, maxSegmentMeters)
+			densified.Coordinates = append(densified.Coordinates, densified.Parts[i]...)
+		}
+	}
+	return densified
+}
+
+// densifyLine returns a copy of coords with great-circle points inserted so
+// that no segment is longer than maxSegmentMeters
+func densifyLine(coords [][]float64, maxSegmentMeters float64) [][]float64 {
+	out := make([][]float64, 0, len(coords))
+	for i, coord := range coords {
+		if i > 0 {
+			prev := coords[i-1]
+			distance := haversineDistance(prev[0], prev[1], coord[0], coord[1])
+			steps := int(math.Ceil(distance / maxSegmentMeters))
+			for step := 1; step < steps; step++ {
+				out = append(out, interpolateGreatCircle(prev, coord, float64(step)/float64(steps)))
+			}
+		}
+		out = append(out, append([]float64(nil), coord...))
+	}
+	return out
+}
+
+// interpolateGreatCircle returns the point at fraction f of the way from a to
+// b along the great circle, with depth interpolated linearly when both
+// coordinates carry one
+func interpolateGreatCircle(a, b []float64, f float64) []float64 {
+	const toRad = math.Pi / 180
+	lon1, lat1 := a[0]*toRad, a[1]*toRad
+	lon2, lat2 := b[0]*toRad, b[1]*toRad
+
+	// Unit vectors of both ends, and the angle between them
+	x1, y1, z1 := math.Cos(lat1)*math.Cos(lon1), math.Cos(lat1)*math.Sin(lon1), math.Sin(lat1)
+	x2, y2, z2 := math.Cos(lat2)*math.Cos(lon2), math.Cos(lat2)*math.Sin(lon2), math.Sin(lat2)
+	angle := math.Acos(math.Max(-1, math.Min(1, x1*x2+y1*y2+z1*z2)))
+
+	point := []float64{a[0] + (b[0]-a[0])*f, a[1] + (b[1]-a[1])*f}
+	if sin := math.Sin(angle); sin > 1e-12 {
+		wa, wb := math.Sin((1-f)*angle)/sin, math.Sin(f*angle)/sin
+		x, y, z := wa*x1+wb*x2, wa*y1+wb*y2, wa*z1+wb*z2
+		point[0] = math.Atan2(y, x) / toRad
+		point[1] = math.Atan2(z, math.Hypot(x, y)) / toRad
+	}
+	if len(a) > 2 && len(b) > 2 {
+		point = append(point, a[2]+(b[2]-a[2])*f)
+	}
+	return point
+}
+
+// Simplify returns the geometry with vertices removed by the Douglas–Peucker
+// algorithm, so that no removed vertex lies farther than toleranceMeters from
+// the simplified line.
+//
+// Lines keep their endpoints. A polygon's outer ring and each hole are
+// simplified separately: each stays closed and keeps at least three distinct
+// vertices (four coordinates), and if simplifying would make it cross itself,
+// the tolerance is reduced until it does not, and the ring is left unchanged if
+// that fails. Polygon boundaries and MultiLineString parts are simplified
+// separately too. Kept vertices are copied unchanged, depth included. Points
+// are returned unchanged, as is every geometry when toleranceMeters is not
+// positive. The source geometry is not modified.
+//
+// Example:
+//
+//	// About one pixel at zoom 8
+//	coastline := feature.Geometry().Simplify(600)
+func (g Geometry) Simplify(toleranceMeters float64) Geometry {
+	if g.Type == GeometryTypePoint || toleranceMeters <= 0 {
+		return g
+	}
+
+	simplified := Geometry{Type: g.Type}
+	if g.Type == GeometryTypePolygon {
+		for _, ring := range polygonRings(g.Coordinates) {
+			simplified.Coordinates = append(simplified.Coordinates, simplifyRing(ring, toleranceMeters)...)
+		}
+	} else {
+		simplified.Coordinates = simplifyLine(g.Coordinates, toleranceMeters)
+	}
+	if g.Boundary != nil {
+		simplified.Boundary = make([][][]float64, len(g.Boundary))
+		for i, line := range g.Boundary {
+			simplified.Boundary[i] = simplifyLine(line, toleranceMeters)
+		}
+	}
+	if g.Parts != nil {
+		simplified.Parts = make([][][]float64, len(g.Parts))
+		simplified.Coordinates = nil
+		for i, line := range g.Parts {
+			simplified.Parts[i] = simplifyLine(line, toleranceMeters)
+			simplified.Coordinates = append(simplified.Coordinates, simplified.Parts[i]...)
+		}
+	}
+	return simplified
+}
+
+// simplifyLine returns a copy of the vertices of coords kept by Douglas–Peucker
+// at the given tolerance, always including both endpoints
+func simplifyLine(coords [][]float64, toleranceMeters float64) [][]float64 {
+	if len(coords) < 3 {
+		return copyCoords(coords, nil)
+	}
+	points := projectLocal(coords)
+	keep := make([]bool, len(coords))
+	keep[0], keep[len(coords)-1] = true, true
+	douglasPeucker(points, 0, len(coords)-1, toleranceMeters, keep)
+	return copyCoords(coords, keep)
+}
+
+// simplifyRing returns a copy of a polygon ring simplified by Douglas–Peucker.
+// The ring is split at its first vertex and the vertex farthest from it, and
+// both halves are simplified like lines. The result keeps at least four
+// coordinates and is never self-intersecting unless the source ring is.
+func simplifyRing(ring [][]float64, toleranceMeters float64) [][]float64 {
+	if len(ring) <= 4 {
+		return copyCoords(ring, nil)
+	}
+	points := projectLocal(ring)
+	last := len(ring) - 1
+
+	split := 1
+	for i := 2; i < last; i++ {
+		if math.Hypot(points[i][0]-points[0][0], points[i][1]-points[0][1]) >
+			math.Hypot(points[split][0]-points[0][0], points[split][1]-points[0][1]) {
+			split = i
+		}
+	}
+
+	for tolerance, attempt := toleranceMeters, 0; attempt < 8; tolerance, attempt = tolerance/2, attempt+1 {
+		keep := make([]bool, len(ring))
+		keep[0], keep[split], keep[last] = true, true, true
+		douglasPeucker(points, 0, split, tolerance, keep)
+		douglasPeucker(points, split, last, tolerance, keep)
+
+		// Three coordinates (two distinct vertices) are no ring: keep the
+		// vertex farthest from the chord as the third
+		kept := 0
+		for _, k := range keep {
+			if k {
+				kept++
+			}
+		}
+		if kept < 4 {
+			i, d1 := farthestFromSegment(points, 0, split)
+			j, d2 := farthestFromSegment(points, split, last)
+			if d2 > d1 {
+				i = j
+			}
+			keep[i] = true
+		}
+
+		simplified := copyCoords(ring, keep)
+		candidate := parser.Geometry{Type: parser.GeometryTypePolygon, Coordinates: simplified}
+		if parser.ValidatePolygon(&candidate) == nil {
+			return simplified
+		}
+	}
+	return copyCoords(ring, nil)
+}
+
+// douglasPeucker marks in keep the vertices between first and last that
+// Douglas–Peucker retains at the given tolerance. Points are in meters.
+func douglasPeucker(points [][2]float64, first, last int, toleranceMeters float64, keep []bool) {
+	stack := [][2]int{{first, last}}
+	for len(stack) > 0 {
+		span := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		i, distance := farthestFromSegment(points, span[0], span[1])
+		if i < 0 || distance <= toleranceMeters {
+			continue
+		}
+		keep[i] = true
+		stack = append(stack, [2]int{span[0], i}, [2]int{i, span[1]})
+	}
+}
+
+// farthestFromSegment returns the index of the point strictly between first
+// and last farthest from the segment joining them, and its distance. Returns
+// -1 when there is no point in between.
+func farthestFromSegment(points [][2]float64, first, last int) (int, float64) {
+	a, b := points[first], points[last]
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	lengthSq := dx*dx + dy*dy
+
+	farthest, maxDistance := -1, -1.0
+	for i := first + 1; i < last; i++ {
+		p := points[i]
+		t := 0.0
+		if lengthSq > 0 {
+			t = math.Max(0, math.Min(1, ((p[0]-a[0])*dx+(p[1]-a[1])*dy)/lengthSq))
+		}
+		distance := math.Hypot(p[0]-(a[0]+t*dx), p[1]-(a[1]+t*dy))
+		if distance > maxDistance {
+			farthest, maxDistance = i, distance
+		}
+	}
+	return farthest, maxDistance
+}
+
+// projectLocal projects coordinates to meters in a local equirectangular
+// projection around their middle latitude
+func projectLocal(coords [][]float64) [][2]float64 {
+	minLat, maxLat := coords[0][1], coords[0][1]
+	for _, coord := range coords {
+		minLat = math.Min(minLat, coord[1])
+		maxLat = math.Max(maxLat, coord[1])
+	}
+	metersPerDegree := earthRadius * math.Pi / 180
+	lonScale := math.Max(math.Cos((minLat+maxLat)/2*math.Pi/180), 0.01) * metersPerDegree
+
+	points := make([][2]float64, len(coords))
+	for i, coord := range coords {
+		points[i] = [2]float64{coord[0] * lonScale, coord[1] * metersPerDegree}
+	}
+	return points
+}
+
+// copyCoords returns copies of the coordinates marked in keep, or of all of
+// them when keep is nil
+func copyCoords(coords [][]float64, keep []bool) [][]float64 {
+	out := make([][]float64, 0, len(coords))
+	for i, coord := range coords {
+		if keep == nil || keep[i] {
+			out = append(out, append([]float64(nil), coord...))
+		}
+	}
+	return out
+}
+
+// smallestEnclosingCircle returns the center and radius of the smallest circle
+// enclosing points in the plane (incremental Welzl algorithm)
+func smallestEnclosingCircle(points [][2]float64) ([2]float64, float64) {
+	center, radius := points[0], 0.0
+	inside := func(p [2]float64) bool {
+		return math.Hypot(p[0]-center[0], p[1]-center[1]) <= radius*(1+1e-12)
+	}
+
+	for i := 1; i < len(points); i++ {
+		if inside(points[i]) {
+			continue
+		}
+		// points[i] is on the boundary of the circle of points[:i+1]
+		center, radius = points[i], 0
+		for j := 0; j < i; j++ {
+			if inside(points[j]) {
+				continue
+			}
+			// points[i] and points[j] are both on the boundary
+			center = [2]float64{(points[i][0] + points[j][0]) / 2, (points[i][1] + points[j][1]) / 2}
+			radius = math.Hypot(points[i][0]-center[0], points[i][1]-center[1])
+			for k := 0; k < j; k++ {
+				if !inside(points[k]) {
+					center, radius = circumcircle(points[i], points[j], points[k])
+				}
+			}
+		}
+	}
+	return center, radius
+}
+
+// circumcircle returns the circle through three points. Collinear points give
+// the circle on their two farthest points as diameter.
+func circumcircle(a, b, c [2]float64) ([2]float64, float64) {
+	bx, by := b[0]-a[0], b[1]-a[1]
+	cx, cy := c[0]-a[0], c[1]-a[1]
+	d := 2 * (bx*cy - by*cx)
+	if d == 0 {
+		p, q := a, b
+		if math.Hypot(c[0]-a[0], c[1]-a[1]) > math.Hypot(q[0]-p[0], q[1]-p[1]) {
+			q = c
+		}
+		if math.Hypot(c[0]-b[0], c[1]-b[1]) > math.Hypot(q[0]-p[0], q[1]-p[1]) {
+			p, q = b, c
+		}
+		center := [2]float64{(p[0] + q[0]) / 2, (p[1] + q[1]) / 2}
+		return center, math.Hypot(p[0]-center[0], p[1]-center[1])
+	}
+
+	b2, c2 := bx*bx+by*by, cx*cx+cy*cy
+	ux := (cy*b2 - by*c2) / d
+	uy := (bx*c2 - cx*b2) / d
+	return [2]float64{a[0] + ux, a[1] + uy}, math.Hypot(ux, uy)
+}
+
+// haversineDistance returns the great-circle distance in meters between two
+// WGS-84 positions
+func haversineDistance(lon1, lat1, lon2, lat2 float64) float64 {
+	const toRad = math.Pi / 180
+	dLat := (lat2 - lat1) * toRad
+	dLon := (lon2 - lon1) * toRad
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
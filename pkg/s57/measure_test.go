@@ -0,0 +1,212 @@
+package s57
+
+import (
+	"math"
+	"testing"
+)
+
+// TestPerimeter tests the perimeter of a one-degree square at the equator
+func TestPerimeter(t *testing.T) {
+	square := Geometry{
+		Type:        GeometryTypePolygon,
+		Coordinates: [][]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}},
+	}
+
+	// Three sides of one degree of arc; the northern side is shortened by cos(1°)
+	degree := earthRadius * math.Pi / 180
+	expected := degree * (3 + math.Cos(math.Pi/180))
+	if got := square.Perimeter(); math.Abs(got-expected) > 1 {
+		t.Errorf("Perimeter = %.1f m, expected %.1f m", got, expected)
+	}
+
+	// An unclosed ring still includes the closing segment
+	open := Geometry{Type: GeometryTypePolygon, Coordinates: square.Coordinates[:4]}
+	if got := open.Perimeter(); math.Abs(got-expected) > 1 {
+		t.Errorf("Unclosed ring perimeter = %.1f m, expected %.1f m", got, expected)
+	}
+
+	// A hole adds its own ring, without segments joining it to the outer ring
+	holed := Geometry{
+		Type: GeometryTypePolygon,
+		Coordinates: [][]float64{
+			{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0},
+			{1, 1}, {1, 3}, {3, 3}, {3, 1}, {1, 1},
+		},
+	}
+	expected = 0
+	for _, ring := range [][][]float64{holed.Coordinates[:5], holed.Coordinates[5:]} {
+		expected += Geometry{Type: GeometryTypePolygon, Coordinates: ring}.Perimeter()
+	}
+	if got := holed.Perimeter(); math.Abs(got-expected) > 1e-6 {
+		t.Errorf("Perimeter with hole = %.1f m, expected %.1f m", got, expected)
+	}
+
+	line := Geometry{Type: GeometryTypeLineString, Coordinates: square.Coordinates}
+	if got := line.Perimeter(); got != 0 {
+		t.Errorf("Line perimeter = %v, expected 0", got)
+	}
+}
+
+// TestBoundingCircle tests the enclosing circle of polygon rings
+func TestBoundingCircle(t *testing.T) {
+	square := Geometry{
+		Type:        GeometryTypePolygon,
+		Coordinates: [][]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}},
+	}
+	lon, lat, radius := square.BoundingCircle()
+	if math.Abs(lon-0.5) > 1e-6 || math.Abs(lat-0.5) > 1e-6 {
+		t.Errorf("Center = (%v, %v), expected (0.5, 0.5)", lon, lat)
+	}
+	halfDiagonal := haversineDistance(0.5, 0.5, 0, 0)
+	if math.Abs(radius-halfDiagonal) > 1 {
+		t.Errorf("Radius = %.1f m, expected %.1f m", radius, halfDiagonal)
+	}
+
+	// A concave ring whose circle is set by three vertices
+	ring := Geometry{
+		Type: GeometryTypePolygon,
+		Coordinates: [][]float64{
+			{-76.40, 38.30}, {-76.35, 38.31}, {-76.37, 38.33}, {-76.36, 38.36},
+			{-76.39, 38.34}, {-76.41, 38.35}, {-76.40, 38.30},
+		},
+	}
+	lon, lat, radius = ring.BoundingCircle()
+	farthest := 0.0
+	for _, coord := range ring.Coordinates {
+		farthest = math.Max(farthest, haversineDistance(lon, lat, coord[0], coord[1]))
+	}
+	if radius < farthest || radius == 0 {
+		t.Errorf("Radius %.1f m does not enclose vertex at %.1f m", radius, farthest)
+	}
+	// The circle is no larger than the one around the bounding box
+	if boxRadius := haversineDistance(-76.41, 38.30, -76.35, 38.36) / 2; radius > boxRadius {
+		t.Errorf("Radius %.1f m exceeds bounding box circle %.1f m", radius, boxRadius)
+	}
+
+	point := Geometry{Type: GeometryTypePoint, Coordinates: [][]float64{{1, 1}}}
+	if lon, lat, radius := point.BoundingCircle(); lon != 0 || lat != 0 || radius != 0 {
+		t.Errorf("Point bounding circle = (%v, %v, %v), expected zeros", lon, lat, radius)
+	}
+}
+
+// TestDensify tests great-circle densification of a transatlantic segment
+func TestDensify(t *testing.T) {
+	// New York to London, with a depth at each end
+	leg := Geometry{
+		Type:        GeometryTypeLineString,
+		Coordinates: [][]float64{{-74.0, 40.7, 10}, {-0.1, 51.5, 20}},
+	}
+	const maxSegment = 100000.0
+	dense := leg.Densify(maxSegment)
+
+	coords := dense.Coordinates
+	if len(coords) < 50 {
+		t.Fatalf("Expected the segment to be subdivided, got %d coordinates", len(coords))
+	}
+	if coords[0][0] != -74.0 || coords[len(coords)-1][1] != 51.5 {
+		t.Errorf("Endpoints changed: %v ... %v", coords[0], coords[len(coords)-1])
+	}
+	for i := 1; i < len(coords); i++ {
+		if d := haversineDistance(coords[i-1][0], coords[i-1][1], coords[i][0], coords[i][1]); d > maxSegment+1 {
+			t.Fatalf("Segment %d is %.0f m long", i, d)
+		}
+	}
+
+	// The great circle runs well north of the straight lon/lat line
+	middle := coords[len(coords)/2]
+	if middle[1] < 50 {
+		t.Errorf("Midpoint latitude %.2f, expected the great circle north of 50°", middle[1])
+	}
+	if len(middle) != 3 || math.Abs(middle[2]-15) > 0.5 {
+		t.Errorf("Midpoint depth not interpolated: %v", middle)
+	}
+	if len(leg.Coordinates) != 2 {
+		t.Error("Densify modified the source geometry")
+	}
+
+	// Short segments and points are left alone
+	short := Geometry{Type: GeometryTypeLineString, Coordinates: [][]float64{{0, 0}, {0.1, 0}}}
+	if got := short.Densify(maxSegment); len(got.Coordinates) != 2 {
+		t.Errorf("Expected a short segment unchanged, got %v", got.Coordinates)
+	}
+
+	// No points are inserted across the gap between parts
+	parts := [][][]float64{{{0, 0}, {0.1, 0}}, {{10, 0}, {10.1, 0}}}
+	multi := Geometry{
+		Type:        GeometryTypeMultiLineString,
+		Coordinates: append(append([][]float64{}, parts[0]...), parts[1]...),
+		Parts:       parts,
+	}
+	if got := multi.Densify(maxSegment); len(got.Coordinates) != 4 || len(got.Parts) != 2 {
+		t.Errorf("Expected parts densified separately, got %v", got.Parts)
+	}
+}
+
+// TestSimplify tests Douglas–Peucker simplification of lines and rings
+func TestSimplify(t *testing.T) {
+	// A near-collinear run: each vertex is within ~1 m of the straight line
+	var run [][]float64
+	for i := 0; i <= 10; i++ {
+		offset := 0.000005 * float64(i%2) // ~0.55 m of latitude
+		run = append(run, []float64{-76.0 + 0.01*float64(i), 38.5 + offset, float64(i)})
+	}
+	line := Geometry{Type: GeometryTypeLineString, Coordinates: run}
+
+	got := line.Simplify(5).Coordinates
+	if len(got) != 2 || got[0][0] != run[0][0] || got[1][0] != run[10][0] {
+		t.Fatalf("Expected the run to collapse to its endpoints, got %v", got)
+	}
+	if len(got[1]) != 3 || got[1][2] != 10 {
+		t.Errorf("Depth of kept vertex lost: %v", got[1])
+	}
+	if len(line.Simplify(0.1).Coordinates) != len(run) {
+		t.Error("Expected every vertex kept below the offset")
+	}
+	if len(line.Coordinates) != len(run) {
+		t.Error("Simplify modified the source geometry")
+	}
+
+	// A square with midpoints on each side keeps its four corners
+	square := Geometry{Type: GeometryTypePolygon, Coordinates: [][]float64{
+		{0, 0}, {0.5, 0}, {1, 0}, {1, 0.5}, {1, 1}, {0.5, 1}, {0, 1}, {0, 0.5}, {0, 0},
+	}}
+	ring := square.Simplify(100).Coordinates
+	if len(ring) != 5 {
+		t.Errorf("Expected 4 corners and the closing coordinate, got %v", ring)
+	}
+
+	// A thin triangle never drops below a ring of three vertices
+	thin := Geometry{Type: GeometryTypePolygon, Coordinates: [][]float64{
+		{0, 0}, {0.5, 0.0001}, {1, 0}, {0.5, -0.0001}, {0, 0},
+	}}
+	if ring := thin.Simplify(1000000).Coordinates; len(ring) < 4 {
+		t.Errorf("Ring simplified below 4 coordinates: %v", ring)
+	} else if ok, reason := (Geometry{Type: GeometryTypePolygon, Coordinates: ring}).IsValid(); !ok {
+		t.Errorf("Simplified ring is invalid: %s", reason)
+	}
+
+	// The outer ring and the hole are simplified as rings of their own, each
+	// keeping its corners
+	holed := Geometry{Type: GeometryTypePolygon, Coordinates: [][]float64{
+		{0, 0}, {0.5, 0}, {1, 0}, {1, 0.5}, {1, 1}, {0.5, 1}, {0, 1}, {0, 0.5}, {0, 0},
+		{0.25, 0.25}, {0.25, 0.5}, {0.25, 0.75}, {0.5, 0.75}, {0.75, 0.75}, {0.75, 0.5}, {0.75, 0.25}, {0.5, 0.25}, {0.25, 0.25},
+	}}
+	simplified := holed.Simplify(100)
+	rings := polygonRings(simplified.Coordinates)
+	if len(rings) != 2 || len(rings[0]) != 5 || len(rings[1]) != 5 {
+		t.Errorf("Expected two rings of 4 corners each, got %v", simplified.Coordinates)
+	}
+	if ok, reason := simplified.IsValid(); !ok {
+		t.Errorf("Simplified polygon with hole is invalid: %s", reason)
+	}
+
+	// Parts are simplified separately
+	multi := Geometry{
+		Type:        GeometryTypeMultiLineString,
+		Coordinates: append(append([][]float64{}, run...), run...),
+		Parts:       [][][]float64{run, run},
+	}
+	if got := multi.Simplify(5); len(got.Parts) != 2 || len(got.Coordinates) != 4 {
+		t.Errorf("Expected each part collapsed to its endpoints, got %v", got.Parts)
+	}
+}
@@ -0,0 +1,88 @@
+package s57
+
+import "github.com/beetlebugorg/s57/internal/parser"
+
+// ChartMetadata identifies an S-57 dataset without its features.
+//
+// It is read by ExtractMetadata from the DSID (Data Set Identification) and
+// DSPM (Data Set Parameter) records, S-57 §7.3.1 and §7.3.2.
+type ChartMetadata struct {
+	DatasetName     string // DSNM: cell file name, e.g. "US5MA22M.000"
+	Edition         string // EDTN: edition number
+	UpdateNumber    string // UPDN: 0 for a base cell, 1, 2, ... for updates
+	UpdateDate      string // UADT: update application date (YYYYMMDD)
+	IssueDate       string // ISDT: issue date (YYYYMMDD)
+	ProducingAgency int    // AGEN: producing agency code
+	ExchangePurpose string // EXPP: "New" or "Revision"
+	UsageBand       UsageBand
+
+	CompilationScale int32 // CSCL: compilation scale denominator, 0 if no DSPM
+}
+
+// IsBaseCell reports whether the file is a base cell (EXPP=1) rather than an
+// update (EXPP=2). A missing or unknown EXPP is not a base cell.
+func (m ChartMetadata) IsBaseCell() bool { return m.ExchangePurpose == "New" }
+
+// ExtractMetadata reads the identification of an S-57 file without building
+// its features or geometry.
+//
+// Unlike Parse it accepts update files (.001, .002, ...), so tooling can sort
+// an unorganized directory of files into base cells and their updates before
+// parsing. Updates are not applied: the metadata describes the file itself.
+//
+// Example:
+//
+//	meta, err := s57.ExtractMetadata(path)
+//	if err == nil && meta.IsBaseCell() {
+//	    chart, err = parser.Parse(path)
+//	}
+func ExtractMetadata(filename string) (ChartMetadata, error) {
+	internal, err := parser.ExtractMetadata(filename)
+	if err != nil {
+		return ChartMetadata{}, err
+	}
+	return ChartMetadata{
+		DatasetName:      internal.DatasetName(),
+		Edition:          internal.Edition(),
+		UpdateNumber:     internal.UpdateNumber(),
+		UpdateDate:       internal.UpdateDate(),
+		IssueDate:        internal.IssueDate(),
+		ProducingAgency:  internal.ProducingAgency(),
+		ExchangePurpose:  internal.ExchangePurpose(),
+		UsageBand:        UsageBand(internal.IntendedUsage()),
+		CompilationScale: internal.CompilationScale(),
+	}, nil
+}
+
+// BoundsOnly returns the coverage area of an S-57 file without parsing its
+// features, for building coverage maps of many cells.
+//
+// Only the M_COVR coverage records, the spatial records they reference and
+// the dataset parameters are decoded, so it is many times faster than
+// ExtractMetadata, let alone Parse. M_COVR is the producer's authoritative
+// statement of coverage, and the result is the same box Chart.Bounds reports
+// for the parsed cell. Files without M_COVR fall back to the bounding box of
+// all their nodes and edges, which can differ slightly from the parsed
+// chart's bounds. Updates are not applied, so coverage changed by an update
+// file is not reflected.
+//
+// Example:
+//
+//	for _, path := range cells {
+//	    bounds, err := s57.BoundsOnly(path)
+//	    if err == nil && bounds.Intersects(viewport) {
+//	        visible = append(visible, path)
+//	    }
+//	}
+func BoundsOnly(path string) (Bounds, error) {
+	extent, err := parser.ExtractBounds(path)
+	if err != nil {
+		return Bounds{}, err
+	}
+	return Bounds{
+		MinLon: extent.MinLon,
+		MaxLon: extent.MaxLon,
+		MinLat: extent.MinLat,
+		MaxLat: extent.MaxLat,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package s57
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestExtractMetadata tests telling base cells and updates apart without parsing
+func TestExtractMetadata(t *testing.T) {
+	base, err := ExtractMetadata(testChartPath)
+	if err != nil {
+		t.Fatalf("ExtractMetadata failed: %v", err)
+	}
+	if !base.IsBaseCell() {
+		t.Errorf("Expected %s to be a base cell (EXPP %s)", base.DatasetName, base.ExchangePurpose)
+	}
+	if base.UpdateNumber != "0" || base.CompilationScale == 0 {
+		t.Errorf("Unexpected base metadata: %+v", base)
+	}
+
+	update, err := ExtractMetadata("../../test/US4MD81M/US4MD81M.001")
+	if err != nil {
+		t.Fatalf("ExtractMetadata failed on update: %v", err)
+	}
+	if update.IsBaseCell() {
+		t.Errorf("Expected %s to be an update (EXPP %s)", update.DatasetName, update.ExchangePurpose)
+	}
+	if update.UpdateNumber != "1" {
+		t.Errorf("Expected update number 1, got %q", update.UpdateNumber)
+	}
+	if (ChartMetadata{ExchangePurpose: "Unknown"}).IsBaseCell() {
+		t.Error("Expected unknown EXPP not to be a base cell")
+	}
+
+	// Parse accepts only the base cell, and the chart reports it as such
+	if _, err := NewParser().Parse("../../test/US4MD81M/US4MD81M.001"); !errors.As(err, new(*ErrNotABaseCell)) {
+		t.Errorf("Expected ErrNotABaseCell for the update, got %v", err)
+	}
+	chart, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+	if !chart.IsBaseCell() {
+		t.Error("Expected parsed chart to be a base cell")
+	}
+}
+
+// TestBoundsOnly tests that the coverage read without parsing matches the
+// parsed chart's bounds
+func TestBoundsOnly(t *testing.T) {
+	bounds, err := BoundsOnly(testChartPath)
+	if err != nil {
+		t.Fatalf("BoundsOnly failed: %v", err)
+	}
+
+	opts := DefaultParseOptions()
+	opts.ApplyUpdates = false
+	chart, err := NewParser().ParseWithOptions(testChartPath, opts)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+	expected := chart.Bounds()
+	if math.Abs(bounds.MinLon-expected.MinLon) > 1e-9 || math.Abs(bounds.MaxLon-expected.MaxLon) > 1e-9 ||
+		math.Abs(bounds.MinLat-expected.MinLat) > 1e-9 || math.Abs(bounds.MaxLat-expected.MaxLat) > 1e-9 {
+		t.Errorf("BoundsOnly = %+v, expected %+v", bounds, expected)
+	}
+
+	if _, err := BoundsOnly("../../test/US4MD81M/US4MD81A.TXT"); !errors.Is(err, ErrNotS57) {
+		t.Errorf("Expected ErrNotS57 for a text file, got %v", err)
+	}
+}
+
+// BenchmarkBoundsOnly measures reading coverage without parsing
+func BenchmarkBoundsOnly(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := BoundsOnly(testChartPath); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkExtractMetadata measures reading metadata for comparison with
+// BoundsOnly
+func BenchmarkExtractMetadata(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := ExtractMetadata(testChartPath); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
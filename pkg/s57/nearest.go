@@ -0,0 +1,158 @@
+package s57
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// initialSearchRadius is the first radius, in degrees of latitude, searched by
+// KNearestFeatures. The search box doubles until enough features are found.
+const initialSearchRadius = 0.01
+
+// KNearestFeatures returns up to k features nearest to a position, closest first.
+//
+// Distance is measured to the feature geometry: to the nearest segment of a
+// line or polygon boundary (zero inside a polygon) and to the nearest point of
+// a (multi)point. Distances use a local equirectangular approximation, which
+// preserves ordering at chart scales. Optional classes restrict the search to
+// those object classes. Ties are broken by feature ID so output is
+// deterministic.
+//
+// The search expands a box around the position through the spatial index,
+// doubling it until k features lie within the searched radius, so only nearby
+// features are examined.
+//
+// Example:
+//
+//	hazards := chart.KNearestFeatures(lon, lat, 5, "OBSTRN", "WRECKS", "UWTROC")
+func (c *Chart) KNearestFeatures(lon, lat float64, k int, classes ...string) []Feature {
+	if k <= 0 || len(c.features) == 0 {
+		return nil
+	}
+
+	var wanted map[string]bool
+	if len(classes) > 0 {
+		wanted = make(map[string]bool, len(classes))
+		for _, class := range classes {
+			wanted[class] = true
+		}
+	}
+
+	// Longitude degrees are scaled so distances are isotropic near the position
+	lonScale := math.Cos(lat * math.Pi / 180)
+	if lonScale < 0.01 {
+		lonScale = 0.01
+	}
+
+	chartBounds := c.Bounds()
+	for radius := initialSearchRadius; ; radius *= 2 {
+		box := Bounds{
+			MinLon: lon - radius/lonScale,
+			MaxLon: lon + radius/lonScale,
+			MinLat: lat - radius,
+			MaxLat: lat + radius,
+		}
+
+		nearest := &neighborHeap{}
+		c.EachFeatureInBounds(box, func(f Feature) bool {
+			if wanted != nil && !wanted[f.objectClass] {
+				return true
+			}
+			if len(f.geometry.Coordinates) == 0 {
+				return true
+			}
+			n := neighbor{feature: f, distance: geometryDistance(f.geometry, lon, lat, lonScale)}
+			if nearest.Len() < k {
+				heap.Push(nearest, n)
+			} else if n.closerThan((*nearest)[0]) {
+				(*nearest)[0] = n
+				heap.Fix(nearest, 0)
+			}
+			return true
+		})
+
+		// Every feature within radius intersects the box, so the result is
+		// final once k features lie within it or the box covers the chart
+		coversChart := box.MinLon <= chartBounds.MinLon && box.MaxLon >= chartBounds.MaxLon &&
+			box.MinLat <= chartBounds.MinLat && box.MaxLat >= chartBounds.MaxLat
+		if (nearest.Len() == k && (*nearest)[0].distance <= radius) || coversChart {
+			sort.Slice(*nearest, func(i, j int) bool { return (*nearest)[i].closerThan((*nearest)[j]) })
+			result := make([]Feature, nearest.Len())
+			for i, n := range *nearest {
+				result[i] = n.feature
+			}
+			return result
+		}
+	}
+}
+
+// neighbor is a candidate feature with its distance to the query position
+type neighbor struct {
+	feature  Feature
+	distance float64
+}
+
+// closerThan orders neighbors by distance, then by feature ID
+func (n neighbor) closerThan(other neighbor) bool {
+	if n.distance != other.distance {
+		return n.distance < other.distance
+	}
+	return n.feature.id < other.feature.id
+}
+
+// neighborHeap is a max-heap keeping the farthest of the k best at the root
+type neighborHeap []neighbor
+
+func (h neighborHeap) Len() int            { return len(h) }
+func (h neighborHeap) Less(i, j int) bool  { return h[j].closerThan(h[i]) }
+func (h neighborHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *neighborHeap) Push(x interface{}) { *h = append(*h, x.(neighbor)) }
+func (h *neighborHeap) Pop() interface{} {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+// geometryDistance returns the distance from a position to a geometry in
+// degrees of latitude, with longitude scaled by lonScale
+func geometryDistance(g Geometry, lon, lat, lonScale float64) float64 {
+	coords := g.Coordinates
+	project := func(c []float64) (float64, float64) {
+		return (c[0] - lon) * lonScale, c[1] - lat
+	}
+
+	if g.Type == GeometryTypePolygon && pointInPolygon(lon, lat, coords) {
+		return 0
+	}
+
+	best := math.Inf(1)
+	if g.Type == GeometryTypePoint || len(coords) == 1 {
+		for _, c := range coords {
+			x, y := project(c)
+			best = math.Min(best, math.Hypot(x, y))
+		}
+		return best
+	}
+
+	for _, line := range g.lines() {
+		for i := 0; i+1 < len(line); i++ {
+			ax, ay := project(line[i])
+			bx, by := project(line[i+1])
+			best = math.Min(best, originSegmentDistance(ax, ay, bx, by))
+		}
+	}
+	return best
+}
+
+// originSegmentDistance returns the distance from the origin to segment a-b
+func originSegmentDistance(ax, ay, bx, by float64) float64 {
+	dx, dy := bx-ax, by-ay
+	lengthSq := dx*dx + dy*dy
+	t := 0.0
+	if lengthSq > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/lengthSq))
+	}
+	return math.Hypot(ax+t*dx, ay+t*dy)
+}
@@ -0,0 +1,70 @@
+package s57
+
+import (
+	"testing"
+)
+
+// TestKNearestFeatures tests k-nearest-neighbor queries
+func TestKNearestFeatures(t *testing.T) {
+	point := func(id int64, class string, lon, lat float64) Feature {
+		return Feature{id: id, objectClass: class,
+			geometry:   Geometry{Type: GeometryTypePoint, Coordinates: [][]float64{{lon, lat}}},
+			attributes: map[string]interface{}{}}
+	}
+
+	chart := newTestChart([]Feature{
+		point(1, "WRECKS", 0.30, 0),
+		point(2, "OBSTRN", 0.10, 0),
+		point(3, "LIGHTS", 0.05, 0),
+		point(4, "OBSTRN", -0.10, 0), // Ties with feature 2
+		point(5, "WRECKS", 2.00, 0),  // Far outside the first search radius
+		// Line passing 0.02 north of the origin; its vertices are much farther
+		{id: 6, objectClass: "DEPCNT", attributes: map[string]interface{}{},
+			geometry: Geometry{Type: GeometryTypeLineString, Coordinates: [][]float64{{-1, 0.02}, {1, 0.02}}}},
+	})
+
+	ids := func(features []Feature) []int64 {
+		result := make([]int64, len(features))
+		for i, f := range features {
+			result[i] = f.ID()
+		}
+		return result
+	}
+	equal := func(a, b []int64) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	tests := []struct {
+		name    string
+		k       int
+		classes []string
+		want    []int64
+	}{
+		{"nearest by segment distance", 2, nil, []int64{6, 3}},
+		{"ties broken by ID", 4, nil, []int64{6, 3, 2, 4}},
+		{"class filter", 3, []string{"OBSTRN", "WRECKS"}, []int64{2, 4, 1}},
+		{"expands to distant features", 4, []string{"OBSTRN", "WRECKS"}, []int64{2, 4, 1, 5}},
+		{"fewer features than k", 10, []string{"WRECKS"}, []int64{1, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ids(chart.KNearestFeatures(0, 0, tt.k, tt.classes...))
+			if !equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if got := chart.KNearestFeatures(0, 0, 0); len(got) != 0 {
+		t.Errorf("Expected no features for k=0, got %v", ids(got))
+	}
+}
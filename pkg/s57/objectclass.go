@@ -0,0 +1,131 @@
+package s57
+
+import (
+	"github.com/beetlebugorg/s57/internal/parser"
+)
+
+// ObjectType classifies object classes in the S-57 Object Catalogue.
+//
+// Reference: S-57 Part 2 §2.1 and Appendix A Chapter 1
+type ObjectType int
+
+const (
+	// ObjectTypeUnknown indicates the object type is not known.
+	ObjectTypeUnknown ObjectType = iota
+
+	// ObjectTypeGeo - feature objects carrying descriptive real-world
+	// characteristics (e.g., DEPARE, LIGHTS).
+	ObjectTypeGeo
+
+	// ObjectTypeMeta - feature objects carrying information about other
+	// objects (e.g., M_COVR, M_QUAL).
+	ObjectTypeMeta
+
+	// ObjectTypeCollection - feature objects describing relationships between
+	// other objects (e.g., C_AGGR, C_ASSO).
+	ObjectTypeCollection
+
+	// ObjectTypeCartographic - feature objects carrying cartographic
+	// information such as text placement ($TEXTS).
+	ObjectTypeCartographic
+)
+
+// String returns the human-readable name of the object type.
+func (t ObjectType) String() string {
+	switch t {
+	case ObjectTypeGeo:
+		return "Geo"
+	case ObjectTypeMeta:
+		return "Meta"
+	case ObjectTypeCollection:
+		return "Collection"
+	case ObjectTypeCartographic:
+		return "Cartographic"
+	default:
+		return "Unknown"
+	}
+}
+
+// ObjectClassMetadata describes an object class from the S-57 Object Catalogue.
+type ObjectClassMetadata struct {
+	// Code is the numeric object class code (OBJL), e.g. 43.
+	Code int
+
+	// Acronym is the six-character object class code, e.g. "DEPCNT".
+	Acronym string
+
+	// Name is the human-readable long name, e.g. "Depth contour".
+	Name string
+
+	// Type is the object type (geo, meta, collection or cartographic).
+	Type ObjectType
+
+	// Primitives lists the geometry types the class may be encoded with.
+	// Empty for collection objects, which have no geometry.
+	Primitives []GeometryType
+}
+
+// Allows reports whether the object class permits the given geometry type.
+//
+// For example, DEPCNT only allows GeometryTypeLineString. A MultiLineString
+// is allowed wherever a LineString is, since both are the S-57 line primitive.
+func (m ObjectClassMetadata) Allows(geomType GeometryType) bool {
+	if geomType == GeometryTypeMultiLineString {
+		geomType = GeometryTypeLineString
+	}
+	for _, prim := range m.Primitives {
+		if prim == geomType {
+			return true
+		}
+	}
+	return false
+}
+
+// ObjectClassInfo returns Object Catalogue metadata for an object class code.
+//
+// Use this to build legends ("DEPCNT" → "Depth contour") or to check which
+// geometry primitives a class permits. Returns false for unknown codes.
+//
+// Example:
+//
+//	if info, ok := s57.ObjectClassInfo(feature.ObjectClass()); ok {
+//	    fmt.Printf("%s (%s)\n", info.Name, info.Acronym)
+//	}
+//
+// Reference: S-57 Appendix A Chapter 1 - Object Classes
+func ObjectClassInfo(code string) (ObjectClassMetadata, bool) {
+	def, ok := parser.LookupObjectClass(code)
+	if !ok {
+		return ObjectClassMetadata{}, false
+	}
+
+	info := ObjectClassMetadata{
+		Code:       def.Code,
+		Acronym:    def.Acronym,
+		Name:       def.Name,
+		Primitives: make([]GeometryType, len(def.Primitives)),
+	}
+	switch def.Class {
+	case "G":
+		info.Type = ObjectTypeGeo
+	case "M":
+		info.Type = ObjectTypeMeta
+	case "C":
+		info.Type = ObjectTypeCollection
+	case "$":
+		info.Type = ObjectTypeCartographic
+	}
+	for i, prim := range def.Primitives {
+		info.Primitives[i] = GeometryType(prim)
+	}
+	return info, true
+}
+
+// isMetaClass reports whether an object class is a meta or collection object
+// according to the Object Catalogue. Classes missing from the catalogue are
+// treated as geographic, whatever their acronym, since an "M_" or "C_" prefix
+// alone does not make a class meta.
+func isMetaClass(class string) bool {
+	info, ok := ObjectClassInfo(class)
+	return ok && (info.Type == ObjectTypeMeta || info.Type == ObjectTypeCollection)
+}
@@ -1,28 +1,221 @@
 package s57
 
 // ParseOptions configures parsing behavior.
+//
+// Start from DefaultParseOptions and change the fields you need. The zero
+// value is not the default: a bare ParseOptions{} disables ValidateGeometry,
+// ApplyUpdates and InjectSoundingDepths, which DefaultParseOptions enables.
 type ParseOptions struct {
+	// SkipUnknownFeatures drops features whose geometry cannot be built or
+	// fails ValidateGeometry, instead of failing the parse. Default is false.
 	SkipUnknownFeatures bool
-	ValidateGeometry    bool
-	ObjectClassFilter   []string
+
+	// ValidateGeometry checks that every feature's coordinates are valid
+	// longitudes and latitudes as the feature is built. Default is true.
+	ValidateGeometry bool
+
+	// ObjectClassFilter keeps only features of these object classes, e.g.
+	// []string{"DEPARE", "DEPCNT"}. Empty keeps every class.
+	ObjectClassFilter []string
+
+	// GeometryTypeFilter keeps only features whose geometry is one of these
+	// types, e.g. []GeometryType{GeometryTypePolygon} for a fill layer.
+	// GeometryTypeLineString also keeps MultiLineString. Empty keeps every
+	// type. Features are dropped as they are built, so filtered features
+	// never reach the chart or its spatial index.
+	GeometryTypeFilter []GeometryType
 
 	// ApplyUpdates controls whether to automatically discover and apply
 	// update files (.001, .002, etc.) when parsing a base cell (.000).
 	// Default is true - updates are automatically applied.
 	//
 	// When true, the parser looks for sequential update files in the same
-	// directory as the base file and applies them in order.
+	// directory as the base file and applies them in order. Updates must
+	// match the base cell's edition; update files left over from another
+	// edition are skipped with an ErrStaleUpdate warning.
 	//
 	// Set to false to parse only the base cell without updates.
 	ApplyUpdates bool
+
+	// RetainRawFields keeps the original ISO 8211 fields of every feature
+	// record so they can be inspected with Chart.RawRecord.
+	// Default is false.
+	//
+	// Use this when you need fields the parser does not interpret yet
+	// (e.g., FFPT relationships). Retaining raw bytes increases memory use.
+	RetainRawFields bool
+
+	// SpatialIndexMinChildren and SpatialIndexMaxChildren set the R-tree node
+	// fan-out used for FeaturesInBounds. Defaults are 25 and 50.
+	//
+	// Smaller nodes favour build speed on tiny overview cells; larger nodes
+	// can speed up queries on huge harbour cells. Zero uses the default.
+	SpatialIndexMinChildren int
+	SpatialIndexMaxChildren int
+
+	// ExactBoundsQueries makes FeaturesInBounds and EachFeatureInBounds check
+	// each R-tree candidate against the feature's true bounds. Default is
+	// false.
+	//
+	// The R-tree pads point features by about 11 m, so the default query can
+	// return a few features just outside the bounds. That is harmless for
+	// rendering; enable this for counting or clipping, at the cost of one
+	// bounds test per candidate.
+	ExactBoundsQueries bool
+
+	// ValidatePrimitive checks each feature's geometry type against the
+	// primitives its object class permits (see ObjectClassInfo), e.g. a DEPARE
+	// must be an area and a DEPCNT a line. Default is false.
+	//
+	// Mismatches are reported as warnings rather than errors; they usually
+	// indicate a topology-resolution bug or corrupt data.
+	ValidatePrimitive bool
+
+	// CoordinatePrecision rounds longitude and latitude to this many decimal
+	// digits as coordinates are read. Default is 0 (no rounding).
+	//
+	// ENC coordinates carry 10^-7 degree (~1 cm) precision, far more than
+	// display needs; 6 digits (~10 cm) or 5 (~1 m) shrink GeoJSON and vector
+	// tile output. Rounding is applied to the shared edges and nodes before
+	// geometry is built, so polygon rings stay closed. Depths are not rounded.
+	CoordinatePrecision int
+
+	// ValidatePolygons checks every polygon ring for closure, self-intersection
+	// and sliver area, and reports invalid polygons through OnWarning and
+	// Chart.Warnings. Default is false. See Geometry.IsValid.
+	ValidatePolygons bool
+
+	// DropInvalidPolygons removes polygons found invalid by ValidatePolygons
+	// from the chart. Default is false (invalid polygons are kept and only
+	// reported).
+	DropInvalidPolygons bool
+
+	// CoordinateFactorOverride forces the coordinate multiplication factor
+	// (COMF) used to scale positions. Default is 0 (use the file's value).
+	//
+	// This is an escape hatch for non-conformant producer data whose DSPM
+	// record is present but wrong, which yields coordinates off by orders of
+	// magnitude. Leave it unset for conforming cells; ENCs use 10000000.
+	CoordinateFactorOverride int
+
+	// SoundingFactorOverride forces the sounding multiplication factor (SOMF)
+	// used to scale depths. Default is 0 (use the file's value). Like
+	// CoordinateFactorOverride, it is only for known-bad producer data;
+	// ENCs use 10.
+	SoundingFactorOverride int
+
+	// RingClosureTolerance is the largest gap, in degrees, between a polygon
+	// ring's first and last coordinates that still counts as closed. Default
+	// is 0, which uses 1e-8 (about 1 mm); a negative value requires the ends
+	// to be exactly equal.
+	//
+	// Rings whose ends differ by a floating-point residue are snapped shut
+	// instead of getting a duplicate closing vertex and a zero-length final
+	// segment.
+	RingClosureTolerance float64
+
+	// Keep3D keeps depth on line and area geometries built from 3D (SG3D)
+	// edges and nodes, giving [lon, lat, depth] coordinates. Default is false:
+	// lines and areas are 2D, and only point features such as SOUNDG carry
+	// depth. A geometry is 2D only if none of its coordinates has a depth;
+	// depths missing from some coordinates (for example 2D nodes ending a 3D
+	// edge) are interpolated from their neighbours (see Geometry.Dimensions).
+	Keep3D bool
+
+	// SortFeatures orders the chart's features by FeatureID (agency, number,
+	// subdivision) instead of file record order. Default is false. Record
+	// order differs between a base-only and an updated parse, since features
+	// inserted by updates come last; sorting gives the same logical chart
+	// the same order for snapshot tests and diffs.
+	SortFeatures bool
+
+	// ExcludeMetaFeatures removes meta objects (M_COVR, M_QUAL, ...) and
+	// collection objects (C_AGGR, C_ASSO, ...) from Features, FeatureCount
+	// and the spatial index, leaving only geographic and cartographic
+	// content for renderers. Default is false. M_COVR is still used for
+	// Chart.Bounds.
+	ExcludeMetaFeatures bool
+
+	// InjectSoundingDepths exposes the depths of SOUNDG features as a derived
+	// "DEPTHS" attribute ([]float64, one per sounding) through Attributes,
+	// Attribute and EachAttribute. Default is true.
+	//
+	// DEPTHS is not an S-57 attribute: it is computed on request from the
+	// SG3D coordinates and is not in the file. Set to false to see only the
+	// attributes the file encodes, e.g. for schema discovery; Feature.Depths
+	// still returns the soundings.
+	InjectSoundingDepths bool
+
+	// RecordUpdateLog keeps a summary of each applied update file, with its
+	// counts of inserted, deleted and modified feature and spatial records,
+	// available from Chart.UpdateLog. Default is false.
+	RecordUpdateLog bool
+
+	// HealPrimitiveMismatch rebuilds features whose encoded primitive (FRID
+	// PRIM) contradicts the spatial records they reference. Default is false.
+	//
+	// A point that references edges becomes a line, a line that references
+	// only nodes becomes a point, and an area whose edges never close becomes
+	// a line along its boundary. When false, geometry follows PRIM and each
+	// mismatch is reported as a warning.
+	HealPrimitiveMismatch bool
+
+	// RetainTopology keeps the chart's edge/node graph, available from
+	// Chart.Topology. Default is false.
+	//
+	// This is an advanced option for renderers that work with shared
+	// boundaries. Every edge and node of the cell is kept in memory alongside
+	// the feature geometries, which can add substantially to memory use.
+	RetainTopology bool
+
+	// ContinueOnRecordError keeps parsing when a single record fails.
+	// Default is false.
+	//
+	// When true, a truncated or corrupt ISO 8211 file yields the records that
+	// precede the damage, and features or update instructions that cannot be
+	// applied are skipped. Each problem is reported through OnWarning and
+	// Chart.Warnings instead of failing the whole chart.
+	ContinueOnRecordError bool
+
+	// VerifyCounts checks the number of meta and geo features built against
+	// the counts the cell declares in its DSSI record. Default is false.
+	//
+	// Records inserted or deleted by updates and features removed by
+	// ObjectClassFilter, GeometryTypeFilter or DropInvalidPolygons are
+	// accounted for, so a mismatch means features were lost while parsing
+	// (for example skipped under SkipUnknownFeatures). Each mismatch is
+	// reported as an *ErrFeatureCountMismatch warning.
+	VerifyCounts bool
+
+	// StrictCounts makes a VerifyCounts mismatch fail the parse with an
+	// *ErrFeatureCountMismatch instead of a warning. Default is false.
+	StrictCounts bool
+
+	// OnWarning, if set, is called for every non-fatal problem found while
+	// parsing. The same warnings are available afterwards from Chart.Warnings.
+	OnWarning func(err error)
 }
 
-// DefaultParseOptions returns default options.
+// DefaultParseOptions returns the documented default options: geometry
+// validation, update files and the derived SOUNDG DEPTHS attribute enabled,
+// and the default R-tree fan-out. Parser.Parse and Parser.ParseFS parse with
+// these options.
+//
+// Example:
+//
+//	opts := s57.DefaultParseOptions()
+//	opts.ObjectClassFilter = []string{"DEPARE", "DEPCNT"}
+//	chart, err := parser.ParseWithOptions("US5MA22M.000", opts)
 func DefaultParseOptions() ParseOptions {
 	return ParseOptions{
 		SkipUnknownFeatures: false,
 		ValidateGeometry:    true,
 		ObjectClassFilter:   nil,
 		ApplyUpdates:        true, // Auto-apply updates by default
+
+		InjectSoundingDepths: true,
+
+		SpatialIndexMinChildren: defaultRTreeMinChildren,
+		SpatialIndexMaxChildren: defaultRTreeMaxChildren,
 	}
 }
@@ -1,6 +1,9 @@
 package s57
 
 import (
+	"io/fs"
+	"path"
+
 	"github.com/beetlebugorg/s57/internal/parser"
 )
 
@@ -18,6 +21,30 @@ type Parser interface {
 	//
 	// Use ParseOptions to control validation, error handling, and feature filtering.
 	ParseWithOptions(filename string, opts ParseOptions) (*Chart, error)
+
+	// ParseFS reads an S-57 base cell from a filesystem abstraction.
+	//
+	// Use this to parse charts from embed.FS, a zip.Reader, or any other fs.FS.
+	// The name is a slash-separated path within fsys. Update files (.001, .002,
+	// etc.) are discovered and applied from the same directory of fsys.
+	ParseFS(fsys fs.FS, name string) (*Chart, error)
+
+	// ParseFSWithOptions reads an S-57 base cell from a filesystem
+	// abstraction with custom options.
+	//
+	// It is ParseFS with control over validation, filtering, error handling
+	// and update files; ParseFS uses DefaultParseOptions.
+	ParseFSWithOptions(fsys fs.FS, name string, opts ParseOptions) (*Chart, error)
+
+	// ParseAll reads every dataset of an S-57 file, returning one chart per
+	// dataset in file order.
+	//
+	// Exchange files rarely bundle more than one dataset (several DSID records);
+	// Parse and ParseWithOptions reject such files with ErrMultipleDatasets
+	// rather than merge features of different cells. A file holding a single
+	// dataset gives a one-element slice with its updates applied as usual.
+	// Update files are not applied to the datasets of a multi-dataset file.
+	ParseAll(filename string, opts ParseOptions) ([]*Chart, error)
 }
 
 // NewParser creates a new S-57 parser with default settings.
@@ -34,26 +61,99 @@ func NewParser() Parser {
 
 // parserWrapper wraps the internal parser and converts types
 type parserWrapper struct {
-	internal parser.Parser
+	internal  parser.Parser
+	catalogue *parser.Catalogue // Supplementary catalogue (see NewParserWithCatalogue)
 }
 
 func (p *parserWrapper) Parse(filename string) (*Chart, error) {
-	internalChart, err := p.internal.Parse(filename)
+	return p.ParseWithOptions(filename, DefaultParseOptions())
+}
+
+func (p *parserWrapper) ParseWithOptions(filename string, opts ParseOptions) (*Chart, error) {
+	internalChart, err := p.internal.ParseWithOptions(filename, p.internalOptions(opts))
 	if err != nil {
-		return nil, err
+		return nil, publicError(err)
 	}
-	return convertChart(internalChart), nil
+	chart := convertChart(internalChart, opts)
+	chart.source = diskSource(filename)
+	return chart, nil
 }
 
-func (p *parserWrapper) ParseWithOptions(filename string, opts ParseOptions) (*Chart, error) {
-	internalOpts := parser.ParseOptions{
+func (p *parserWrapper) ParseAll(filename string, opts ParseOptions) ([]*Chart, error) {
+	internalCharts, err := p.internal.ParseAll(filename, p.internalOptions(opts))
+	if err != nil {
+		return nil, publicError(err)
+	}
+	charts := make([]*Chart, len(internalCharts))
+	for i, internalChart := range internalCharts {
+		charts[i] = convertChart(internalChart, opts)
+		charts[i].source = diskSource(filename)
+	}
+	return charts, nil
+}
+
+// internalOptions converts public parse options to internal ones
+func (p *parserWrapper) internalOptions(opts ParseOptions) parser.ParseOptions {
+	return parser.ParseOptions{
 		SkipUnknownFeatures: opts.SkipUnknownFeatures,
 		ValidateGeometry:    opts.ValidateGeometry,
 		ObjectClassFilter:   opts.ObjectClassFilter,
+		GeometryTypeFilter:  convertGeometryTypes(opts.GeometryTypeFilter),
+		ApplyUpdates:        opts.ApplyUpdates,
+		RetainRawFields:     opts.RetainRawFields,
+
+		ValidatePrimitive:     opts.ValidatePrimitive,
+		HealPrimitiveMismatch: opts.HealPrimitiveMismatch,
+		ValidatePolygons:      opts.ValidatePolygons,
+		DropInvalidPolygons:   opts.DropInvalidPolygons,
+		CoordinatePrecision:   opts.CoordinatePrecision,
+		RingClosureTolerance:  opts.RingClosureTolerance,
+		Keep3D:                opts.Keep3D,
+		SortFeatures:          opts.SortFeatures,
+		RecordUpdateLog:       opts.RecordUpdateLog,
+
+		CoordinateFactorOverride: opts.CoordinateFactorOverride,
+		SoundingFactorOverride:   opts.SoundingFactorOverride,
+
+		ContinueOnRecordError: opts.ContinueOnRecordError,
+		VerifyCounts:          opts.VerifyCounts,
+		StrictCounts:          opts.StrictCounts,
+		OnWarning:             publicWarnings(opts.OnWarning),
+
+		Catalogue: p.catalogue,
 	}
-	internalChart, err := p.internal.ParseWithOptions(filename, internalOpts)
+}
+
+// publicWarnings wraps a warning callback to receive public error types
+func publicWarnings(onWarning func(err error)) func(err error) {
+	if onWarning == nil {
+		return nil
+	}
+	return func(err error) { onWarning(publicError(err)) }
+}
+
+func (p *parserWrapper) ParseFS(fsys fs.FS, name string) (*Chart, error) {
+	return p.ParseFSWithOptions(fsys, name, DefaultParseOptions())
+}
+
+func (p *parserWrapper) ParseFSWithOptions(fsys fs.FS, name string, opts ParseOptions) (*Chart, error) {
+	internalChart, err := p.internal.ParseFS(fsys, name, p.internalOptions(opts))
 	if err != nil {
-		return nil, err
+		return nil, publicError(err)
+	}
+	chart := convertChart(internalChart, opts)
+	chart.source = resourceSource{fsys: fsys, dir: path.Dir(name)}
+	return chart, nil
+}
+
+// convertGeometryTypes converts public geometry types to internal ones
+func convertGeometryTypes(types []GeometryType) []parser.GeometryType {
+	if len(types) == 0 {
+		return nil
+	}
+	converted := make([]parser.GeometryType, len(types))
+	for i, t := range types {
+		converted[i] = parser.GeometryType(t)
 	}
-	return convertChart(internalChart), nil
+	return converted
 }
@@ -1,7 +1,17 @@
 package s57
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
 	"testing"
+	"time"
 )
 
 const testChartPath = "../../test/US4MD81M/US4MD81M.000"
@@ -43,6 +53,11 @@ func TestParseRealChart(t *testing.T) {
 		t.Error("Producing agency should not be zero")
 	}
 
+	// Verify DSPM parameters were read - S-57 §7.3.2
+	if chart.UsedDefaultParameters() {
+		t.Error("Chart has a DSPM record but reports default parameters")
+	}
+
 	// Verify features were parsed - S-57 §7.3
 	if chart.FeatureCount() == 0 {
 		t.Error("Chart should contain features")
@@ -96,6 +111,66 @@ func TestUpdateFileHandling(t *testing.T) {
 	// (updates modify the dataset)
 	t.Logf("Base features: %d, Updated features: %d",
 		baseChart.FeatureCount(), chart.FeatureCount())
+
+	// ParseWithOptions applies updates when asked to
+	opts = DefaultParseOptions()
+	opts.RecordUpdateLog = true
+	updatedChart, err := parser.ParseWithOptions(testChartPath, opts)
+	if err != nil {
+		t.Fatalf("Failed to parse with updates: %v", err)
+	}
+	if updatedChart.UpdateNumber() != updateNum {
+		t.Errorf("Expected update number %s, got %s", updateNum, updatedChart.UpdateNumber())
+	}
+	if log := updatedChart.UpdateLog(); len(log) != 3 || log[2].UpdateNumber != updateNum {
+		t.Errorf("Expected 3 update summaries ending at %s, got %+v", updateNum, log)
+	}
+}
+
+// TestDefaultParseOptions tests the documented default options
+func TestDefaultParseOptions(t *testing.T) {
+	opts := DefaultParseOptions()
+	if !opts.ValidateGeometry || opts.SkipUnknownFeatures || !opts.ApplyUpdates || !opts.InjectSoundingDepths {
+		t.Errorf("Unexpected defaults: ValidateGeometry=%v SkipUnknownFeatures=%v ApplyUpdates=%v InjectSoundingDepths=%v",
+			opts.ValidateGeometry, opts.SkipUnknownFeatures, opts.ApplyUpdates, opts.InjectSoundingDepths)
+	}
+	if len(opts.ObjectClassFilter) != 0 || len(opts.GeometryTypeFilter) != 0 {
+		t.Error("Expected no filters by default")
+	}
+	if opts.SpatialIndexMinChildren != 25 || opts.SpatialIndexMaxChildren != 50 {
+		t.Errorf("Expected R-tree fan-out 25/50, got %d/%d",
+			opts.SpatialIndexMinChildren, opts.SpatialIndexMaxChildren)
+	}
+}
+
+// TestParseUsesDefaultOptions tests that Parse behaves as ParseWithOptions
+// with DefaultParseOptions, and that the zero value differs as documented
+func TestParseUsesDefaultOptions(t *testing.T) {
+	parser := NewParser()
+	chart, err := parser.Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+	withDefaults, err := parser.ParseWithOptions(testChartPath, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("Failed to parse with default options: %v", err)
+	}
+	if chart.UpdateNumber() != withDefaults.UpdateNumber() ||
+		chart.FeatureCount() != withDefaults.FeatureCount() ||
+		len(chart.Warnings()) != len(withDefaults.Warnings()) {
+		t.Errorf("Parse gave update %s, %d features, %d warnings; defaults gave %s, %d, %d",
+			chart.UpdateNumber(), chart.FeatureCount(), len(chart.Warnings()),
+			withDefaults.UpdateNumber(), withDefaults.FeatureCount(), len(withDefaults.Warnings()))
+	}
+
+	// A bare struct skips update files
+	bare, err := parser.ParseWithOptions(testChartPath, ParseOptions{})
+	if err != nil {
+		t.Fatalf("Failed to parse with zero options: %v", err)
+	}
+	if bare.UpdateNumber() != "0" {
+		t.Errorf("Expected zero options to skip updates, got update %s", bare.UpdateNumber())
+	}
 }
 
 // TestFeatureObjects tests S-57 feature objects
@@ -154,19 +229,8 @@ func TestSpatialIndexing(t *testing.T) {
 		t.Fatalf("Failed to parse chart: %v", err)
 	}
 
-	// Get chart bounds
-	chartBounds := chart.Bounds()
-
 	// Query a subset viewport (middle 50% of chart)
-	lonRange := chartBounds.MaxLon - chartBounds.MinLon
-	latRange := chartBounds.MaxLat - chartBounds.MinLat
-
-	viewport := Bounds{
-		MinLon: chartBounds.MinLon + lonRange*0.25,
-		MaxLon: chartBounds.MaxLon - lonRange*0.25,
-		MinLat: chartBounds.MinLat + latRange*0.25,
-		MaxLat: chartBounds.MaxLat - latRange*0.25,
-	}
+	viewport := viewportFraction(chart, 0.25, 0.75)
 
 	visible := chart.FeaturesInBounds(viewport)
 
@@ -186,6 +250,21 @@ func TestSpatialIndexing(t *testing.T) {
 	t.Logf("Viewport query: %d/%d features visible", len(visible), totalFeatures)
 }
 
+// viewportFraction returns the part of the chart's bounds between the
+// fractions lo and hi of its extent on both axes, e.g. 0.25 and 0.75 for
+// the middle 50% of the chart.
+func viewportFraction(chart *Chart, lo, hi float64) Bounds {
+	b := chart.Bounds()
+	lonRange := b.MaxLon - b.MinLon
+	latRange := b.MaxLat - b.MinLat
+	return Bounds{
+		MinLon: b.MinLon + lonRange*lo,
+		MaxLon: b.MinLon + lonRange*hi,
+		MinLat: b.MinLat + latRange*lo,
+		MaxLat: b.MinLat + latRange*hi,
+	}
+}
+
 // TestGeometryTypes tests S-57 geometry types
 // S-57 §7.3.3: Spatial Primitives (Point, Line, Area)
 func TestGeometryTypes(t *testing.T) {
@@ -257,6 +336,33 @@ func TestFeatureAttributes(t *testing.T) {
 	t.Logf("LIGHTS feature has %d attributes", len(attrs))
 }
 
+// TestDisplayGroup tests that features carry their GRUP code
+func TestDisplayGroup(t *testing.T) {
+	chart, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	depare, lights := 0, 0
+	for _, f := range chart.Features() {
+		switch f.ObjectClass() {
+		case "DEPARE":
+			depare++
+			if f.DisplayGroup() != 1 {
+				t.Fatalf("DEPARE %d is in group %d, expected 1", f.ID(), f.DisplayGroup())
+			}
+		case "LIGHTS":
+			lights++
+			if f.DisplayGroup() != 2 {
+				t.Fatalf("LIGHTS %d is in group %d, expected 2", f.ID(), f.DisplayGroup())
+			}
+		}
+	}
+	if depare == 0 || lights == 0 {
+		t.Fatalf("Expected DEPARE and LIGHTS in test chart, got %d and %d", depare, lights)
+	}
+}
+
 // TestObjectClassFiltering tests filtering by object class
 // S-57 §7.3: Feature Object Class codes
 func TestObjectClassFiltering(t *testing.T) {
@@ -282,6 +388,67 @@ func TestObjectClassFiltering(t *testing.T) {
 	t.Logf("Filtered to %d DEPCNT features", chart.FeatureCount())
 }
 
+// TestGeometryTypeFiltering tests the parse-time filter and FeaturesOfType
+func TestGeometryTypeFiltering(t *testing.T) {
+	parser := NewParser()
+
+	full, err := parser.ParseWithOptions(testChartPath, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	for _, geomType := range []GeometryType{GeometryTypePoint, GeometryTypeLineString, GeometryTypePolygon} {
+		t.Run(geomType.String(), func(t *testing.T) {
+			ofType := full.FeaturesOfType(geomType)
+			if len(ofType) == 0 {
+				t.Fatalf("Expected %s features in test chart", geomType)
+			}
+			for _, f := range ofType {
+				if f.Geometry().Type != geomType &&
+					!(geomType == GeometryTypeLineString && f.Geometry().Type == GeometryTypeMultiLineString) {
+					t.Fatalf("FeaturesOfType(%s) returned %s", geomType, f.Geometry().Type)
+				}
+			}
+
+			// Both LineString selections also keep MultiLineString features
+			if geomType == GeometryTypeLineString {
+				multi := len(full.FeaturesOfType(GeometryTypeMultiLineString))
+				if multi == 0 {
+					t.Fatal("Expected MultiLineString features in test chart")
+				}
+				lines := 0
+				for _, f := range ofType {
+					if f.Geometry().Type == GeometryTypeLineString {
+						lines++
+					}
+				}
+				if lines+multi != len(ofType) {
+					t.Errorf("FeaturesOfType(LineString) returned %d features, expected %d lines and %d multilines",
+						len(ofType), lines, multi)
+				}
+			}
+			expected := len(ofType)
+
+			opts := DefaultParseOptions()
+			opts.GeometryTypeFilter = []GeometryType{geomType}
+			filtered, err := parser.ParseWithOptions(testChartPath, opts)
+			if err != nil {
+				t.Fatalf("Failed to parse with filter: %v", err)
+			}
+			if filtered.FeatureCount() != expected {
+				t.Errorf("Parse-time filter kept %d features, expected %d",
+					filtered.FeatureCount(), expected)
+			}
+			for _, f := range filtered.Features() {
+				if f.Geometry().Type != geomType &&
+					!(geomType == GeometryTypeLineString && f.Geometry().Type == GeometryTypeMultiLineString) {
+					t.Fatalf("Filter kept %s feature %d", f.Geometry().Type, f.ID())
+				}
+			}
+		})
+	}
+}
+
 // TestUsageBand tests ENC usage band classification
 // S-57 Appendix B.1: Navigational Purpose
 func TestUsageBand(t *testing.T) {
@@ -334,6 +501,57 @@ func TestBoundsOperations(t *testing.T) {
 	if b1.Contains(-69.0, 44.0) {
 		t.Error("b1 should not contain point (-69.0, 44.0)")
 	}
+
+	// Test ContainsBounds
+	if !b1.Expand(0.1).ContainsBounds(b1) {
+		t.Error("Expanded b1 should contain b1")
+	}
+	if b1.ContainsBounds(b2) {
+		t.Error("b1 should not contain b2")
+	}
+}
+
+// TestBoundsIntersectionArea tests bounds area and overlap
+func TestBoundsIntersectionArea(t *testing.T) {
+	square := Bounds{MinLon: -71.0, MaxLon: -70.0, MinLat: 42.0, MaxLat: 43.0}
+	dateline := Bounds{MinLon: 179.0, MaxLon: -179.0, MinLat: 10.0, MaxLat: 11.0}
+
+	tests := []struct {
+		name     string
+		a, b     Bounds
+		expected float64
+	}{
+		{"Partial overlap", square, Bounds{MinLon: -70.5, MaxLon: -69.5, MinLat: 42.5, MaxLat: 43.5}, 0.25},
+		{"Contained", square, Bounds{MinLon: -70.75, MaxLon: -70.25, MinLat: 42.25, MaxLat: 42.75}, 0.25},
+		{"Identical", square, square, 1},
+		{"Disjoint", square, Bounds{MinLon: -69.0, MaxLon: -68.0, MinLat: 44.0, MaxLat: 45.0}, 0},
+		{"Touching edge", square, Bounds{MinLon: -70.0, MaxLon: -69.0, MinLat: 42.0, MaxLat: 43.0}, 0},
+		{"Antimeridian east half", dateline, Bounds{MinLon: 179.5, MaxLon: 180.0, MinLat: 10.0, MaxLat: 11.0}, 0.5},
+		{"Antimeridian west half", dateline, Bounds{MinLon: -180.0, MaxLon: -178.0, MinLat: 10.0, MaxLat: 11.0}, 1},
+		{"Both cross antimeridian", dateline, Bounds{MinLon: 179.5, MaxLon: -179.5, MinLat: 10.5, MaxLat: 12.0}, 0.5},
+		{"Antimeridian disjoint", dateline, Bounds{MinLon: 0, MaxLon: 10, MinLat: 10.0, MaxLat: 11.0}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.IntersectionArea(tt.b); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("IntersectionArea = %g, expected %g", got, tt.expected)
+			}
+			if got := tt.b.IntersectionArea(tt.a); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("Reversed IntersectionArea = %g, expected %g", got, tt.expected)
+			}
+		})
+	}
+
+	if got := square.Area(); math.Abs(got-1) > 1e-9 {
+		t.Errorf("Area = %g, expected 1", got)
+	}
+	if got := dateline.Area(); math.Abs(got-2) > 1e-9 {
+		t.Errorf("Antimeridian Area = %g, expected 2", got)
+	}
+	if got := (Bounds{}).Area(); got != 0 {
+		t.Errorf("Empty Area = %g, expected 0", got)
+	}
 }
 
 // TestGeometryTypeString tests geometry type string conversion
@@ -345,6 +563,7 @@ func TestGeometryTypeString(t *testing.T) {
 		{GeometryTypePoint, "Point"},
 		{GeometryTypeLineString, "LineString"},
 		{GeometryTypePolygon, "Polygon"},
+		{GeometryTypeMultiLineString, "MultiLineString"},
 	}
 
 	for _, tt := range tests {
@@ -354,3 +573,926 @@ func TestGeometryTypeString(t *testing.T) {
 		}
 	}
 }
+
+// TestRetainRawFields tests opt-in access to raw ISO 8211 feature fields
+func TestRetainRawFields(t *testing.T) {
+	parser := NewParser()
+
+	chart, err := parser.ParseWithOptions(testChartPath, ParseOptions{RetainRawFields: true})
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	f := chart.Features()[0]
+	fields, ok := chart.RawRecord(f.FeatureID())
+	if !ok {
+		t.Fatal("Expected raw fields to be retained")
+	}
+	if _, ok := fields["FRID"]; !ok {
+		t.Error("Raw fields should include FRID")
+	}
+
+	// Raw fields are not retained by default
+	chart, err = parser.Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+	if _, ok := chart.RawRecord(chart.Features()[0].FeatureID()); ok {
+		t.Error("Raw fields should not be retained by default")
+	}
+}
+
+// TestPointEpsilonHighLatitude tests that padded point bounds stay roughly
+// square in meters at high latitudes
+func TestPointEpsilonHighLatitude(t *testing.T) {
+	const metersPerDegree = 111320.0
+
+	for _, lat := range []float64{0, 45, 70} {
+		indexed := &indexedFeature{bounds: Bounds{MinLon: 20, MaxLon: 20, MinLat: lat, MaxLat: lat}}
+		rect := indexed.Bounds()
+
+		widthMeters := rect.LengthsCoord(0) * metersPerDegree * math.Cos(lat*math.Pi/180)
+		heightMeters := rect.LengthsCoord(1) * metersPerDegree
+
+		ratio := widthMeters / heightMeters
+		if ratio < 0.95 || ratio > 1.05 {
+			t.Errorf("lat %.0f: padded box %.1fm x %.1fm is not square (ratio %.2f)",
+				lat, widthMeters, heightMeters, ratio)
+		}
+	}
+}
+
+// BenchmarkSpatialIndexNodeSize sweeps R-tree fan-out on the test cell
+func BenchmarkSpatialIndexNodeSize(b *testing.B) {
+	parser := NewParser()
+	chart, err := parser.Parse(testChartPath)
+	if err != nil {
+		b.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	viewport := viewportFraction(chart, 0.4, 0.6)
+
+	for _, size := range []struct{ min, max int }{{4, 8}, {10, 20}, {25, 50}, {50, 100}} {
+		b.Run(fmt.Sprintf("build/%d-%d", size.min, size.max), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c := &Chart{features: chart.features, rtreeMinChildren: size.min, rtreeMaxChildren: size.max}
+				c.buildSpatialIndex()
+			}
+		})
+
+		c := &Chart{features: chart.features, rtreeMinChildren: size.min, rtreeMaxChildren: size.max}
+		c.buildSpatialIndex()
+		b.Run(fmt.Sprintf("query/%d-%d", size.min, size.max), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c.FeaturesInBounds(viewport)
+			}
+		})
+	}
+}
+
+// BenchmarkFeaturesInBoundsLinear measures the no-index fallback for a
+// whole-chart viewport and a partial one
+func BenchmarkFeaturesInBoundsLinear(b *testing.B) {
+	chart, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		b.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	viewports := map[string]Bounds{
+		"whole-chart": chart.Bounds().Expand(0.01),
+		"partial":     viewportFraction(chart, 0.4, 0.6),
+	}
+
+	for name, viewport := range viewports {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				chart.featuresInBoundsLinear(viewport)
+			}
+		})
+	}
+}
+
+// TestEachFeatureInBounds tests callback iteration matches the slice API
+func TestEachFeatureInBounds(t *testing.T) {
+	parser := NewParser()
+	chart, err := parser.Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	viewport := viewportFraction(chart, 0.25, 0.75)
+
+	visited := 0
+	chart.EachFeatureInBounds(viewport, func(Feature) bool {
+		visited++
+		return true
+	})
+	if expected := len(chart.FeaturesInBounds(viewport)); visited != expected {
+		t.Errorf("Expected %d features, visited %d", expected, visited)
+	}
+
+	// Returning false stops iteration
+	visited = 0
+	chart.EachFeatureInBounds(viewport, func(Feature) bool {
+		visited++
+		return visited < 3
+	})
+	if visited != 3 {
+		t.Errorf("Expected iteration to stop after 3 features, visited %d", visited)
+	}
+
+	visited = 0
+	chart.EachFeature(func(Feature) bool {
+		visited++
+		return true
+	})
+	if visited != chart.FeatureCount() {
+		t.Errorf("Expected %d features, visited %d", chart.FeatureCount(), visited)
+	}
+}
+
+// TestFeaturesInBoundsChunked tests batched delivery matches FeaturesInBounds
+func TestFeaturesInBoundsChunked(t *testing.T) {
+	chart, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	viewport := chart.Bounds()
+	expected := len(chart.FeaturesInBounds(viewport))
+	if expected < 10 {
+		t.Fatalf("Expected a populated chart, got %d features", expected)
+	}
+
+	const chunkSize = 7
+	total, chunks := 0, 0
+	seen := make(map[FeatureID]bool)
+	chart.FeaturesInBoundsChunked(viewport, chunkSize, func(batch []Feature) bool {
+		if len(batch) == 0 || len(batch) > chunkSize {
+			t.Errorf("Chunk %d has %d features, expected 1..%d", chunks, len(batch), chunkSize)
+		}
+		for _, f := range batch {
+			seen[f.FeatureID()] = true
+		}
+		total += len(batch)
+		chunks++
+		return true
+	})
+	if total != expected {
+		t.Errorf("Expected %d features in chunks, got %d", expected, total)
+	}
+	if want := (expected + chunkSize - 1) / chunkSize; chunks != want {
+		t.Errorf("Expected %d chunks, got %d", want, chunks)
+	}
+	if len(seen) != expected {
+		t.Errorf("Chunks delivered %d distinct features, expected %d", len(seen), expected)
+	}
+
+	// Returning false stops after the current chunk
+	chunks = 0
+	chart.FeaturesInBoundsChunked(viewport, chunkSize, func([]Feature) bool {
+		chunks++
+		return false
+	})
+	if chunks != 1 {
+		t.Errorf("Expected 1 chunk before stopping, got %d", chunks)
+	}
+}
+
+// TestFeaturesInBoundsWithStats tests query statistics and the exact bounds filter
+func TestFeaturesInBoundsWithStats(t *testing.T) {
+	opts := DefaultParseOptions()
+	chart, err := NewParser().ParseWithOptions(testChartPath, opts)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	viewport := viewportFraction(chart, 0.4, 0.6)
+
+	features, stats := chart.FeaturesInBoundsWithStats(viewport)
+	if stats.Returned != len(features) || stats.Candidates < stats.Returned {
+		t.Errorf("Inconsistent stats %+v for %d features", stats, len(features))
+	}
+	if stats.ExactFilter {
+		t.Error("Exact filter should be off by default")
+	}
+	if len(features) != len(chart.FeaturesInBounds(viewport)) {
+		t.Error("FeaturesInBoundsWithStats and FeaturesInBounds disagree")
+	}
+
+	opts.ExactBoundsQueries = true
+	exact, err := NewParser().ParseWithOptions(testChartPath, opts)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+	features, stats = exact.FeaturesInBoundsWithStats(viewport)
+	if !stats.ExactFilter || stats.Candidates < stats.Returned {
+		t.Errorf("Unexpected exact stats %+v", stats)
+	}
+	for _, f := range features {
+		if !viewport.Intersects(featureBounds(f)) {
+			t.Errorf("Feature %d outside the viewport returned with exact filter", f.ID())
+		}
+	}
+	visited := 0
+	exact.EachFeatureInBounds(viewport, func(Feature) bool {
+		visited++
+		return true
+	})
+	if visited != stats.Returned {
+		t.Errorf("EachFeatureInBounds visited %d, expected %d", visited, stats.Returned)
+	}
+	t.Logf("%d of %d candidates returned", stats.Returned, stats.Candidates)
+}
+
+// TestFeaturesInBoundsExact tests that the exact query drops padded point features
+func TestFeaturesInBoundsExact(t *testing.T) {
+	point := func(lon, lat float64) Geometry {
+		return Geometry{Type: GeometryTypePoint, Coordinates: [][]float64{{lon, lat}}}
+	}
+	viewport := Bounds{MinLon: -76.5, MaxLon: -76.4, MinLat: 38.9, MaxLat: 39.0}
+
+	// Feature 2 lies ~5 m west of the viewport, inside the R-tree point padding
+	chart := newTestChart([]Feature{
+		{id: 1, objectClass: "LIGHTS", geometry: point(-76.45, 38.95)},
+		{id: 2, objectClass: "BOYLAT", geometry: point(-76.50005, 38.95)},
+		{id: 3, objectClass: "BOYLAT", geometry: point(-76.3, 38.95)},
+	})
+
+	// The padded rectangle of feature 2 reaches into the viewport
+	if loose := chart.FeaturesInBounds(viewport); len(loose) != 2 {
+		t.Errorf("Expected the loose query to return 2 features, got %d", len(loose))
+	}
+
+	exact := chart.FeaturesInBoundsExact(viewport)
+	if len(exact) != 1 || exact[0].ID() != 1 {
+		ids := make([]int64, len(exact))
+		for i, f := range exact {
+			ids[i] = f.ID()
+		}
+		t.Errorf("Expected only feature 1 from the exact query, got %v", ids)
+	}
+}
+
+// TestChartSummary tests the summary counts against a manual tally
+func TestChartSummary(t *testing.T) {
+	parser := NewParser()
+	chart, err := parser.Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	summary := chart.Summary()
+
+	if summary.DatasetName != chart.DatasetName() {
+		t.Errorf("Expected dataset name %s, got %s", chart.DatasetName(), summary.DatasetName)
+	}
+	if summary.FeatureCount != chart.FeatureCount() {
+		t.Errorf("Expected %d features, got %d", chart.FeatureCount(), summary.FeatureCount)
+	}
+
+	// Manual tally
+	counts := make(map[string]int)
+	for _, f := range chart.Features() {
+		counts[f.ObjectClass()]++
+	}
+
+	if len(counts) != len(summary.ObjectClassCounts) {
+		t.Errorf("Expected %d object classes, got %d", len(counts), len(summary.ObjectClassCounts))
+	}
+	for class, count := range counts {
+		if summary.ObjectClassCounts[class] != count {
+			t.Errorf("%s: expected %d, got %d", class, count, summary.ObjectClassCounts[class])
+		}
+	}
+
+	total := 0
+	for _, count := range summary.GeometryTypeCounts {
+		total += count
+	}
+	if total != summary.FeatureCount {
+		t.Errorf("Geometry type counts sum to %d, expected %d", total, summary.FeatureCount)
+	}
+
+	// NOAA cells use the standard scaling factors
+	if chart.CoordinateFactor() != 10000000 || chart.SoundingFactor() != 10 {
+		t.Errorf("Expected COMF 10^7 and SOMF 10, got %d and %d", chart.CoordinateFactor(), chart.SoundingFactor())
+	}
+	if summary.CoordinateFactor != 10000000 || summary.SoundingFactor != 10 {
+		t.Errorf("Summary factors = %d, %d", summary.CoordinateFactor, summary.SoundingFactor)
+	}
+	if summary.CoordinateUnits != CoordinateUnitsLatLon.String() || summary.UsedDefaultParameters {
+		t.Errorf("Summary units = %q, defaulted = %v", summary.CoordinateUnits, summary.UsedDefaultParameters)
+	}
+}
+
+// TestSoundingStyles tests classification of soundings against a safety depth
+func TestSoundingStyles(t *testing.T) {
+	soundg := Feature{
+		objectClass: "SOUNDG",
+		geometry: Geometry{
+			Type: GeometryTypePoint,
+			Coordinates: [][]float64{
+				{-76.1, 38.1, 2.4},
+				{-76.2, 38.2, 6.0},
+				{-76.3, 38.3, 6.1},
+				{-76.4, 38.4, 15.0},
+			},
+		},
+	}
+
+	styles := soundg.SoundingStyles(6.0)
+	if len(styles) != 4 {
+		t.Fatalf("Expected 4 sounding styles, got %d", len(styles))
+	}
+
+	expected := []bool{true, true, false, false}
+	for i, style := range styles {
+		if style.Shallow != expected[i] {
+			t.Errorf("Sounding %.1fm: expected shallow=%v, got %v",
+				style.Depth, expected[i], style.Shallow)
+		}
+	}
+	if styles[2].Lon != -76.3 || styles[2].Lat != 38.3 {
+		t.Errorf("Sounding styles out of coordinate order: %+v", styles[2])
+	}
+
+	lights := Feature{objectClass: "LIGHTS", geometry: soundg.geometry}
+	if styles := lights.SoundingStyles(6.0); styles != nil {
+		t.Errorf("Expected nil styles for non-SOUNDG feature, got %v", styles)
+	}
+}
+
+// TestBearing tests line direction from ORIENT or vertex order
+func TestBearing(t *testing.T) {
+	northward := Feature{
+		objectClass: "FAIRWY",
+		geometry: Geometry{
+			Type:        GeometryTypeLineString,
+			Coordinates: [][]float64{{-76.5, 38.0}, {-76.5, 38.1}, {-76.5, 38.2}},
+		},
+	}
+	if bearing, ok := northward.Bearing(); !ok || math.Abs(bearing) > 1e-9 {
+		t.Errorf("Expected bearing 0 for northward line, got %v (ok=%v)", bearing, ok)
+	}
+
+	southward := northward
+	southward.geometry.Coordinates = [][]float64{{-76.5, 38.2}, {-76.5, 38.1}, {-76.5, 38.0}}
+	if bearing, ok := southward.Bearing(); !ok || math.Abs(bearing-180) > 1e-9 {
+		t.Errorf("Expected bearing 180 for southward line, got %v (ok=%v)", bearing, ok)
+	}
+
+	// ORIENT takes precedence over the vertex order
+	lane := southward
+	lane.objectClass = "TSSLPT"
+	lane.attributes = map[string]interface{}{"ORIENT": "45"}
+	if bearing, ok := lane.Bearing(); !ok || bearing != 45 {
+		t.Errorf("Expected ORIENT bearing 45, got %v (ok=%v)", bearing, ok)
+	}
+
+	for _, geomType := range []GeometryType{GeometryTypePoint, GeometryTypePolygon} {
+		f := northward
+		f.geometry.Type = geomType
+		if _, ok := f.Bearing(); ok {
+			t.Errorf("Expected no bearing for %v", geomType)
+		}
+	}
+}
+
+// TestUpdateDateTime tests parsing of S-57 dates into time.Time
+func TestUpdateDateTime(t *testing.T) {
+	chart := &Chart{issueDate: "20231115", updateDate: "20240101"}
+
+	updated, ok := chart.UpdateDateTime()
+	if !ok {
+		t.Fatal("Expected update date to parse")
+	}
+	if want := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); !updated.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, updated)
+	}
+
+	if !chart.IsCurrentAsOf(time.Date(2024, 1, 1, 18, 30, 0, 0, time.UTC)) {
+		t.Error("Chart updated on the cutoff date should be current")
+	}
+	if chart.IsCurrentAsOf(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Chart updated before the cutoff should not be current")
+	}
+
+	// Falls back to the issue date
+	chart.updateDate = ""
+	if issued, ok := chart.UpdateDateTime(); !ok || issued.Day() != 15 {
+		t.Errorf("Expected fallback to issue date, got %v (ok=%v)", issued, ok)
+	}
+
+	for _, bad := range []string{"2024011", "2024-01-01", "20241301", "abcdefgh"} {
+		chart.issueDate = bad
+		if _, ok := chart.UpdateDateTime(); ok {
+			t.Errorf("Expected malformed date %q to be rejected", bad)
+		}
+	}
+	if chart.IsCurrentAsOf(time.Time{}) {
+		t.Error("Chart with malformed date should never be current")
+	}
+}
+
+// TestRetainTopology tests access to the edge/node graph
+func TestRetainTopology(t *testing.T) {
+	parser := NewParser()
+
+	chart, err := parser.Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+	if chart.Topology() != nil {
+		t.Error("Topology should not be retained by default")
+	}
+
+	opts := DefaultParseOptions()
+	opts.RetainTopology = true
+	chart, err = parser.ParseWithOptions(testChartPath, opts)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	topo := chart.Topology()
+	if topo == nil || len(topo.Edges) == 0 || len(topo.Nodes) == 0 {
+		t.Fatal("Expected edges and nodes in retained topology")
+	}
+
+	nodes := make(map[int64]TopologyNode, len(topo.Nodes))
+	for _, node := range topo.Nodes {
+		if node.Connected {
+			nodes[node.ID] = node
+		}
+	}
+
+	for _, edge := range topo.Edges {
+		if len(edge.Coordinates) < 2 {
+			t.Errorf("Edge %d has %d coordinates", edge.ID, len(edge.Coordinates))
+		}
+		start, ok := nodes[edge.StartNode]
+		if !ok {
+			continue
+		}
+		if !start.Connected {
+			t.Errorf("Edge %d starts at isolated node %d", edge.ID, start.ID)
+		}
+		found := false
+		for _, id := range start.Edges {
+			if id == edge.ID {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Node %d does not list edge %d", start.ID, edge.ID)
+		}
+		first := start.Coordinates[0]
+		if edge.Coordinates[0][0] != first[0] || edge.Coordinates[0][1] != first[1] {
+			t.Errorf("Edge %d does not begin at its start node", edge.ID)
+		}
+	}
+}
+
+// TestAgencyName tests the producing agency lookup
+func TestAgencyName(t *testing.T) {
+	if name, ok := AgencyName(550); !ok || name != "NOAA (US)" {
+		t.Errorf("Expected NOAA (US) for 550, got %q (ok=%v)", name, ok)
+	}
+	if name, ok := AgencyName(540); !ok || name != "UKHO (GB)" {
+		t.Errorf("Expected UKHO (GB) for 540, got %q (ok=%v)", name, ok)
+	}
+	if _, ok := AgencyName(9999); ok {
+		t.Error("Expected unknown agency code to return ok=false")
+	}
+
+	for code, want := range map[string]string{
+		"FR": "SHOM (FR)", "DE": "BSH (DE)", "CA": "CHS (CA)", "AU": "AHO (AU)",
+		"JP": "JHOD (JP)", "NO": "NHS (NO)", "SE": "SMA (SE)", "DK": "DGA (DK)",
+	} {
+		if name, ok := ProducerName(code); !ok || name != want {
+			t.Errorf("Expected %s for producer %s, got %q (ok=%v)", want, code, name, ok)
+		}
+	}
+	if _, ok := ProducerName("ZZ"); ok {
+		t.Error("Expected unknown producer code to return ok=false")
+	}
+
+	// Charts with an AGEN missing from the table fall back to the cell name
+	french := &Chart{datasetName: "FR501130", producingAgency: 9999}
+	if name, ok := french.ProducingAgencyName(); !ok || name != "SHOM (FR)" {
+		t.Errorf("Expected SHOM (FR) from the cell name, got %q (ok=%v)", name, ok)
+	}
+	if _, ok := (&Chart{datasetName: "ZZ501130", producingAgency: 9999}).ProducingAgencyName(); ok {
+		t.Error("Expected unknown agency and producer to return ok=false")
+	}
+
+	chart := &Chart{producingAgency: 550}
+	if name, ok := chart.ProducingAgencyName(); !ok || name != "NOAA (US)" {
+		t.Errorf("Expected chart agency NOAA (US), got %q", name)
+	}
+}
+
+// TestParseUpdateFileAsBase tests that an update file is rejected by Parse
+func TestParseUpdateFileAsBase(t *testing.T) {
+	_, err := NewParser().Parse("../../test/US4MD81M/US4MD81M.001")
+	if err == nil {
+		t.Fatal("Expected error parsing an update file as a base cell")
+	}
+
+	var notBase *ErrNotABaseCell
+	if !errors.As(err, &notBase) {
+		t.Fatalf("Expected ErrNotABaseCell, got %T: %v", err, err)
+	}
+	if notBase.UpdateNumber != "1" {
+		t.Errorf("Expected update number 1, got %q", notBase.UpdateNumber)
+	}
+}
+
+// TestStructuredErrors tests that parse failures can be identified with errors.Is
+func TestStructuredErrors(t *testing.T) {
+	parser := NewParser()
+	dir := t.TempDir()
+
+	// Missing file keeps the os error so os.IsNotExist works
+	_, err := parser.Parse(filepath.Join(dir, "MISSING.000"))
+	if !os.IsNotExist(err) || !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected not-exist error, got %v", err)
+	}
+
+	// Arbitrary bytes are not S-57
+	junk := filepath.Join(dir, "JUNK.000")
+	if err := os.WriteFile(junk, []byte("this is definitely not an ISO 8211 file"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.Parse(junk); !errors.Is(err, ErrNotS57) {
+		t.Errorf("Expected ErrNotS57, got %v", err)
+	}
+
+	// Drop the DSID record (the first data record after the DDR)
+	data, err := os.ReadFile(testChartPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordLength := func(offset int) int {
+		n, err := strconv.Atoi(string(data[offset : offset+5]))
+		if err != nil {
+			t.Fatalf("Bad record leader at %d: %v", offset, err)
+		}
+		return n
+	}
+	ddrEnd := recordLength(0)
+	dsidEnd := ddrEnd + recordLength(ddrEnd)
+	noDSID := filepath.Join(dir, "NODSID.000")
+	stripped := append(append([]byte{}, data[:ddrEnd]...), data[dsidEnd:]...)
+	if err := os.WriteFile(noDSID, stripped, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.Parse(noDSID); !errors.Is(err, ErrMissingDSID) {
+		t.Errorf("Expected ErrMissingDSID, got %v", err)
+	}
+}
+
+// TestStructureInfo compares the DSSI declared counts with the parsed base cell
+func TestStructureInfo(t *testing.T) {
+	// Base cell only, so the declared counts apply
+	chart, err := NewParser().ParseWithOptions(testChartPath, ParseOptions{RetainTopology: true})
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	info := chart.StructureInfo()
+	if info.DataStructure != 2 {
+		t.Errorf("Expected chain-node topology (DSTR=2), got %d", info.DataStructure)
+	}
+	if info.FeatureRecords() != chart.FeatureCount() {
+		t.Errorf("DSSI declares %d feature records, parsed %d", info.FeatureRecords(), chart.FeatureCount())
+	}
+
+	parsed := make(map[ObjectType]int)
+	for _, f := range chart.Features() {
+		meta, _ := ObjectClassInfo(f.ObjectClass())
+		parsed[meta.Type]++
+	}
+	declared := map[ObjectType]int{
+		ObjectTypeMeta:         info.MetaRecords,
+		ObjectTypeCartographic: info.CartographicRecords,
+		ObjectTypeGeo:          info.GeoRecords,
+		ObjectTypeCollection:   info.CollectionRecords,
+	}
+	for objType, count := range declared {
+		if parsed[objType] != count {
+			t.Errorf("%s: DSSI declares %d records, parsed %d", objType, count, parsed[objType])
+		}
+	}
+
+	if edges := len(chart.Topology().Edges); edges != info.Edges {
+		t.Errorf("DSSI declares %d edges, topology has %d", info.Edges, edges)
+	}
+	if nodes := len(chart.Topology().Nodes); nodes != info.IsolatedNodes+info.ConnectedNodes {
+		t.Errorf("DSSI declares %d nodes, topology has %d", info.IsolatedNodes+info.ConnectedNodes, nodes)
+	}
+}
+
+// TestObjectClasses tests the set of object classes present in a chart
+func TestObjectClasses(t *testing.T) {
+	chart, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	var expected []string
+	for _, f := range chart.Features() {
+		if !seen[f.ObjectClass()] {
+			seen[f.ObjectClass()] = true
+			expected = append(expected, f.ObjectClass())
+		}
+	}
+	sort.Strings(expected)
+
+	classes := chart.ObjectClasses()
+	if !reflect.DeepEqual(classes, expected) {
+		t.Errorf("ObjectClasses() = %v, expected %v", classes, expected)
+	}
+
+	// The cached set is not exposed to callers
+	classes[0] = "XXXXXX"
+	if chart.ObjectClasses()[0] != expected[0] {
+		t.Error("Modifying the returned slice changed the chart's object classes")
+	}
+
+	if !chart.HasObjectClass("DEPARE") {
+		t.Error("Expected chart to have DEPARE")
+	}
+	if chart.HasObjectClass("XXXXXX") {
+		t.Error("Expected chart not to have XXXXXX")
+	}
+}
+
+// TestRelease tests that a released chart returns empty results without panicking
+func TestRelease(t *testing.T) {
+	parser := NewParser()
+	chart, err := parser.Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	bounds := chart.Bounds()
+	name := chart.DatasetName()
+	kept := chart.Features()[0]
+
+	chart.Release()
+	chart.Release() // Idempotent
+
+	if !chart.Released() {
+		t.Error("Released() = false after Release")
+	}
+	if n := chart.FeatureCount(); n != 0 {
+		t.Errorf("FeatureCount() = %d after Release, want 0", n)
+	}
+	if got := chart.FeaturesInBounds(bounds); len(got) != 0 {
+		t.Errorf("FeaturesInBounds returned %d features after Release", len(got))
+	}
+	if got := chart.FeaturesInBoundsExact(bounds); len(got) != 0 {
+		t.Errorf("FeaturesInBoundsExact returned %d features after Release", len(got))
+	}
+	chart.EachFeatureInBounds(bounds, func(Feature) bool {
+		t.Error("EachFeatureInBounds visited a feature after Release")
+		return false
+	})
+	if got := chart.KNearestFeatures(bounds.MinLon, bounds.MinLat, 5); len(got) != 0 {
+		t.Errorf("KNearestFeatures returned %d features after Release", len(got))
+	}
+	if got := chart.ObjectClasses(); len(got) != 0 {
+		t.Errorf("ObjectClasses() = %v after Release", got)
+	}
+	if chart.HasObjectClass(kept.ObjectClass()) {
+		t.Errorf("HasObjectClass(%q) = true after Release", kept.ObjectClass())
+	}
+	if _, err := chart.Resource("US4MD81A.TXT"); !errors.Is(err, ErrChartReleased) {
+		t.Errorf("Resource error = %v, want ErrChartReleased", err)
+	}
+
+	// Metadata and previously obtained features remain usable
+	if chart.DatasetName() != name {
+		t.Errorf("DatasetName() = %q after Release, want %q", chart.DatasetName(), name)
+	}
+	if len(kept.Geometry().Coordinates) == 0 {
+		t.Error("Feature obtained before Release lost its geometry")
+	}
+}
+
+// TestFeaturesInBoundsOfClass tests class-filtered queries match filtering FeaturesInBounds
+func TestFeaturesInBoundsOfClass(t *testing.T) {
+	parser := NewParser()
+	chart, err := parser.Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	viewport := viewportFraction(chart, 0.3, 0.7)
+
+	ids := func(features []Feature) []int64 {
+		result := make([]int64, 0, len(features))
+		for _, f := range features {
+			result = append(result, f.ID())
+		}
+		sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+		return result
+	}
+
+	for _, classes := range [][]string{{"DEPCNT"}, {"SOUNDG", "DEPARE"}, {"DEPARE", "DEPARE"}, {"XXXXXX"}} {
+		wanted := make(map[string]bool)
+		for _, class := range classes {
+			wanted[class] = true
+		}
+		var expected []Feature
+		for _, f := range chart.FeaturesInBounds(viewport) {
+			if wanted[f.ObjectClass()] {
+				expected = append(expected, f)
+			}
+		}
+
+		// Twice: building the class index, then reusing it
+		for pass := 0; pass < 2; pass++ {
+			got := chart.FeaturesInBoundsOfClass(viewport, classes...)
+			if !reflect.DeepEqual(ids(got), ids(expected)) {
+				t.Errorf("%v pass %d: got %d features, expected %d", classes, pass, len(got), len(expected))
+			}
+		}
+	}
+
+	if got := chart.FeaturesInBoundsOfClass(viewport); len(got) != 0 {
+		t.Errorf("No classes: got %d features, expected 0", len(got))
+	}
+
+	chart.Release()
+	if got := chart.FeaturesInBoundsOfClass(viewport, "DEPCNT"); len(got) != 0 {
+		t.Errorf("After Release: got %d features, expected 0", len(got))
+	}
+}
+
+// BenchmarkFeaturesInBoundsOfClass compares per-class indexes with filtering FeaturesInBounds
+func BenchmarkFeaturesInBoundsOfClass(b *testing.B) {
+	parser := NewParser()
+	chart, err := parser.Parse(testChartPath)
+	if err != nil {
+		b.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	viewport := viewportFraction(chart, 0.2, 0.8)
+
+	b.Run("filter", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var result []Feature
+			for _, f := range chart.FeaturesInBounds(viewport) {
+				if f.ObjectClass() == "DEPCNT" {
+					result = append(result, f)
+				}
+			}
+		}
+	})
+
+	chart.FeaturesInBoundsOfClass(viewport, "DEPCNT") // Build the class index
+	b.Run("class-index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			chart.FeaturesInBoundsOfClass(viewport, "DEPCNT")
+		}
+	})
+}
+
+// TestNewParserWithCatalogue tests catalogue validation and parsing with a catalogue
+func TestNewParserWithCatalogue(t *testing.T) {
+	cat := NewCatalogue()
+	if err := cat.AddObjectClass(ObjectClassMetadata{Code: 17050, Acronym: "wtwaxs", Type: ObjectTypeGeo}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cat.AddObjectClass(ObjectClassMetadata{Code: 17051}); err == nil {
+		t.Error("Expected an error for an object class without an acronym")
+	}
+	if err := cat.AddAttribute(0, "catbrt"); err == nil {
+		t.Error("Expected an error for attribute code 0")
+	}
+
+	// Standard charts parse the same with a catalogue
+	chart, err := NewParserWithCatalogue(cat).Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+	plain, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+	if chart.FeatureCount() != plain.FeatureCount() {
+		t.Errorf("Expected %d features, got %d", plain.FeatureCount(), chart.FeatureCount())
+	}
+}
+
+// TestParseAll tests reading a synthetic file that bundles two datasets
+func TestParseAll(t *testing.T) {
+	data, err := os.ReadFile(testChartPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ddrLength, err := strconv.Atoi(string(data[:5]))
+	if err != nil {
+		t.Fatalf("Invalid DDR leader: %v", err)
+	}
+
+	// The test cell's records twice behind one DDR: two DSID records
+	path := filepath.Join(t.TempDir(), "MULTI.000")
+	if err := os.WriteFile(path, append(append([]byte{}, data...), data[ddrLength:]...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	parser := NewParser()
+	if _, err := parser.Parse(path); !errors.Is(err, ErrMultipleDatasets) {
+		t.Fatalf("Expected ErrMultipleDatasets, got %v", err)
+	}
+
+	charts, err := parser.ParseAll(path, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseAll failed: %v", err)
+	}
+	if len(charts) != 2 {
+		t.Fatalf("Expected 2 charts, got %d", len(charts))
+	}
+	if charts[0].FeatureCount() == 0 || charts[0].FeatureCount() != charts[1].FeatureCount() {
+		t.Errorf("Expected equal non-zero feature counts, got %d and %d",
+			charts[0].FeatureCount(), charts[1].FeatureCount())
+	}
+}
+
+// TestExcludeMetaFeatures tests dropping meta and collection objects from the feature list
+func TestExcludeMetaFeatures(t *testing.T) {
+	opts := DefaultParseOptions()
+	all, err := NewParser().ParseWithOptions(testChartPath, opts)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+	meta := 0
+	for _, f := range all.Features() {
+		if info, ok := ObjectClassInfo(f.ObjectClass()); ok &&
+			(info.Type == ObjectTypeMeta || info.Type == ObjectTypeCollection) {
+			meta++
+		}
+	}
+	if meta == 0 {
+		t.Fatal("Expected meta features in test chart")
+	}
+
+	opts.ExcludeMetaFeatures = true
+	geo, err := NewParser().ParseWithOptions(testChartPath, opts)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+	if geo.FeatureCount() != all.FeatureCount()-meta {
+		t.Errorf("Expected %d features without %d meta features, got %d",
+			all.FeatureCount()-meta, meta, geo.FeatureCount())
+	}
+	for _, f := range geo.Features() {
+		if isMetaClass(f.ObjectClass()) {
+			t.Errorf("Meta feature %s %d left in the feature list", f.ObjectClass(), f.ID())
+		}
+	}
+	if len(geo.FeaturesInBoundsOfClass(geo.Bounds(), "M_COVR")) != 0 {
+		t.Error("M_COVR still in the spatial index")
+	}
+
+	// M_COVR still defines the coverage
+	if geo.Bounds() != all.Bounds() {
+		t.Errorf("Bounds changed: %+v, expected %+v", geo.Bounds(), all.Bounds())
+	}
+}
+
+// TestMetaFeatures tests the meta and collection object accessor
+func TestMetaFeatures(t *testing.T) {
+	for _, exclude := range []bool{false, true} {
+		opts := DefaultParseOptions()
+		opts.ExcludeMetaFeatures = exclude
+		chart, err := NewParser().ParseWithOptions(testChartPath, opts)
+		if err != nil {
+			t.Fatalf("Failed to parse chart: %v", err)
+		}
+
+		classes := make(map[string]int)
+		for _, f := range chart.MetaFeatures() {
+			classes[f.ObjectClass()]++
+		}
+		if classes["M_COVR"] == 0 {
+			t.Errorf("exclude=%v: expected M_COVR in MetaFeatures, got %v", exclude, classes)
+		}
+		if classes["DEPARE"] != 0 {
+			t.Errorf("exclude=%v: DEPARE is not a meta class", exclude)
+		}
+	}
+
+	// Classification follows the catalogue, not the acronym
+	if isMetaClass("M_XXXX") {
+		t.Error("Unknown M_ class should not be treated as meta")
+	}
+	if !isMetaClass("C_AGGR") || !isMetaClass("M_QUAL") || isMetaClass("DEPARE") {
+		t.Error("Unexpected meta classification")
+	}
+}
@@ -0,0 +1,35 @@
+package s57
+
+// QualityAt returns the zone of confidence (CATZOC) at a position.
+//
+// Data quality is encoded in M_QUAL meta-features: polygons whose CATZOC
+// attribute rates the survey (1 = A1 ... 5 = D, 6 = U unassessed). QualityAt
+// finds the M_QUAL area containing the point and returns its CATZOC so a UI
+// can overlay ZOC symbols. Returns false if no M_QUAL area covers the point
+// or the area has no CATZOC value.
+//
+// Example:
+//
+//	if zoc, ok := chart.QualityAt(-76.45, 38.98); ok && zoc >= 4 {
+//	    fmt.Println("Poor survey quality - navigate with caution")
+//	}
+func (c *Chart) QualityAt(lon, lat float64) (catzoc int, ok bool) {
+	lonEps, latEps := pointEpsilon(lat)
+	probe := Bounds{MinLon: lon - lonEps, MaxLon: lon + lonEps, MinLat: lat - latEps, MaxLat: lat + latEps}
+
+	c.EachFeatureInBounds(probe, func(f Feature) bool {
+		if f.objectClass != "M_QUAL" || f.geometry.Type != GeometryTypePolygon {
+			return true
+		}
+		if !pointInPolygon(lon, lat, f.geometry.Coordinates) {
+			return true
+		}
+		value, found := attributeFloat(f, "CATZOC")
+		if !found {
+			return true
+		}
+		catzoc, ok = int(value), true
+		return false
+	})
+	return catzoc, ok
+}
@@ -0,0 +1,43 @@
+package s57
+
+import (
+	"testing"
+)
+
+// TestQualityAt tests CATZOC lookup across multiple M_QUAL zones
+func TestQualityAt(t *testing.T) {
+	zone := func(id int64, x float64, catzoc string) Feature {
+		return Feature{
+			id:          id,
+			objectClass: "M_QUAL",
+			geometry:    square(x, 38),
+			attributes:  map[string]interface{}{"CATZOC": catzoc},
+		}
+	}
+
+	chart := newTestChart([]Feature{
+		zone(1, -77, "2"), // Zone A
+		zone(2, -76, "4"), // Zone C
+		{id: 3, objectClass: "DEPARE", geometry: square(-75, 38),
+			attributes: map[string]interface{}{"DRVAL1": "0"}},
+	})
+
+	tests := []struct {
+		lon, lat float64
+		catzoc   int
+		ok       bool
+	}{
+		{-76.5, 38.5, 2, true},
+		{-75.5, 38.2, 4, true},
+		{-74.5, 38.5, 0, false}, // Only a depth area here
+		{-80.0, 38.5, 0, false}, // Off chart
+	}
+
+	for _, tt := range tests {
+		catzoc, ok := chart.QualityAt(tt.lon, tt.lat)
+		if catzoc != tt.catzoc || ok != tt.ok {
+			t.Errorf("QualityAt(%v, %v) = %d, %v; want %d, %v",
+				tt.lon, tt.lat, catzoc, ok, tt.catzoc, tt.ok)
+		}
+	}
+}
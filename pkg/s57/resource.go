@@ -0,0 +1,86 @@
+package s57
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// resourceSource is where a chart's companion files (text and pictures) live:
+// the directory of the base cell within the filesystem it was read from.
+type resourceSource struct {
+	fsys fs.FS
+	dir  string // Slash-separated directory of the cell within fsys
+}
+
+// diskSource returns the resource source for a cell parsed from disk
+func diskSource(filename string) resourceSource {
+	return resourceSource{fsys: os.DirFS(filepath.Dir(filename)), dir: "."}
+}
+
+// Resource returns the contents of a companion file of the exchange set, such
+// as the text file named by a TXTDSC or NTXTDS attribute or the picture named
+// by PICREP.
+//
+// Names are resolved relative to the directory of the base cell, in the same
+// filesystem the chart was read from: the cell's directory on disk for Parse,
+// or the cell's directory within fsys (e.g. the same zip archive) for ParseFS.
+// Lookup order:
+//
+//  1. The name exactly as given
+//  2. A file in the cell's directory whose name matches ignoring case, since
+//     exchange sets written on case-insensitive systems often differ in case
+//     from the names the attributes carry
+//
+// Names must be relative and may not leave the cell's directory. A file that
+// is absent from the exchange set returns an error wrapping
+// ErrResourceNotFound. For ParseFS charts the filesystem must remain readable
+// (a zip archive open) while resources are requested.
+//
+// Example:
+//
+//	if name, ok := feature.Attribute("TXTDSC"); ok {
+//	    text, err := chart.Resource(name.(string))
+//	    if err == nil {
+//	        fmt.Println(string(text))
+//	    }
+//	}
+func (c *Chart) Resource(name string) ([]byte, error) {
+	if c.released {
+		return nil, ErrChartReleased
+	}
+	if c.source.fsys == nil {
+		return nil, fmt.Errorf("%w: %s (chart has no source directory)", ErrResourceNotFound, name)
+	}
+
+	name = strings.TrimSpace(strings.ReplaceAll(name, "\\", "/"))
+	if name == "" || !fs.ValidPath(name) {
+		return nil, fmt.Errorf("invalid resource name %q", name)
+	}
+
+	fullName := path.Join(c.source.dir, name)
+	data, err := fs.ReadFile(c.source.fsys, fullName)
+	if err == nil {
+		return data, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	// Fall back to a case-insensitive match in the same directory
+	entries, err := fs.ReadDir(c.source.fsys, path.Dir(fullName))
+	if err == nil {
+		base := path.Base(fullName)
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.EqualFold(entry.Name(), base) {
+				return fs.ReadFile(c.source.fsys, path.Join(path.Dir(fullName), entry.Name()))
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, name)
+}
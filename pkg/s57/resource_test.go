@@ -0,0 +1,137 @@
+package s57
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestResource tests reading companion text files from disk and from a zip
+func TestResource(t *testing.T) {
+	want, err := os.ReadFile(filepath.Join(filepath.Dir(testChartPath), "US4MD81A.TXT"))
+	if err != nil {
+		t.Fatalf("Failed to read test text file: %v", err)
+	}
+
+	chart, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	// The M_NPUB feature names the text file in TXTDSC
+	var name string
+	for _, f := range chart.Features() {
+		if v, ok := f.Attribute("TXTDSC"); ok && f.ObjectClass() == "M_NPUB" {
+			name = v.(string)
+		}
+	}
+	if name != "US4MD81A.TXT" {
+		t.Fatalf("Expected M_NPUB TXTDSC US4MD81A.TXT, got %q", name)
+	}
+
+	for _, n := range []string{name, strings.ToLower(name)} {
+		data, err := chart.Resource(n)
+		if err != nil {
+			t.Errorf("Resource(%q) failed: %v", n, err)
+		} else if !bytes.Equal(data, want) {
+			t.Errorf("Resource(%q) returned different contents", n)
+		}
+	}
+
+	if _, err := chart.Resource("MISSING.TXT"); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("Expected ErrResourceNotFound for absent file, got %v", err)
+	}
+	if _, err := chart.Resource("../US4MD81M/US4MD81A.TXT"); err == nil {
+		t.Error("Expected error for a name outside the cell directory")
+	}
+
+	// A zip-streamed exchange set reads the sibling entry from the archive
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, file := range []string{"US4MD81M.000", "US4MD81A.TXT"} {
+		data, err := os.ReadFile(filepath.Join(filepath.Dir(testChartPath), file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w, err := zw.Create("ENC_ROOT/US4MD81M/" + file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zipped, err := NewParser().ParseFS(zr, "ENC_ROOT/US4MD81M/US4MD81M.000")
+	if err != nil {
+		t.Fatalf("Failed to parse chart from zip: %v", err)
+	}
+	data, err := zipped.Resource(name)
+	if err != nil {
+		t.Fatalf("Resource from zip failed: %v", err)
+	}
+	if !bytes.Equal(data, want) {
+		t.Error("Resource from zip returned different contents")
+	}
+	if _, err := zipped.Resource("US4MD81B.TXT"); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("Expected ErrResourceNotFound for file missing from zip, got %v", err)
+	}
+
+	// A filesystem wrapping fs.ErrNotExist still gets the case-insensitive match
+	wrapped, err := NewParser().ParseFS(wrappedNotExistFS{os.DirFS(filepath.Dir(testChartPath))}, "US4MD81M.000")
+	if err != nil {
+		t.Fatalf("Failed to parse chart from wrapping filesystem: %v", err)
+	}
+	if data, err := wrapped.Resource(strings.ToLower(name)); err != nil || !bytes.Equal(data, want) {
+		t.Errorf("Resource(%q) from wrapping filesystem failed: %v", strings.ToLower(name), err)
+	}
+}
+
+// wrappedNotExistFS reports missing files with a wrapped fs.ErrNotExist
+// rather than an *fs.PathError, as fs.FS implementations may
+type wrappedNotExistFS struct{ fs.FS }
+
+func (w wrappedNotExistFS) Open(name string) (fs.File, error) {
+	f, err := w.FS.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("no entry %s: %w", name, fs.ErrNotExist)
+	}
+	return f, err
+}
+
+// TestParseFSWithOptions tests that options apply to charts read from an fs.FS
+func TestParseFSWithOptions(t *testing.T) {
+	fsys := os.DirFS(filepath.Dir(testChartPath))
+
+	opts := DefaultParseOptions()
+	opts.ApplyUpdates = false
+	opts.ObjectClassFilter = []string{"LIGHTS"}
+	chart, err := NewParser().ParseFSWithOptions(fsys, "US4MD81M.000", opts)
+	if err != nil {
+		t.Fatalf("Failed to parse chart from fs.FS: %v", err)
+	}
+	if chart.UpdateNumber() != "0" {
+		t.Errorf("Expected updates skipped, got update %s", chart.UpdateNumber())
+	}
+	if chart.FeatureCount() == 0 {
+		t.Fatal("Expected LIGHTS features")
+	}
+	for _, f := range chart.Features() {
+		if f.ObjectClass() != "LIGHTS" {
+			t.Fatalf("Filter kept %s feature %d", f.ObjectClass(), f.ID())
+		}
+	}
+}
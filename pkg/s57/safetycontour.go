@@ -0,0 +1,183 @@
+package s57
+
+import (
+	"sort"
+	"strconv"
+)
+
+// shallowSideClasses are areas that count as shallow water for the safety
+// contour regardless of depth: a boundary between deep water and land or
+// unsurveyed water is part of the safety contour.
+var shallowSideClasses = map[string]bool{
+	"LNDARE": true, // Land area
+	"UNSARE": true, // Unsurveyed area
+}
+
+// SafetyContour returns the line features forming the effective safety
+// contour for the given safety depth.
+//
+// Mariners choose a safety depth that rarely matches a charted DEPCNT value,
+// so per S-52 (conditional procedure DEPARE) the safety contour is synthesized
+// from the depth areas: a DEPARE or DRGARE is deep if its DRVAL1 is greater
+// than or equal to the safety depth, and the contour is every boundary shared
+// between a deep area and a shallow area, land (LNDARE) or unsurveyed water
+// (UNSARE). This places the contour on the next-deeper available depth
+// contour.
+//
+// The returned features are synthetic DEPCNT lines, one per connected run of
+// boundary, with VALDCO set to the depth of the contour they follow. Shared
+// boundaries are found by exact coordinate match, which holds for polygons
+// built from the same S-57 edges. Cell edges are not part of the contour.
+//
+// Example:
+//
+//	for _, line := range chart.SafetyContour(8.0) {
+//	    drawBold(line.Geometry())
+//	}
+func (c *Chart) SafetyContour(depth float64) []Feature {
+	const shallowSide = -1.0 // Deep-side marker for segments owned by shallow areas
+
+	// Record, for every polygon segment, the deep-area DRVAL1 or shallowSide
+	sides := make(map[segmentKey][]float64)
+	directed := make(map[segmentKey][2][]float64)
+	for _, f := range c.features {
+		if f.geometry.Type != GeometryTypePolygon {
+			continue
+		}
+
+		side := shallowSide
+		switch {
+		case depthAreaClasses[f.objectClass]:
+			if drval1, ok := attributeFloat(f, "DRVAL1"); ok && drval1 >= depth {
+				side = drval1
+			}
+		case !shallowSideClasses[f.objectClass]:
+			continue
+		}
+
+		for _, ring := range polygonRings(f.geometry.Coordinates) {
+			for i := 0; i+1 < len(ring); i++ {
+				a, b := ring[i], ring[i+1]
+				if a[0] == b[0] && a[1] == b[1] {
+					continue
+				}
+				key := newSegmentKey(a, b)
+				sides[key] = append(sides[key], side)
+				directed[key] = [2][]float64{a, b}
+			}
+		}
+	}
+
+	// Keep segments with a deep area on one side and shallow water on the other
+	byContour := make(map[float64][]segmentKey)
+	for key, s := range sides {
+		deepest, shallow := shallowSide, false
+		for _, side := range s {
+			if side == shallowSide {
+				shallow = true
+			} else if deepest == shallowSide || side < deepest {
+				deepest = side
+			}
+		}
+		if shallow && deepest != shallowSide {
+			byContour[deepest] = append(byContour[deepest], key)
+		}
+	}
+
+	contours := make([]float64, 0, len(byContour))
+	for valdco := range byContour {
+		contours = append(contours, valdco)
+	}
+	sort.Float64s(contours)
+
+	result := make([]Feature, 0)
+	for _, valdco := range contours {
+		for _, line := range chainSegments(byContour[valdco], directed) {
+			result = append(result, Feature{
+				objectClass: "DEPCNT",
+				geometry:    Geometry{Type: GeometryTypeLineString, Coordinates: line},
+				attributes: map[string]interface{}{
+					"VALDCO": strconv.FormatFloat(valdco, 'f', -1, 64),
+				},
+			})
+		}
+	}
+	return result
+}
+
+// chainSegments joins undirected segments into polylines at shared endpoints.
+// Chains break where more than two segments meet.
+func chainSegments(keys []segmentKey, directed map[segmentKey][2][]float64) [][][]float64 {
+	type point struct{ x, y float64 }
+
+	// Sort for deterministic output
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.x1 != b.x1 {
+			return a.x1 < b.x1
+		}
+		if a.y1 != b.y1 {
+			return a.y1 < b.y1
+		}
+		if a.x2 != b.x2 {
+			return a.x2 < b.x2
+		}
+		return a.y2 < b.y2
+	})
+
+	incident := make(map[point][]int)
+	for i, key := range keys {
+		incident[point{key.x1, key.y1}] = append(incident[point{key.x1, key.y1}], i)
+		incident[point{key.x2, key.y2}] = append(incident[point{key.x2, key.y2}], i)
+	}
+
+	used := make([]bool, len(keys))
+	// extend walks from p along unused segments while the chain is unambiguous
+	extend := func(p point) [][]float64 {
+		var coords [][]float64
+		for len(incident[p]) == 2 {
+			next := -1
+			for _, i := range incident[p] {
+				if !used[i] {
+					next = i
+				}
+			}
+			if next < 0 {
+				break
+			}
+			used[next] = true
+			key := keys[next]
+			if key.x1 == p.x && key.y1 == p.y {
+				p = point{key.x2, key.y2}
+			} else {
+				p = point{key.x1, key.y1}
+			}
+			coords = append(coords, []float64{p.x, p.y})
+		}
+		return coords
+	}
+
+	var lines [][][]float64
+	for i, key := range keys {
+		if used[i] {
+			continue
+		}
+		used[i] = true
+		seg := directed[key]
+		start := point{seg[0][0], seg[0][1]}
+		end := point{seg[1][0], seg[1][1]}
+
+		// Grow forward from the end, then backward from the start
+		forward := extend(end)
+		backward := extend(start)
+
+		line := make([][]float64, 0, len(backward)+len(forward)+2)
+		for j := len(backward) - 1; j >= 0; j-- {
+			line = append(line, backward[j])
+		}
+		line = append(line, []float64{start.x, start.y}, []float64{end.x, end.y})
+		line = append(line, forward...)
+		lines = append(lines, line)
+	}
+	return lines
+}
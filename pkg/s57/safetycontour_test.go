@@ -0,0 +1,69 @@
+package s57
+
+import (
+	"testing"
+)
+
+// TestSafetyContour tests synthesizing the safety contour from depth bands
+func TestSafetyContour(t *testing.T) {
+	band := func(id int64, x float64, drval1, drval2 string) Feature {
+		return Feature{
+			id:          id,
+			objectClass: "DEPARE",
+			geometry:    square(x, 0),
+			attributes:  map[string]interface{}{"DRVAL1": drval1, "DRVAL2": drval2},
+		}
+	}
+
+	// Land, then bands deepening offshore: 0-2, 2-5, 5-10, 10-20 m
+	chart := newTestChart([]Feature{
+		{id: 1, objectClass: "LNDARE", geometry: square(-1, 0), attributes: map[string]interface{}{}},
+		band(2, 0, "0", "2"),
+		band(3, 1, "2", "5"),
+		band(4, 2, "5", "10"),
+		band(5, 3, "10", "20"),
+	})
+
+	tests := []struct {
+		name   string
+		safety float64
+		x      float64 // Expected longitude of the contour
+		valdco string
+	}{
+		{"between charted contours", 6, 3, "10"},
+		{"on a charted contour", 5, 2, "5"},
+		{"shallower than all bands", 0, 0, "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := chart.SafetyContour(tt.safety)
+			if len(lines) != 1 {
+				t.Fatalf("Expected 1 contour line, got %d: %v", len(lines), lines)
+			}
+
+			line := lines[0]
+			if line.ObjectClass() != "DEPCNT" || line.Geometry().Type != GeometryTypeLineString {
+				t.Errorf("Expected DEPCNT line, got %s %v", line.ObjectClass(), line.Geometry().Type)
+			}
+			if valdco, _ := line.Attribute("VALDCO"); valdco != tt.valdco {
+				t.Errorf("Expected VALDCO %s, got %v", tt.valdco, valdco)
+			}
+
+			coords := line.Geometry().Coordinates
+			if len(coords) != 2 {
+				t.Fatalf("Expected a single segment, got %v", coords)
+			}
+			for _, coord := range coords {
+				if coord[0] != tt.x {
+					t.Errorf("Expected contour at lon %v, got %v", tt.x, coords)
+				}
+			}
+		})
+	}
+
+	// No deep water at all means no safety contour
+	if lines := chart.SafetyContour(30); len(lines) != 0 {
+		t.Errorf("Expected no contour deeper than all bands, got %v", lines)
+	}
+}
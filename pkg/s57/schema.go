@@ -0,0 +1,147 @@
+package s57
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AttributeType is the value type inferred for an attribute from the values
+// observed in a chart.
+type AttributeType int
+
+const (
+	// AttributeTypeInteger - every value is a whole number (e.g., SCAMIN, CATLAM).
+	AttributeTypeInteger AttributeType = iota
+
+	// AttributeTypeFloat - every value is numeric and at least one has a
+	// fractional part (e.g., DRVAL1, VALSOU).
+	AttributeTypeFloat
+
+	// AttributeTypeIntegerList - values are comma-separated whole numbers, as
+	// used by S-57 list attributes (e.g., COLOUR "1,3").
+	AttributeTypeIntegerList
+
+	// AttributeTypeString - free text (e.g., OBJNAM), or values of mixed types.
+	AttributeTypeString
+)
+
+// String returns the human-readable name of the attribute type.
+func (t AttributeType) String() string {
+	switch t {
+	case AttributeTypeInteger:
+		return "Integer"
+	case AttributeTypeFloat:
+		return "Float"
+	case AttributeTypeIntegerList:
+		return "IntegerList"
+	default:
+		return "String"
+	}
+}
+
+// AttributeColumn describes one attribute observed on an object class.
+type AttributeColumn struct {
+	// Name is the attribute acronym, e.g. "DRVAL1".
+	Name string
+
+	// Type is the narrowest type that fits every observed value.
+	Type AttributeType
+
+	// Count is the number of features of the class that carry the attribute.
+	Count int
+}
+
+// AttributeSchema returns, for each object class present in the chart, the
+// attributes observed on its features and their inferred types.
+//
+// Use it to generate columns before exporting features to a tabular format:
+// each class maps to its columns sorted by name. Types are inferred from the
+// values actually present, widening as needed (Integer and Float give Float,
+// Integer and IntegerList give IntegerList, anything else gives String). The
+// derived DEPTHS attribute of SOUNDG features is not included, since it is
+// computed from geometry rather than parsed.
+//
+// The schema is computed from the features on each call.
+//
+// Example:
+//
+//	for class, columns := range chart.AttributeSchema() {
+//	    for _, col := range columns {
+//	        fmt.Printf("%s.%s %s\n", class, col.Name, col.Type)
+//	    }
+//	}
+func (c *Chart) AttributeSchema() map[string][]AttributeColumn {
+	columnsByClass := make(map[string]map[string]*AttributeColumn)
+	for _, feature := range c.features {
+		columns, ok := columnsByClass[feature.objectClass]
+		if !ok {
+			columns = make(map[string]*AttributeColumn)
+			columnsByClass[feature.objectClass] = columns
+		}
+
+		for name, value := range feature.attributes {
+			valueType := inferAttributeType(value)
+			column, ok := columns[name]
+			if !ok {
+				columns[name] = &AttributeColumn{Name: name, Type: valueType, Count: 1}
+				continue
+			}
+			column.Type = widenAttributeType(column.Type, valueType)
+			column.Count++
+		}
+	}
+
+	schema := make(map[string][]AttributeColumn, len(columnsByClass))
+	for class, columns := range columnsByClass {
+		result := make([]AttributeColumn, 0, len(columns))
+		for _, column := range columns {
+			result = append(result, *column)
+		}
+		sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+		schema[class] = result
+	}
+	return schema
+}
+
+// inferAttributeType returns the narrowest type for a single attribute value.
+func inferAttributeType(value interface{}) AttributeType {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return AttributeTypeInteger
+	case float32, float64:
+		return AttributeTypeFloat
+	case string:
+		s := strings.TrimSpace(v)
+		if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return AttributeTypeInteger
+		}
+		if _, err := strconv.ParseFloat(s, 64); err == nil {
+			return AttributeTypeFloat
+		}
+		if strings.Contains(s, ",") {
+			for _, item := range strings.Split(s, ",") {
+				if _, err := strconv.ParseInt(strings.TrimSpace(item), 10, 64); err != nil {
+					return AttributeTypeString
+				}
+			}
+			return AttributeTypeIntegerList
+		}
+	}
+	return AttributeTypeString
+}
+
+// widenAttributeType returns the narrowest type that holds values of both types.
+func widenAttributeType(a, b AttributeType) AttributeType {
+	if a == b {
+		return a
+	}
+	if a > b {
+		a, b = b, a
+	}
+	// Integers widen to floats or lists; any other mix only fits text
+	if a == AttributeTypeInteger && (b == AttributeTypeFloat || b == AttributeTypeIntegerList) {
+		return b
+	}
+	return AttributeTypeString
+}
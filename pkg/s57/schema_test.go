@@ -0,0 +1,84 @@
+package s57
+
+import "testing"
+
+// TestAttributeSchema tests attribute discovery on the test cell
+func TestAttributeSchema(t *testing.T) {
+	chart, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	schema := chart.AttributeSchema()
+	for _, class := range chart.ObjectClasses() {
+		if _, ok := schema[class]; !ok {
+			t.Errorf("Schema is missing object class %s", class)
+		}
+	}
+
+	columns := make(map[string]AttributeColumn)
+	for _, col := range schema["DEPARE"] {
+		columns[col.Name] = col
+	}
+	for _, name := range []string{"DRVAL1", "DRVAL2"} {
+		col, ok := columns[name]
+		if !ok {
+			t.Errorf("DEPARE schema is missing %s: %v", name, schema["DEPARE"])
+			continue
+		}
+		if col.Type != AttributeTypeInteger && col.Type != AttributeTypeFloat {
+			t.Errorf("DEPARE.%s type = %v, expected numeric", name, col.Type)
+		}
+		if col.Count == 0 {
+			t.Errorf("DEPARE.%s count = 0", name)
+		}
+	}
+}
+
+// TestAttributeSchemaInference tests type inference and widening
+func TestAttributeSchemaInference(t *testing.T) {
+	chart := &Chart{features: []Feature{
+		{id: 1, objectClass: "BOYLAT", attributes: map[string]interface{}{
+			"CATLAM": "1", "COLOUR": "3", "OBJNAM": "R 2", "VALSOU": "4"}},
+		{id: 2, objectClass: "BOYLAT", attributes: map[string]interface{}{
+			"CATLAM": "2", "COLOUR": "3,1", "OBJNAM": "G 1", "VALSOU": "4.5"}},
+		{id: 3, objectClass: "BOYLAT", attributes: map[string]interface{}{
+			"COLOUR": "4.5"}},
+		{id: 4, objectClass: "DEPARE", attributes: map[string]interface{}{
+			"DRVAL1": 10.0}},
+	}}
+
+	schema := chart.AttributeSchema()
+	expected := map[string][]AttributeColumn{
+		"BOYLAT": {
+			{Name: "CATLAM", Type: AttributeTypeInteger, Count: 2},
+			{Name: "COLOUR", Type: AttributeTypeString, Count: 3},
+			{Name: "OBJNAM", Type: AttributeTypeString, Count: 2},
+			{Name: "VALSOU", Type: AttributeTypeFloat, Count: 2},
+		},
+		"DEPARE": {
+			{Name: "DRVAL1", Type: AttributeTypeFloat, Count: 1},
+		},
+	}
+
+	if len(schema) != len(expected) {
+		t.Fatalf("Expected %d classes, got %v", len(expected), schema)
+	}
+	for class, want := range expected {
+		got := schema[class]
+		if len(got) != len(want) {
+			t.Errorf("%s: expected %v, got %v", class, want, got)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s column %d: expected %+v, got %+v", class, i, want[i], got[i])
+			}
+		}
+	}
+
+	// Integer and IntegerList widen to IntegerList
+	if got := widenAttributeType(AttributeTypeInteger, AttributeTypeIntegerList); got != AttributeTypeIntegerList {
+		t.Errorf("widen(Integer, IntegerList) = %v", got)
+	}
+}
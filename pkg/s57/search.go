@@ -0,0 +1,74 @@
+package s57
+
+import (
+	"sort"
+	"strings"
+)
+
+// searchTextAttributes are the free-text attributes SearchText matches.
+var searchTextAttributes = []string{"INFORM", "NINFOM", "OBJNAM", "NOBJNM"}
+
+// searchFileAttributes name companion text files whose content SearchText
+// matches (see Resource).
+var searchFileAttributes = []string{"TXTDSC", "NTXTDS"}
+
+// SearchText returns the features whose textual attributes contain query,
+// ignoring case.
+//
+// INFORM, NINFOM, OBJNAM and NOBJNM are searched, as is the content of the
+// text files named by TXTDSC and NTXTDS when the chart's exchange set
+// provides them (files that cannot be read are skipped). Results are sorted
+// by feature ID. An empty query matches nothing.
+//
+// Example:
+//
+//	for _, f := range chart.SearchText("pilot") {
+//	    fmt.Println(f.ObjectClass(), f.ID())
+//	}
+func (c *Chart) SearchText(query string) []Feature {
+	result := make([]Feature, 0)
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return result
+	}
+
+	// Text files are shared by many features; read each once per search
+	files := make(map[string]string)
+	fileText := func(name string) string {
+		text, ok := files[name]
+		if !ok {
+			if data, err := c.Resource(name); err == nil {
+				text = strings.ToLower(string(data))
+			}
+			files[name] = text
+		}
+		return text
+	}
+
+	for _, feature := range c.features {
+		if featureMatchesText(feature, query, fileText) {
+			result = append(result, feature)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].id < result[j].id })
+	return result
+}
+
+// featureMatchesText reports whether a feature's text attributes or referenced
+// text files contain the lower-cased query.
+func featureMatchesText(feature Feature, query string, fileText func(string) string) bool {
+	for _, name := range searchTextAttributes {
+		if value, ok := feature.attributes[name].(string); ok && strings.Contains(strings.ToLower(value), query) {
+			return true
+		}
+	}
+	for _, name := range searchFileAttributes {
+		if value, ok := feature.attributes[name].(string); ok && value != "" {
+			if strings.Contains(fileText(value), query) {
+				return true
+			}
+		}
+	}
+	return false
+}
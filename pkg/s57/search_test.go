@@ -0,0 +1,61 @@
+package s57
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSearchText tests keyword search over OBJNAM and TXTDSC content
+func TestSearchText(t *testing.T) {
+	chart, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	// Pick a word from some feature's OBJNAM
+	var target Feature
+	var word string
+	for _, f := range chart.Features() {
+		if name, ok := f.Attribute("OBJNAM"); ok {
+			if fields := strings.Fields(name.(string)); len(fields) > 0 && len(fields[0]) > 3 {
+				target, word = f, fields[0]
+				break
+			}
+		}
+	}
+	if word == "" {
+		t.Fatal("Test chart has no named features")
+	}
+
+	results := chart.SearchText(strings.ToUpper(word))
+	found := false
+	for i, f := range results {
+		if f.FeatureID() == target.FeatureID() {
+			found = true
+		}
+		if i > 0 && results[i-1].ID() > f.ID() {
+			t.Errorf("Results not sorted by ID at %d", i)
+		}
+	}
+	if !found {
+		t.Errorf("SearchText(%q) did not return feature %d named %v", word, target.ID(), target.Attributes()["OBJNAM"])
+	}
+
+	// TXTDSC content: M_NPUB references US4MD81A.TXT
+	found = false
+	for _, f := range chart.SearchText("cove point to hackett point") {
+		if txt, ok := f.Attribute("TXTDSC"); ok && strings.EqualFold(txt.(string), "US4MD81A.TXT") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected a match through the content of US4MD81A.TXT")
+	}
+
+	if got := chart.SearchText("  "); len(got) != 0 {
+		t.Errorf("Empty query matched %d features", len(got))
+	}
+	if got := chart.SearchText("no such text anywhere xyzzy"); len(got) != 0 {
+		t.Errorf("Expected no matches, got %d", len(got))
+	}
+}
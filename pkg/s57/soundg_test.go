@@ -67,3 +67,83 @@ func TestSOUNDGWith3DCoordinates(t *testing.T) {
 		t.Error("Expected at least some coordinates with Z value (depth)")
 	}
 }
+
+func TestSOUNDGDepthsAccessor(t *testing.T) {
+	parser := s57.NewParser()
+	chart, err := parser.Parse("../../test/US4MD81M/US4MD81M.000")
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	var soundg *s57.Feature
+	for _, f := range chart.Features() {
+		if f.ObjectClass() == "SOUNDG" {
+			soundg = &f
+			break
+		}
+	}
+	if soundg == nil {
+		t.Skip("No SOUNDG features found in test chart")
+	}
+
+	depths := soundg.Depths()
+	if len(depths) == 0 {
+		t.Fatal("Expected SOUNDG depths")
+	}
+
+	// DEPTHS is still available as a derived attribute
+	val, ok := soundg.Attribute("DEPTHS")
+	if !ok {
+		t.Fatal("Expected DEPTHS attribute on SOUNDG")
+	}
+	if got := val.([]float64); len(got) != len(depths) {
+		t.Errorf("DEPTHS attribute has %d values, Depths() has %d", len(got), len(depths))
+	}
+
+	// Mutating the returned map must not corrupt the feature
+	attrs := soundg.Attributes()
+	attrs["OBJNAM"] = "mutated"
+	delete(attrs, "DEPTHS")
+	if val, ok := soundg.Attribute("OBJNAM"); ok && val == "mutated" {
+		t.Error("Attributes() returned the feature's internal map")
+	}
+	if _, ok := soundg.Attributes()["DEPTHS"]; !ok {
+		t.Error("Deleting from a returned map removed DEPTHS from the feature")
+	}
+}
+
+func TestSOUNDGWithoutInjectedDepths(t *testing.T) {
+	opts := s57.DefaultParseOptions()
+	opts.InjectSoundingDepths = false
+	chart, err := s57.NewParser().ParseWithOptions("../../test/US4MD81M/US4MD81M.000", opts)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	found := false
+	for _, f := range chart.Features() {
+		if f.ObjectClass() != "SOUNDG" {
+			continue
+		}
+		found = true
+		if _, ok := f.Attributes()["DEPTHS"]; ok {
+			t.Fatalf("SOUNDG %d has DEPTHS in Attributes", f.ID())
+		}
+		if _, ok := f.Attribute("DEPTHS"); ok {
+			t.Fatalf("SOUNDG %d has DEPTHS from Attribute", f.ID())
+		}
+		f.EachAttribute(func(name string, _ interface{}) bool {
+			if name == "DEPTHS" {
+				t.Errorf("SOUNDG %d has DEPTHS from EachAttribute", f.ID())
+			}
+			return true
+		})
+		// Soundings whose nodes were deleted by an update have no coordinates
+		if len(f.Geometry().Coordinates) > 0 && len(f.Depths()) == 0 {
+			t.Errorf("SOUNDG %d lost its depths", f.ID())
+		}
+	}
+	if !found {
+		t.Skip("No SOUNDG features found in test chart")
+	}
+}
@@ -1,5 +1,7 @@
 package s57
 
+import "math"
+
 // Bounds represents a geographic bounding box in WGS-84 coordinates.
 //
 // Coordinates are in decimal degrees.
@@ -16,6 +18,12 @@ func (b Bounds) Contains(lon, lat float64) bool {
 		lat >= b.MinLat && lat <= b.MaxLat
 }
 
+// ContainsBounds returns true if other lies entirely within the bounds.
+func (b Bounds) ContainsBounds(other Bounds) bool {
+	return other.MinLon >= b.MinLon && other.MaxLon <= b.MaxLon &&
+		other.MinLat >= b.MinLat && other.MaxLat <= b.MaxLat
+}
+
 // Intersects returns true if the given bounds intersects with this bounds.
 func (b Bounds) Intersects(other Bounds) bool {
 	return !(other.MaxLon < b.MinLon ||
@@ -58,6 +66,55 @@ func (b Bounds) Union(other Bounds) Bounds {
 	return result
 }
 
+// Area returns the area of the bounds in square degrees.
+//
+// Bounds with MinLon greater than MaxLon cross the antimeridian and span
+// from MinLon east through 180° to MaxLon. Returns 0 for empty or inverted
+// latitude ranges. Square degrees shrink toward the poles, so compare areas of
+// nearby bounds, or use ratios such as IntersectionArea / Area.
+func (b Bounds) Area() float64 {
+	height := b.MaxLat - b.MinLat
+	if height <= 0 {
+		return 0
+	}
+	width := 0.0
+	for _, span := range b.lonSpans() {
+		width += span[1] - span[0]
+	}
+	return width * height
+}
+
+// IntersectionArea returns the area in square degrees shared by both bounds,
+// or 0 if they do not overlap. Bounds crossing the antimeridian are handled
+// as in Area.
+//
+// Example:
+//
+//	// Fraction of the viewport covered by a chart
+//	coverage := viewport.IntersectionArea(chart.Bounds()) / viewport.Area()
+func (b Bounds) IntersectionArea(other Bounds) float64 {
+	height := math.Min(b.MaxLat, other.MaxLat) - math.Max(b.MinLat, other.MinLat)
+	if height <= 0 {
+		return 0
+	}
+	width := 0.0
+	for _, span := range b.lonSpans() {
+		for _, otherSpan := range other.lonSpans() {
+			width += math.Max(0, math.Min(span[1], otherSpan[1])-math.Max(span[0], otherSpan[0]))
+		}
+	}
+	return width * height
+}
+
+// lonSpans returns the longitude ranges covered by the bounds: one range, or
+// two split at the antimeridian when MinLon is greater than MaxLon
+func (b Bounds) lonSpans() [][2]float64 {
+	if b.MinLon <= b.MaxLon {
+		return [][2]float64{{b.MinLon, b.MaxLon}}
+	}
+	return [][2]float64{{b.MinLon, 180}, {-180, b.MaxLon}}
+}
+
 // featureBounds calculates the bounding box for a feature's geometry.
 func featureBounds(f Feature) Bounds {
 	if len(f.geometry.Coordinates) == 0 {
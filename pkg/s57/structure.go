@@ -0,0 +1,43 @@
+package s57
+
+// StructureInfo is the dataset structure declared in the DSSI record.
+//
+// S-57 §7.3.1.2: The DSSI (Data Set Structure Information) field gives the
+// topology level, the lexical levels used for attribute text and the number
+// of records of each kind in the dataset. Counts describe the base cell file;
+// they are not adjusted for applied updates.
+type StructureInfo struct {
+	// DataStructure is the topology level (DSTR): 1 = cartographic
+	// spaghetti, 2 = chain-node, 3 = planar graph, 4 = full topology,
+	// 255 = topology not relevant.
+	DataStructure int
+
+	ATTFLexicalLevel int // AALL: 0 = ASCII, 1 = ISO 8859-1
+	NATFLexicalLevel int // NALL: 0 = ASCII, 1 = ISO 8859-1, 2 = UCS-2
+
+	MetaRecords         int // NOMR: meta feature records (M_COVR, M_QUAL, ...)
+	CartographicRecords int // NOCR: cartographic feature records
+	GeoRecords          int // NOGR: geo feature records
+	CollectionRecords   int // NOLR: collection feature records
+
+	IsolatedNodes  int // NOIN: isolated node records
+	ConnectedNodes int // NOCN: connected node records
+	Edges          int // NOED: edge records
+	Faces          int // NOFA: face records
+}
+
+// FeatureRecords returns the declared number of feature records of all kinds.
+func (s StructureInfo) FeatureRecords() int {
+	return s.MetaRecords + s.CartographicRecords + s.GeoRecords + s.CollectionRecords
+}
+
+// SpatialRecords returns the declared number of spatial records of all kinds.
+func (s StructureInfo) SpatialRecords() int {
+	return s.IsolatedNodes + s.ConnectedNodes + s.Edges + s.Faces
+}
+
+// StructureInfo returns the dataset structure declared in the DSSI record.
+//
+// Compare the declared counts with the parsed features to validate a chart.
+// Returns the zero value if the dataset has no DSSI record.
+func (c *Chart) StructureInfo() StructureInfo { return c.structure }
@@ -0,0 +1,61 @@
+package s57
+
+// ChartSummary is a snapshot of a chart's metadata and feature statistics.
+//
+// It is intended for CLI tools and reports, and marshals cleanly to JSON.
+type ChartSummary struct {
+	DatasetName     string `json:"datasetName"`
+	Edition         string `json:"edition"`
+	UpdateNumber    string `json:"updateNumber"`
+	IssueDate       string `json:"issueDate"`
+	ProducingAgency int    `json:"producingAgency"`
+	Bounds          Bounds `json:"bounds"`
+	FeatureCount    int    `json:"featureCount"`
+
+	// Scaling parameters the parser applied (see Chart.CoordinateFactor).
+	// CoordinateUnits is the CoordinateUnits name, e.g. "Unknown" when the
+	// chart had no usable DSPM record.
+	CoordinateFactor      int32  `json:"coordinateFactor"`
+	SoundingFactor        int32  `json:"soundingFactor"`
+	CoordinateUnits       string `json:"coordinateUnits"`
+	UsedDefaultParameters bool   `json:"usedDefaultParameters"`
+
+	// ObjectClassCounts maps object class codes (e.g., "DEPCNT") to feature counts.
+	ObjectClassCounts map[string]int `json:"objectClassCounts"`
+
+	// GeometryTypeCounts maps geometry type names ("Point", "LineString",
+	// "Polygon") to feature counts.
+	GeometryTypeCounts map[string]int `json:"geometryTypeCounts"`
+}
+
+// Summary returns the chart's metadata and feature counts in a single struct.
+//
+// Example:
+//
+//	summary := chart.Summary()
+//	fmt.Printf("%s: %d features\n", summary.DatasetName, summary.FeatureCount)
+//	json.NewEncoder(os.Stdout).Encode(summary)
+func (c *Chart) Summary() ChartSummary {
+	summary := ChartSummary{
+		DatasetName:           c.datasetName,
+		Edition:               c.edition,
+		UpdateNumber:          c.updateNumber,
+		IssueDate:             c.issueDate,
+		ProducingAgency:       c.producingAgency,
+		Bounds:                c.bounds,
+		FeatureCount:          len(c.features),
+		CoordinateFactor:      c.coordinateFactor,
+		SoundingFactor:        c.soundingFactor,
+		CoordinateUnits:       c.coordinateUnits.String(),
+		UsedDefaultParameters: c.usedDefaultParameters,
+		ObjectClassCounts:     make(map[string]int),
+		GeometryTypeCounts:    make(map[string]int),
+	}
+
+	for _, feature := range c.features {
+		summary.ObjectClassCounts[feature.objectClass]++
+		summary.GeometryTypeCounts[feature.geometry.Type.String()]++
+	}
+
+	return summary
+}
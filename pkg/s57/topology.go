@@ -0,0 +1,74 @@
+package s57
+
+import "github.com/beetlebugorg/s57/internal/parser"
+
+// Topology is the chart's underlying edge/node graph.
+//
+// Feature geometries are materialized from shared edges and nodes (S-57
+// chain-node or full topology). Topology exposes those primitives so advanced
+// renderers can work with shared boundaries directly, e.g. for coastline
+// generalization or styling an edge once for both adjacent areas.
+type Topology struct {
+	Nodes []TopologyNode // Sorted by ID, isolated before connected for equal IDs
+	Edges []TopologyEdge // Sorted by ID
+}
+
+// TopologyNode is a node primitive with the edges that meet at it.
+//
+// Isolated and connected nodes are numbered independently, so an isolated and
+// a connected node may share an ID; Connected tells them apart. Edge StartNode
+// and EndNode refer to connected nodes.
+type TopologyNode struct {
+	ID          int64       // Node record ID (RCID)
+	Connected   bool        // Connected node (RCNM=120); false for isolated nodes (RCNM=110)
+	Coordinates [][]float64 // Position(s) as [lon, lat] or [lon, lat, depth]
+	Edges       []int64     // IDs of edges that start or end at this node
+}
+
+// TopologyEdge is an edge primitive with its bounding nodes and faces.
+type TopologyEdge struct {
+	ID          int64       // Edge record ID (RCID)
+	StartNode   int64       // Beginning node ID, 0 if missing
+	EndNode     int64       // End node ID, 0 if missing
+	LeftFace    int64       // Face on the left, 0 unless the chart has full topology
+	RightFace   int64       // Face on the right, 0 unless the chart has full topology
+	Coordinates [][]float64 // [lon, lat] from start node through end node
+}
+
+// Topology returns the chart's edge/node graph.
+//
+// The topology is only retained when parsing with ParseOptions.RetainTopology;
+// otherwise Topology returns nil. The returned value is shared with the chart
+// and must not be modified.
+func (c *Chart) Topology() *Topology { return c.topology }
+
+// convertTopology converts the internal edge/node graph to the public API
+func convertTopology(internal *parser.Topology) *Topology {
+	topo := &Topology{
+		Nodes: make([]TopologyNode, len(internal.Nodes)),
+		Edges: make([]TopologyEdge, len(internal.Edges)),
+	}
+	for i, n := range internal.Nodes {
+		topo.Nodes[i] = TopologyNode{
+			ID:          n.ID,
+			Connected:   n.Connected,
+			Coordinates: n.Coordinates,
+			Edges:       n.Edges,
+		}
+	}
+	for i, e := range internal.Edges {
+		coords := make([][]float64, len(e.Coordinates))
+		for j, c := range e.Coordinates {
+			coords[j] = []float64{c[0], c[1]}
+		}
+		topo.Edges[i] = TopologyEdge{
+			ID:          e.ID,
+			StartNode:   e.StartNode,
+			EndNode:     e.EndNode,
+			LeftFace:    e.LeftFace,
+			RightFace:   e.RightFace,
+			Coordinates: coords,
+		}
+	}
+	return topo
+}
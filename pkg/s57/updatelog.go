@@ -0,0 +1,35 @@
+package s57
+
+// UpdateSummary records what one update file changed.
+//
+// S-57 Part 3 §8.4: each record of an update file carries an update
+// instruction (RUIN) to insert, delete or modify a feature or spatial record.
+// The summary counts the instructions that were applied; a failed instruction
+// skipped under ContinueOnRecordError is reported in Chart.Warnings instead.
+type UpdateSummary struct {
+	File         string // Path of the update file (e.g. "US5MA22M.001")
+	UpdateNumber string // Update number (UPDN) from the update's DSID
+
+	FeaturesInserted int // Feature records inserted (RUIN=1)
+	FeaturesDeleted  int // Feature records deleted (RUIN=2)
+	FeaturesModified int // Feature records modified (RUIN=3)
+
+	SpatialInserted int // Spatial records inserted (RUIN=1)
+	SpatialDeleted  int // Spatial records deleted (RUIN=2)
+	SpatialModified int // Spatial records modified (RUIN=3)
+}
+
+// UpdateLog returns a summary of each update file applied to the chart, in
+// the order they were applied.
+//
+// Returns nil unless ParseOptions.RecordUpdateLog was set, or when no updates
+// were applied. Update files skipped as stale (see ErrStaleUpdate) do not
+// appear.
+//
+// Example:
+//
+//	for _, u := range chart.UpdateLog() {
+//	    fmt.Printf("update %s: +%d -%d ~%d features\n", u.UpdateNumber,
+//	        u.FeaturesInserted, u.FeaturesDeleted, u.FeaturesModified)
+//	}
+func (c *Chart) UpdateLog() []UpdateSummary { return c.updateLog }
@@ -0,0 +1,206 @@
+package s57
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Severity ranks how serious a validation finding is.
+type Severity int
+
+const (
+	// SeverityWarning - the data is suspect but usable (e.g., an object
+	// outside the cell's coverage).
+	SeverityWarning Severity = iota
+
+	// SeverityError - the data violates the ENC Product Specification and
+	// should be corrected by the producer (e.g., DRVAL1 greater than DRVAL2).
+	SeverityError
+)
+
+// String returns the human-readable name of the severity.
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "Error"
+	}
+	return "Warning"
+}
+
+// Check IDs of the data-quality checks run by ValidateS58.
+const (
+	// CheckMandatoryAttribute - an object lacks an attribute the ENC Product
+	// Specification makes mandatory for its class (e.g., DEPARE without DRVAL1).
+	CheckMandatoryAttribute = "mandatory-attribute"
+
+	// CheckDepthRangeOrder - a depth or dredged area has DRVAL1 greater than
+	// DRVAL2.
+	CheckDepthRangeOrder = "drval-order"
+
+	// CheckOutsideCoverage - an object lies entirely outside the cell's data
+	// coverage (M_COVR with CATCOV=1).
+	CheckOutsideCoverage = "outside-coverage"
+
+	// CheckDuplicateFOID - several feature records share a feature object
+	// identifier (AGEN, FIDN, FIDS).
+	CheckDuplicateFOID = "duplicate-foid"
+)
+
+// ValidationFinding is one problem reported by ValidateS58.
+type ValidationFinding struct {
+	// Check is the ID of the check that failed, e.g. CheckDepthRangeOrder.
+	Check string
+
+	// Severity ranks the finding.
+	Severity Severity
+
+	// Feature is the offending feature. It is the zero Feature for duplicate
+	// records the parser already dropped (see ErrDuplicateFeature).
+	Feature Feature
+
+	// Message describes the problem.
+	Message string
+}
+
+// mandatoryAttributes lists, per object class, the attributes the S-57 ENC
+// Product Specification requires on every object of the class. Only the
+// unconditional requirements most often missed in practice are checked.
+var mandatoryAttributes = map[string][]string{
+	"DEPARE": {"DRVAL1", "DRVAL2"},
+	"DRGARE": {"DRVAL1"},
+	"DEPCNT": {"VALDCO"},
+	"LNDELV": {"ELEVAT"},
+	"TOPMAR": {"TOPSHP"},
+	"M_COVR": {"CATCOV"},
+	"M_CSCL": {"CSCALE"},
+	"M_NSYS": {"MARSYS"},
+	"M_QUAL": {"CATZOC"},
+	"M_SDAT": {"VERDAT"},
+	"M_VDAT": {"VERDAT"},
+}
+
+// ValidateS58 runs a subset of the S-58 ENC validation checks on the chart
+// and returns the problems found, in feature order.
+//
+// The checks are the ones most often failed by real cells:
+//
+//   - CheckMandatoryAttribute: mandatory attributes present for the class
+//   - CheckDepthRangeOrder: DRVAL1 ≤ DRVAL2 on DEPARE and DRGARE
+//   - CheckOutsideCoverage: every object lies at least partly within the
+//     data coverage (M_COVR CATCOV=1), when the chart has coverage areas
+//   - CheckDuplicateFOID: feature object identifiers are unique, including
+//     duplicate records the parser dropped while parsing
+//
+// It is a quality gate for producers and importers, not a replacement for a
+// full S-58 validator. Meta features removed by ExcludeMetaFeatures are still
+// used as coverage and checked for mandatory attributes.
+//
+// Example:
+//
+//	for _, finding := range chart.ValidateS58() {
+//	    if finding.Severity == s57.SeverityError {
+//	        fmt.Printf("%s: %s\n", finding.Check, finding.Message)
+//	    }
+//	}
+func (c *Chart) ValidateS58() []ValidationFinding {
+	var findings []ValidationFinding
+	features := append(append([]Feature{}, c.metaFeatures...), c.features...)
+	coverage := c.dataCoverage()
+
+	seen := make(map[FeatureID]bool, len(features))
+	for _, f := range features {
+		for _, name := range mandatoryAttributes[f.objectClass] {
+			if _, ok := f.attributes[name]; !ok {
+				findings = append(findings, ValidationFinding{
+					Check:    CheckMandatoryAttribute,
+					Severity: SeverityError,
+					Feature:  f,
+					Message:  fmt.Sprintf("%s %d is missing mandatory attribute %s", f.objectClass, f.id, name),
+				})
+			}
+		}
+
+		if f.objectClass == "DEPARE" || f.objectClass == "DRGARE" {
+			drval1, ok1 := attributeFloat(f, "DRVAL1")
+			drval2, ok2 := attributeFloat(f, "DRVAL2")
+			if ok1 && ok2 && drval1 > drval2 {
+				findings = append(findings, ValidationFinding{
+					Check:    CheckDepthRangeOrder,
+					Severity: SeverityError,
+					Feature:  f,
+					Message:  fmt.Sprintf("%s %d has DRVAL1 %g greater than DRVAL2 %g", f.objectClass, f.id, drval1, drval2),
+				})
+			}
+		}
+
+		if len(coverage) > 0 && !isMetaClass(f.objectClass) && !withinCoverage(f.geometry, coverage) {
+			findings = append(findings, ValidationFinding{
+				Check:    CheckOutsideCoverage,
+				Severity: SeverityWarning,
+				Feature:  f,
+				Message:  fmt.Sprintf("%s %d lies outside the data coverage", f.objectClass, f.id),
+			})
+		}
+
+		if foid := f.FeatureID(); foid != (FeatureID{}) {
+			if seen[foid] {
+				findings = append(findings, ValidationFinding{
+					Check:    CheckDuplicateFOID,
+					Severity: SeverityError,
+					Feature:  f,
+					Message:  fmt.Sprintf("%s %d repeats feature object identifier %v", f.objectClass, f.id, foid),
+				})
+			}
+			seen[foid] = true
+		}
+	}
+
+	// Duplicates the parser already resolved are only left as warnings
+	for _, warning := range c.warnings {
+		var duplicate *ErrDuplicateFeature
+		if errors.As(warning, &duplicate) {
+			findings = append(findings, ValidationFinding{
+				Check:    CheckDuplicateFOID,
+				Severity: SeverityError,
+				Message:  duplicate.Error(),
+			})
+		}
+	}
+
+	return findings
+}
+
+// dataCoverage returns the polygons of the chart's M_COVR features with
+// CATCOV=1 (coverage available)
+func (c *Chart) dataCoverage() []Geometry {
+	var coverage []Geometry
+	for _, f := range c.MetaFeatures() {
+		if f.objectClass != "M_COVR" || f.geometry.Type != GeometryTypePolygon {
+			continue
+		}
+		if catcov, ok := attributeFloat(f, "CATCOV"); ok && catcov == 1 {
+			coverage = append(coverage, f.geometry)
+		}
+	}
+	return coverage
+}
+
+// withinCoverage reports whether any vertex of g lies inside, or within about
+// 11 m of the boundary of, one of the coverage polygons. Geometry without
+// coordinates counts as covered.
+func withinCoverage(g Geometry, coverage []Geometry) bool {
+	if len(g.Coordinates) == 0 {
+		return true
+	}
+	for _, coord := range g.Coordinates {
+		lon, lat := coord[0], coord[1]
+		_, tolerance := pointEpsilon(lat)
+		lonScale := math.Cos(lat * math.Pi / 180)
+		for _, area := range coverage {
+			if geometryDistance(area, lon, lat, lonScale) <= tolerance {
+				return true
+			}
+		}
+	}
+	return false
+}
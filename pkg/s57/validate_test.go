@@ -0,0 +1,77 @@
+package s57
+
+import "testing"
+
+// TestValidateS58 tests each check on a synthetic chart
+func TestValidateS58(t *testing.T) {
+	square := func(lon, lat, size float64) Geometry {
+		return Geometry{Type: GeometryTypePolygon, Coordinates: [][]float64{
+			{lon, lat}, {lon + size, lat}, {lon + size, lat + size}, {lon, lat + size}, {lon, lat},
+		}}
+	}
+	point := func(lon, lat float64) Geometry {
+		return Geometry{Type: GeometryTypePoint, Coordinates: [][]float64{{lon, lat}}}
+	}
+	chart := &Chart{features: []Feature{
+		{id: 1, agency: 550, objectClass: "M_COVR", geometry: square(0, 0, 1),
+			attributes: map[string]interface{}{"CATCOV": "1"}},
+		{id: 2, agency: 550, objectClass: "DEPARE", geometry: square(0.1, 0.1, 0.2),
+			attributes: map[string]interface{}{"DRVAL1": "5", "DRVAL2": "2"}},
+		{id: 3, agency: 550, objectClass: "DEPARE", geometry: square(0.4, 0.4, 0.2),
+			attributes: map[string]interface{}{"DRVAL1": "0"}},
+		{id: 4, agency: 550, objectClass: "LNDMRK", geometry: point(5, 5)},
+		{id: 5, agency: 550, objectClass: "DEPARE", geometry: square(0.5, 0.8, 0.2),
+			attributes: map[string]interface{}{"DRVAL1": "2", "DRVAL2": "5"}},
+		{id: 5, agency: 550, objectClass: "LNDMRK", geometry: point(0.5, 0.5)},
+	}}
+
+	findings := chart.ValidateS58()
+	got := make(map[string][]int64)
+	for _, finding := range findings {
+		got[finding.Check] = append(got[finding.Check], finding.Feature.id)
+		if finding.Message == "" {
+			t.Errorf("Finding %s has no message", finding.Check)
+		}
+	}
+	expected := map[string][]int64{
+		CheckDepthRangeOrder:    {2},
+		CheckMandatoryAttribute: {3},
+		CheckOutsideCoverage:    {4},
+		CheckDuplicateFOID:      {5},
+	}
+	if len(got) != len(expected) {
+		t.Errorf("Expected findings %v, got %v", expected, got)
+	}
+	for check, ids := range expected {
+		if len(got[check]) != len(ids) || got[check][0] != ids[0] {
+			t.Errorf("%s: expected features %v, got %v", check, ids, got[check])
+		}
+	}
+	for _, finding := range findings {
+		if finding.Check == CheckOutsideCoverage && finding.Severity != SeverityWarning {
+			t.Errorf("Outside coverage severity = %v, expected Warning", finding.Severity)
+		}
+		if finding.Check == CheckDepthRangeOrder && finding.Severity != SeverityError {
+			t.Errorf("DRVAL order severity = %v, expected Error", finding.Severity)
+		}
+	}
+
+	// A feature crossing the coverage boundary is covered
+	chart.features[3].geometry = Geometry{Type: GeometryTypeLineString, Coordinates: [][]float64{{0.5, 0.5}, {5, 5}}}
+	for _, finding := range chart.ValidateS58() {
+		if finding.Check == CheckOutsideCoverage {
+			t.Errorf("Unexpected finding: %s", finding.Message)
+		}
+	}
+}
+
+// TestValidateS58RealChart tests that the test cell passes every check
+func TestValidateS58RealChart(t *testing.T) {
+	chart, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+	for _, finding := range chart.ValidateS58() {
+		t.Errorf("Unexpected finding %s: %s", finding.Check, finding.Message)
+	}
+}
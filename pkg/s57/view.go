@@ -0,0 +1,102 @@
+package s57
+
+// ChartView is a read-only snapshot of a chart, safe to query from many
+// goroutines at once.
+//
+// A view is the read side of a copy-on-write scheme: render goroutines query
+// the current view while the owner prepares a new chart (for example by
+// parsing a newer edition or update) and then atomically swaps in that chart's
+// view, e.g. with an atomic.Pointer[s57.ChartView]. Queries on the old view
+// keep returning the old data until readers move on.
+//
+// A view shares the feature slice and spatial index of the chart it was taken
+// from; nothing is copied. Treat returned features and their geometry as
+// read-only, since they are shared with the chart and every other reader. A
+// view keeps that memory alive, so Chart.Release does not free a chart while a
+// view of it is still referenced; drop views once they have been replaced.
+type ChartView struct {
+	chart *Chart // Private chart holding the shared features and index
+}
+
+// Snapshot returns a read-only view of the chart's current features and
+// spatial index.
+//
+// Views are cheap: they reference the chart's data instead of copying it.
+// Lazily built caches (object classes, per-class indexes) belong to the view
+// and are safe for concurrent use.
+//
+// Example:
+//
+//	var current atomic.Pointer[s57.ChartView]
+//	current.Store(chart.Snapshot())
+//
+//	// Render goroutines
+//	features := current.Load().FeaturesInBounds(viewport)
+//
+//	// Owner, after parsing an updated chart
+//	current.Store(updated.Snapshot())
+func (c *Chart) Snapshot() *ChartView {
+	return &ChartView{chart: &Chart{
+		features:           c.features,
+		spatialIndex:       c.spatialIndex,
+		bounds:             c.bounds,
+		datasetName:        c.datasetName,
+		edition:            c.edition,
+		updateNumber:       c.updateNumber,
+		updateDate:         c.updateDate,
+		issueDate:          c.issueDate,
+		rtreeMinChildren:   c.rtreeMinChildren,
+		rtreeMaxChildren:   c.rtreeMaxChildren,
+		exactBoundsQueries: c.exactBoundsQueries,
+		released:           c.released,
+	}}
+}
+
+// DatasetName returns the dataset name of the chart the view was taken from.
+func (v *ChartView) DatasetName() string { return v.chart.datasetName }
+
+// Edition returns the edition of the chart the view was taken from.
+func (v *ChartView) Edition() string { return v.chart.edition }
+
+// UpdateNumber returns the update number of the chart the view was taken from.
+func (v *ChartView) UpdateNumber() string { return v.chart.updateNumber }
+
+// Bounds returns the chart's coverage area at the time of the snapshot.
+func (v *ChartView) Bounds() Bounds { return v.chart.bounds }
+
+// Features returns all features in the view. The slice is shared and must
+// not be modified.
+func (v *ChartView) Features() []Feature { return v.chart.features }
+
+// FeatureCount returns the number of features in the view.
+func (v *ChartView) FeatureCount() int { return len(v.chart.features) }
+
+// FeaturesInBounds returns the features that intersect the bounding box
+// (see Chart.FeaturesInBounds).
+func (v *ChartView) FeaturesInBounds(bounds Bounds) []Feature {
+	return v.chart.FeaturesInBounds(bounds)
+}
+
+// FeaturesInBoundsExact returns the features whose true bounds intersect
+// the bounding box (see Chart.FeaturesInBoundsExact).
+func (v *ChartView) FeaturesInBoundsExact(bounds Bounds) []Feature {
+	return v.chart.FeaturesInBoundsExact(bounds)
+}
+
+// FeaturesInBoundsOfClass returns the features of the given object classes
+// that intersect the bounding box (see Chart.FeaturesInBoundsOfClass).
+func (v *ChartView) FeaturesInBoundsOfClass(bounds Bounds, classes ...string) []Feature {
+	return v.chart.FeaturesInBoundsOfClass(bounds, classes...)
+}
+
+// EachFeatureInBounds calls fn for every feature that intersects bounds
+// (see Chart.EachFeatureInBounds).
+func (v *ChartView) EachFeatureInBounds(bounds Bounds, fn func(Feature) bool) {
+	v.chart.EachFeatureInBounds(bounds, fn)
+}
+
+// ObjectClasses returns the sorted object class codes present in the view.
+func (v *ChartView) ObjectClasses() []string { return v.chart.ObjectClasses() }
+
+// HasObjectClass reports whether the view has a feature of the object class.
+func (v *ChartView) HasObjectClass(code string) bool { return v.chart.HasObjectClass(code) }
@@ -0,0 +1,60 @@
+package s57
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestChartViewConcurrent queries views from many goroutines while the owner
+// swaps in new views. Run with -race to check for data races.
+func TestChartViewConcurrent(t *testing.T) {
+	chart, err := NewParser().Parse(testChartPath)
+	if err != nil {
+		t.Fatalf("Failed to parse chart: %v", err)
+	}
+
+	var current atomic.Pointer[ChartView]
+	current.Store(chart.Snapshot())
+
+	viewport := chart.Bounds()
+	expected := len(chart.FeaturesInBounds(viewport))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				view := current.Load()
+				if got := len(view.FeaturesInBounds(viewport)); got != expected {
+					t.Errorf("FeaturesInBounds returned %d features, expected %d", got, expected)
+					return
+				}
+				view.FeaturesInBoundsOfClass(viewport, "DEPCNT", "SOUNDG")
+				view.HasObjectClass("DEPARE")
+				n := 0
+				view.EachFeatureInBounds(viewport, func(Feature) bool {
+					n++
+					return n < 100
+				})
+			}
+		}(i)
+	}
+
+	// Owner swaps in fresh views while readers run
+	for i := 0; i < 10; i++ {
+		current.Store(chart.Snapshot())
+	}
+	wg.Wait()
+
+	// Releasing the chart does not affect an existing view
+	view := current.Load()
+	chart.Release()
+	if view.FeatureCount() == 0 || len(view.FeaturesInBounds(viewport)) != expected {
+		t.Error("View changed after the chart was released")
+	}
+	if chart.Snapshot().FeatureCount() != 0 {
+		t.Error("Snapshot of a released chart should be empty")
+	}
+}